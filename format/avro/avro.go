@@ -0,0 +1,63 @@
+package avro
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// nullDefault is 'null' default value of nullable fields.
+var nullDefault = json.RawMessage("null")
+
+// namePattern is the pattern of avro names and enum symbols.
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Record is an avro record schema.
+type Record struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Doc       string   `json:"doc,omitempty"`
+	Fields    []*Field `json:"fields"`
+}
+
+type Field struct {
+	Name    string          `json:"name"`
+	Type    interface{}     `json:"type"`
+	Doc     string          `json:"doc,omitempty"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// LogicalType is a primitive type annotated with logical type.
+type LogicalType struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+	Precision   uint16 `json:"precision,omitempty"`
+	Scale       uint16 `json:"scale,omitempty"`
+}
+
+type Enum struct {
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols"`
+}
+
+// invalidNameCharPattern is the pattern of characters which are not allowed in avro names.
+var invalidNameCharPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// IsValidName returns true if name can be used as avro name or enum symbol.
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// ToValidName returns avro name converted from name.
+// invalid characters are replaced with '_', and '_' is prepended if name does not start with a letter or '_'.
+func ToValidName(name string) string {
+	if IsValidName(name) {
+		return name
+	}
+	name = invalidNameCharPattern.ReplaceAllString(name, "_")
+	if !IsValidName(name) {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,74 @@
+package avro
+
+import (
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/urfave/cli/v2"
+	"strings"
+)
+
+const (
+	FlagGroups       = "groups"
+	FlagInput        = "input"
+	FlagNamespace    = "namespace"
+	FlagOutput       = "output"
+	FlagPrefix       = "prefix"
+	FlagRemovePrefix = "removePrefix"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	gen := NewGenerator(schema, &Option{
+		PrefixMapper:   common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:    octopus.GetTableFilterFn(c.String(FlagGroups)),
+		RemovePrefixes: strings.Split(c.String(FlagRemovePrefix), ","),
+		Namespace:      c.String(FlagNamespace),
+	})
+
+	return gen.Generate(c.String(FlagOutput))
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate avro schema files to `DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagNamespace,
+		Aliases: []string{"n"},
+		Usage:   "set record namespace",
+		EnvVars: []string{"OCTOPUS_NAMESPACE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"p"},
+		Usage:   "set record name prefix",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"r"},
+		Usage:   "set prefixes to remove from record name. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
+}
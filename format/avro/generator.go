@@ -0,0 +1,211 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// defaultDecimalPrecision is the precision of decimal columns without size.
+const defaultDecimalPrecision = 10
+
+type Option struct {
+	PrefixMapper   *common.PrefixMapper
+	TableFilter    octopus.TableFilterFn
+	RemovePrefixes []string
+	Namespace      string
+}
+
+type Generator struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	return &Generator{
+		schema: schema,
+		option: option,
+	}
+}
+
+// RecordName returns record name from table name.
+// name is converted to valid avro name.
+func (g *Generator) RecordName(table *octopus.Table) string {
+	name := table.ClassName
+	if name == "" {
+		tableName := table.Name
+		for _, prefix := range g.option.RemovePrefixes {
+			tableName = strings.TrimPrefix(tableName, prefix)
+		}
+		name = strcase.ToCamel(tableName)
+
+		if prefixMapper := g.option.PrefixMapper; prefixMapper != nil {
+			if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+				name = prefix + name
+			}
+		}
+	}
+	return ToValidName(name)
+}
+
+// NewRecord returns avro record schema of the table.
+func (g *Generator) NewRecord(table *octopus.Table) *Record {
+	name := g.RecordName(table)
+
+	var fields []*Field
+	fieldNameSet := util.NewStringSet()
+	for _, column := range table.Columns {
+		fieldName := fieldName(column.Name, fieldNameSet)
+		if fieldName != column.Name {
+			log.Printf("Field renamed. %s.%s -> %s", name, column.Name, fieldName)
+		}
+		fieldNameSet.Add(fieldName)
+
+		fieldType := toAvroType(column, name)
+
+		field := &Field{
+			Name: fieldName,
+			Type: fieldType,
+			Doc:  column.Description,
+		}
+		if !column.NotNull {
+			field.Type = []interface{}{"null", fieldType}
+			field.Default = nullDefault
+		}
+		fields = append(fields, field)
+	}
+
+	return &Record{
+		Type:      "record",
+		Name:      name,
+		Namespace: g.option.Namespace,
+		Doc:       table.Description,
+		Fields:    fields,
+	}
+}
+
+// fieldName returns valid avro name of column, which is not in fieldNameSet.
+// number suffix is appended if name is already used.
+func fieldName(columnName string, fieldNameSet *util.StringSet) string {
+	name := ToValidName(columnName)
+	result := name
+	for i := 2; fieldNameSet.Contains(result); i++ {
+		result = fmt.Sprintf("%s_%d", name, i)
+	}
+	return result
+}
+
+// toAvroType returns avro type of column.
+// recordName is used to name enum types.
+func toAvroType(column *octopus.Column, recordName string) interface{} {
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeBoolean:
+		return "boolean"
+	case octopus.ColTypeBit:
+		if column.Size == 1 {
+			return "boolean"
+		}
+		return "long"
+	case octopus.ColTypeInt8:
+		fallthrough
+	case octopus.ColTypeInt16:
+		fallthrough
+	case octopus.ColTypeInt24:
+		fallthrough
+	case octopus.ColTypeInt32:
+		fallthrough
+	case octopus.ColTypeYear:
+		return "int"
+	case octopus.ColTypeInt64:
+		return "long"
+	case octopus.ColTypeDecimal:
+		precision := column.Size
+		if precision == 0 {
+			precision = defaultDecimalPrecision
+		}
+		return &LogicalType{
+			Type:        "bytes",
+			LogicalType: "decimal",
+			Precision:   precision,
+			Scale:       column.Scale,
+		}
+	case octopus.ColTypeFloat:
+		return "float"
+	case octopus.ColTypeDouble:
+		return "double"
+	case octopus.ColTypeDateTime:
+		return &LogicalType{Type: "long", LogicalType: "timestamp-millis"}
+	case octopus.ColTypeDate:
+		return &LogicalType{Type: "int", LogicalType: "date"}
+	case octopus.ColTypeTime:
+		return &LogicalType{Type: "int", LogicalType: "time-millis"}
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		return "bytes"
+	case octopus.ColTypeEnum:
+		if len(column.Values) == 0 {
+			return "string"
+		}
+		for _, value := range column.Values {
+			if !IsValidName(value) {
+				log.Printf("Enum type skipped. %s.%s has invalid symbol: '%s'", recordName, column.Name, value)
+				return "string"
+			}
+		}
+		return &Enum{
+			Type:    "enum",
+			Name:    ToValidName(recordName + strcase.ToCamel(column.Name)),
+			Symbols: column.Values,
+		}
+	default:
+		return "string"
+	}
+}
+
+// GenerateRecord writes avro schema of the table.
+func (g *Generator) GenerateRecord(wr io.Writer, table *octopus.Table) error {
+	encoder := json.NewEncoder(wr)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g.NewRecord(table))
+}
+
+// Generate writes '{record name}.avsc' file of each table to outputDir.
+func (g *Generator) Generate(outputDir string) error {
+	if _, err := util.Mkdir(outputDir); err != nil {
+		return err
+	}
+
+	for _, table := range g.schema.Tables {
+		if g.option.TableFilter != nil && !g.option.TableFilter(table) {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if err := g.GenerateRecord(buf, table); err != nil {
+			return err
+		}
+		filename := filepath.Join(outputDir, g.RecordName(table)+".avsc")
+		if err := util.WriteStringToFile(filename, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package avro
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func TestGenerator_GenerateRecord(t *testing.T) {
+	Convey("GenerateRecord", t, func() {
+		table := &octopus.Table{
+			Name:        "user",
+			Description: "User table",
+			Group:       "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:        "name",
+					Type:        octopus.ColTypeVarchar,
+					Size:        40,
+					NotNull:     true,
+					Description: "login name",
+				},
+				{
+					Name:    "class",
+					Type:    octopus.ColTypeEnum,
+					Values:  []string{"A", "B"},
+					NotNull: true,
+				},
+				{
+					Name:   "status",
+					Type:   octopus.ColTypeEnum,
+					Values: []string{"in-use", "deleted"},
+				},
+				{
+					Name:  "point",
+					Type:  octopus.ColTypeDecimal,
+					Size:  10,
+					Scale: 2,
+				},
+				{
+					Name:    "birthday",
+					Type:    octopus.ColTypeDate,
+					NotNull: true,
+				},
+				{
+					Name: "created_at",
+					Type: octopus.ColTypeDateTime,
+				},
+			},
+		}
+		expected := `{
+  "type": "record",
+  "name": "CUser",
+  "namespace": "com.example.cdc",
+  "doc": "User table",
+  "fields": [
+    {
+      "name": "id",
+      "type": "long"
+    },
+    {
+      "name": "name",
+      "type": "string",
+      "doc": "login name"
+    },
+    {
+      "name": "class",
+      "type": {
+        "type": "enum",
+        "name": "CUserClass",
+        "symbols": [
+          "A",
+          "B"
+        ]
+      }
+    },
+    {
+      "name": "status",
+      "type": [
+        "null",
+        "string"
+      ],
+      "default": null
+    },
+    {
+      "name": "point",
+      "type": [
+        "null",
+        {
+          "type": "bytes",
+          "logicalType": "decimal",
+          "precision": 10,
+          "scale": 2
+        }
+      ],
+      "default": null
+    },
+    {
+      "name": "birthday",
+      "type": {
+        "type": "int",
+        "logicalType": "date"
+      }
+    },
+    {
+      "name": "created_at",
+      "type": [
+        "null",
+        {
+          "type": "long",
+          "logicalType": "timestamp-millis"
+        }
+      ],
+      "default": null
+    }
+  ]
+}
+`
+
+		gen := NewGenerator(&octopus.Schema{Tables: []*octopus.Table{table}}, &Option{
+			PrefixMapper: common.NewPrefixMapper("common:C"),
+			Namespace:    "com.example.cdc",
+		})
+		buf := new(bytes.Buffer)
+		err := gen.GenerateRecord(buf, table)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_InvalidNames(t *testing.T) {
+	Convey("Invalid names", t, func() {
+		table := &octopus.Table{
+			Name:      "user-log",
+			ClassName: "2User-Log",
+			Columns: []*octopus.Column{
+				{Name: "1_level", Type: octopus.ColTypeInt32, NotNull: true},
+				{Name: "in-use", Type: octopus.ColTypeBoolean, NotNull: true},
+				{Name: "in_use", Type: octopus.ColTypeBoolean, NotNull: true},
+			},
+		}
+		expected := `{
+  "type": "record",
+  "name": "_2User_Log",
+  "fields": [
+    {
+      "name": "_1_level",
+      "type": "int"
+    },
+    {
+      "name": "in_use",
+      "type": "boolean"
+    },
+    {
+      "name": "in_use_2",
+      "type": "boolean"
+    }
+  ]
+}
+`
+
+		gen := NewGenerator(&octopus.Schema{Tables: []*octopus.Table{table}}, &Option{})
+		buf := new(bytes.Buffer)
+		So(gen.GenerateRecord(buf, table), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
@@ -6,17 +6,22 @@ import (
 )
 
 const (
+	FormatAvro            = "avro"
 	FormatDbdiagramIo     = "dbdiagram.io"
 	FormatGorm            = "gorm"
 	FormatGraphql         = "graphql"
 	FormatJpaKotlin       = "jpa-kotlin"
+	FormatJsonSchema      = "jsonschema"
 	FormatLiquibase       = "liquibase"
 	FormatOctopus1        = "octopus1"
 	FormatOctopus2        = "octopus2"
+	FormatOpenapi         = "openapi"
 	FormatOptiStudio      = "opti-studio"
 	FormatPlantuml        = "plantuml"
 	FormatProtobuf        = "protobuf"
+	FormatPydantic        = "pydantic"
 	FormatQuickdbd        = "quickdbd"
+	FormatRust            = "rust"
 	FormatSchemaConverter = "schema-converter"
 	FormatSqlalchemy      = "sqlalchemy"
 	FormatSqlH2           = "h2"
@@ -26,6 +31,7 @@ const (
 	FormatSqlSqlite3      = "sqlite3"
 	FormatSqlSqlserver    = "sqlserver"
 	FormatStaruml2        = "staruml2"
+	FormatTypescript      = "typescript"
 	FormatXlsx            = "xlsx"
 )
 
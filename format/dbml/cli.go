@@ -8,23 +8,65 @@ import (
 )
 
 const (
-	FlagGroups = "groups"
-	FlagInput  = "input"
-	FlagOutput = "output"
+	FlagAuthor  = "author"
+	FlagGroups  = "groups"
+	FlagInput   = "input"
+	FlagOutput  = "output"
+	FlagVersion = "version"
 )
 
+func ImportAction(c *cli.Context) error {
+	importer := NewImporter(&ImportOption{
+		Author:  c.String(FlagAuthor),
+		Version: c.String(FlagVersion),
+	})
+	schema, err := importer.ImportFile(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	// write to file
+	return schema.ToFile(c.String(FlagOutput))
+}
+
+var ImportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    FlagAuthor,
+		Aliases: []string{"a"},
+		Usage:   "import with author",
+		EnvVars: []string{"OCTOPUS_AUTHOR"},
+	},
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "import DBML from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "write octopus schema to `FILE`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagVersion,
+		Aliases: []string{"v"},
+		Usage:   "import with version",
+		EnvVars: []string{"OCTOPUS_VERSION"},
+	},
+}
+
 func ExportAction(c *cli.Context) error {
 	schema, err := octopus.LoadSchema(c.String(FlagInput))
 	if err != nil {
 		return err
 	}
 
-	exporter := Exporter{
-		schema: schema,
-		option: &Option{
-			TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
-		},
-	}
+	exporter := NewExporter(schema, &Option{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+	})
 	buf := new(bytes.Buffer)
 	if err = exporter.Export(buf); err != nil {
 		return err
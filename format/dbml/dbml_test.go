@@ -0,0 +1,406 @@
+package dbml
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func newTestSchema() *octopus.Schema {
+	return &octopus.Schema{
+		Name: "sample",
+		Tables: []*octopus.Table{
+			{
+				Name:        "user",
+				Group:       "common",
+				ClassName:   "Member",
+				Description: "User's table",
+				Columns: []*octopus.Column{
+					{
+						Name:            "id",
+						Type:            octopus.ColTypeInt64,
+						NotNull:         true,
+						PrimaryKey:      true,
+						AutoIncremental: true,
+					},
+					{
+						Name:         "name",
+						Type:         octopus.ColTypeVarchar,
+						Size:         40,
+						NotNull:      true,
+						UniqueKey:    true,
+						DefaultValue: "noname",
+						Description:  "login name\nunique",
+					},
+					{
+						Name:      "tenant",
+						Type:      octopus.ColTypeInt32,
+						NotNull:   true,
+						UniqueKey: true,
+					},
+					{
+						Name:   "status",
+						Type:   octopus.ColTypeEnum,
+						Values: []string{"active", "dormant user"},
+					},
+					{
+						Name:   "roles",
+						Type:   octopus.ColTypeSet,
+						Values: []string{"admin", "it's me"},
+					},
+					{
+						Name:         "score",
+						Type:         octopus.ColTypeDecimal,
+						Size:         10,
+						Scale:        2,
+						DefaultValue: "-1.5",
+					},
+					{
+						Name:         "created_at",
+						Type:         octopus.ColTypeDateTime,
+						DefaultValue: "fn::CURRENT_TIMESTAMP",
+					},
+					{
+						Name: "group_id",
+						Type: octopus.ColTypeInt64,
+						Ref: &octopus.Reference{
+							Table:        "group",
+							Column:       "id",
+							Relationship: octopus.RefManyToOne,
+						},
+					},
+				},
+				Indices: []*octopus.Index{
+					{Name: "idx_group", Columns: []string{"group_id", "status"}},
+				},
+			},
+			{
+				Name: "group",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+				},
+			},
+			{
+				Name:  "user role",
+				Group: "common",
+				Columns: []*octopus.Column{
+					{
+						Name:       "user_id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+						Ref: &octopus.Reference{
+							Table:        "user",
+							Column:       "id",
+							Relationship: octopus.RefOneToOne,
+						},
+					},
+					{
+						Name:       "role",
+						Type:       octopus.ColTypeVarchar,
+						Size:       20,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+const testDbml = `Project sample {
+}
+
+enum user_status {
+  "active"
+  "dormant user"
+}
+
+Table user as Member {
+  id int64 [pk, increment, not null]
+  name varchar(40) [not null, default: 'noname', note: '''login name
+unique''']
+  tenant int32 [not null]
+  status user_status
+  roles "set('admin','it''s me')"
+  score decimal(10,2) [default: -1.5]
+  created_at datetime [default: ` + "`CURRENT_TIMESTAMP()`" + `]
+  group_id int64
+
+  Note: 'User\'s table'
+
+  indexes {
+    (name, tenant) [unique]
+    (group_id, status) [name: 'idx_group']
+  }
+}
+
+Table group {
+  id int64 [pk, not null]
+}
+
+Table "user role" {
+  user_id int64 [not null, ref: - user.id]
+  role varchar(20) [not null]
+
+  indexes {
+    (user_id, role) [pk]
+  }
+}
+
+TableGroup common {
+  user
+  "user role"
+}
+
+Ref: user.group_id > group.id
+`
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		Convey("all tables", func() {
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &Option{}).Export(buf), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(testDbml, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, testDbml)
+		})
+
+		Convey("filtered tables", func() {
+			expected := `Table group {
+  id int64 [pk, not null]
+}
+`
+			buf := new(bytes.Buffer)
+			So(NewExporter(&octopus.Schema{Tables: newTestSchema().Tables[1:2]}, &Option{
+				TableFilter: octopus.GetTableFilterFn(""),
+			}).Export(buf), ShouldBeNil)
+			So(buf.String(), ShouldEqual, expected)
+
+			// references to filtered tables are removed
+			buf = new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &Option{
+				TableFilter: octopus.GetTableFilterFn("common"),
+			}).Export(buf), ShouldBeNil)
+			So(buf.String(), ShouldNotContainSubstring, "group.id")
+			So(buf.String(), ShouldContainSubstring, "ref: - user.id")
+		})
+	})
+}
+
+func TestImporter_Import(t *testing.T) {
+	Convey("Import", t, func() {
+		importer := NewImporter(&ImportOption{})
+
+		Convey("round trip", func() {
+			schema, err := importer.ImportDbml(testDbml)
+			So(err, ShouldBeNil)
+
+			expected := newTestSchema()
+			diff := cmp.Diff(expected, schema)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(schema, ShouldResemble, expected)
+		})
+
+		Convey("function default round trip", func() {
+			dbml := `Table event {
+  created_at timestamptz [default: ` + "`now()`" + `]
+  expired_at timestamptz [default: ` + "`date_add(now(), interval 1 day)`" + `]
+}
+`
+			schema, err := importer.ImportDbml(dbml)
+			So(err, ShouldBeNil)
+			So(schema.Tables[0].Columns[0].DefaultValue, ShouldEqual, "fn::now")
+
+			buf := new(bytes.Buffer)
+			So(NewExporter(schema, &Option{}).Export(buf), ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "created_at datetime [default: `now()`]")
+			So(buf.String(), ShouldContainSubstring, "expired_at datetime [default: `date_add(now(), interval 1 day)`]")
+		})
+
+		Convey("dbdiagram", func() {
+			dbml := `// sketched on dbdiagram.io
+Project shop {
+  database_type: 'PostgreSQL'
+  Note: 'shop schema'
+}
+
+Table public.orders as O [headercolor: #3498DB] {
+  id serial [primary key]
+  user_id integer [not null, ref: > U.id]
+  status order_status [default: 'created', note: "status"]
+  created_at timestamptz [default: ` + "`now()`" + `]
+  amount numeric(10, 2) [default: null]
+  Note {
+    'orders'
+  }
+
+  indexes {
+    user_id
+    ` + "`lower(status)`" + `
+    (user_id, created_at) [type: btree, name: 'ix_user_created']
+  }
+}
+
+Table users as U {
+  id bigint [pk]
+  email "character varying"(255) [unique]
+  active bool [default: true]
+}
+
+/* multi-line
+   comment */
+enum order_status {
+  created [note: 'new order']
+  paid
+}
+
+Ref user_orders {
+  O.user_id < U.id [delete: cascade]
+}
+`
+			schema, err := importer.ImportDbml(dbml)
+			So(err, ShouldBeNil)
+			So(schema.Name, ShouldEqual, "shop")
+
+			expected := []*octopus.Table{
+				{
+					Name:        "orders",
+					ClassName:   "O",
+					Description: "orders",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt32,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name:    "user_id",
+							Type:    octopus.ColTypeInt32,
+							NotNull: true,
+							Ref: &octopus.Reference{
+								Table:        "users",
+								Column:       "id",
+								Relationship: octopus.RefOneToMany,
+							},
+						},
+						{
+							Name:         "status",
+							Type:         octopus.ColTypeEnum,
+							Description:  "status",
+							DefaultValue: "created",
+							Values:       []string{"created", "paid"},
+						},
+						{
+							Name:         "created_at",
+							Type:         octopus.ColTypeDateTime,
+							DefaultValue: "fn::now",
+						},
+						{
+							Name:  "amount",
+							Type:  octopus.ColTypeDecimal,
+							Size:  10,
+							Scale: 2,
+						},
+					},
+					Indices: []*octopus.Index{
+						{Name: "idx_orders_user_id", Columns: []string{"user_id"}},
+						{Name: "ix_user_created", Columns: []string{"user_id", "created_at"}},
+					},
+				},
+				{
+					Name:      "users",
+					ClassName: "U",
+					Columns: []*octopus.Column{
+						{
+							Name:       "id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+						},
+						{
+							Name:      "email",
+							Type:      octopus.ColTypeVarchar,
+							Size:      255,
+							UniqueKey: true,
+						},
+						{
+							Name:         "active",
+							Type:         octopus.ColTypeBoolean,
+							DefaultValue: "true",
+						},
+					},
+				},
+			}
+			diff := cmp.Diff(expected, schema.Tables)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(schema.Tables, ShouldResemble, expected)
+		})
+
+		Convey("postgres types", func() {
+			dbml := `Table device {
+  id uuid [pk]
+  address inet
+  mac macaddr
+  price money
+  name citext
+  payload bytea
+  code varchar(10)
+}
+`
+			schema, err := importer.ImportDbml(dbml)
+			So(err, ShouldBeNil)
+
+			var actual []string
+			for _, column := range schema.Tables[0].Columns {
+				actual = append(actual, column.Name+" "+column.Format())
+			}
+			expected := []string{
+				"id char(36)",
+				"address varchar(49)",
+				"mac varchar(17)",
+				"price decimal(19,2)",
+				"name text16",
+				"payload blob32",
+				"code varchar(10)",
+			}
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldResemble, expected)
+		})
+
+		Convey("errors", func() {
+			invalids := map[string]string{
+				"Table t {\n  id tsvector\n}":                        "unsupported column type: t.id: tsvector",
+				"Table t {\n  id int [ref: <> u.id]\n}":              "line 2: many-to-many relationship is not supported",
+				"Table t {\n  id int\n}\nRef: t.id > u.id":           "line 4: table not found: u",
+				"Table t {\n  id int\n  id int\n}":                   "line 3: duplicate column: t.id",
+				"Table t {\n  id int [note: 'unterminated]\n}":       "line 2: unterminated quote: '",
+				"Table t {\n  id int\n  indexes {\n    name\n  }\n}": "line 4: column not found: t.name",
+				"Tables t {\n}": "line 1: unexpected 'Tables'",
+			}
+			for dbml, expected := range invalids {
+				_, err := importer.ImportDbml(dbml)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, expected)
+			}
+		})
+	})
+}
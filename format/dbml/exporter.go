@@ -5,9 +5,15 @@ import (
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
 	"io"
+	"regexp"
 	"strings"
 )
 
+var (
+	identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	literalRegex    = regexp.MustCompile(`^(-?[0-9]+(\.[0-9]+)?|(?i)true|false|null)$`)
+)
+
 type Option struct {
 	TableFilter octopus.TableFilterFn
 }
@@ -17,87 +23,230 @@ type Exporter struct {
 	option *Option
 }
 
+func NewExporter(schema *octopus.Schema, option *Option) *Exporter {
+	return &Exporter{schema: schema, option: option}
+}
+
 func (c *Exporter) Export(wr io.Writer) error {
-	// TODO: replace with template
-	if bytes, err := c.exportToString(); err != nil {
-		return err
-	} else {
-		_, err := wr.Write(bytes)
-		return err
-	}
+	_, err := wr.Write([]byte(strings.Join(c.exportLines(), "\n") + "\n"))
+	return err
 }
 
-func (c *Exporter) exportToString() ([]byte, error) {
+func (c *Exporter) exportLines() []string {
 	var result []string
 	var deferredRefs []string
-	definedTables := make(map[string]bool)
 
-	for _, table := range c.schema.Tables {
-		result = append(result, fmt.Sprintf("Table %s {", table.Name))
+	tables := c.schema.FilteredTables(c.option.TableFilter)
+	tableNames := util.NewStringSet()
+	for _, table := range tables {
+		tableNames.Add(table.Name)
+	}
+
+	// project
+	if c.schema.Name != "" {
+		result = append(result, fmt.Sprintf("Project %s {", quoteIdentifier(c.schema.Name)))
+		result = append(result, "}", "")
+	}
+
+	// enums
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			if column.Type != octopus.ColTypeEnum || len(column.Values) == 0 {
+				continue
+			}
+			result = append(result, fmt.Sprintf("enum %s {", quoteIdentifier(enumName(table, column))))
+			for _, value := range column.Values {
+				result = append(result, "  "+quoteDoubleQuotes(value))
+			}
+			result = append(result, "}", "")
+		}
+	}
+
+	// tables
+	definedTables := util.NewStringSet()
+	for _, table := range tables {
+		tableDef := "Table " + quoteIdentifier(table.Name)
+		if table.ClassName != "" {
+			tableDef += " as " + quoteIdentifier(table.ClassName)
+		}
+		result = append(result, tableDef+" {")
 
+		pkNames := columnNames(table, func(column *octopus.Column) bool { return column.PrimaryKey })
+		uniqueNames := columnNames(table, func(column *octopus.Column) bool { return column.UniqueKey })
 		for _, column := range table.Columns {
 			var params []string
 
-			if column.PrimaryKey {
+			if column.PrimaryKey && len(pkNames) == 1 {
 				params = append(params, "pk")
 			}
-			if column.UniqueKey {
+			if column.UniqueKey && len(uniqueNames) == 1 {
 				params = append(params, "unique")
 			}
-			//if column.AutoIncremental {
-			//	params = append(params, "auto increment")
-			//}
+			if column.AutoIncremental {
+				params = append(params, "increment")
+			}
 			if column.NotNull {
 				params = append(params, "not null")
 			}
 			if column.DefaultValue != "" {
-				defaultValue := column.DefaultValue
-				if util.IsStringType(column.Type) {
-					defaultValue = fmt.Sprintf("'%s'", defaultValue)
-				}
-				params = append(params, fmt.Sprintf("default: %s", defaultValue))
+				params = append(params, "default: "+formatDefaultValue(column))
 			}
-			if ref := column.Ref; ref != nil {
+			if ref := column.Ref; ref != nil && tableNames.Contains(ref.Table) {
 				rel := getRelationshipType(ref)
+				target := quoteIdentifier(ref.Table) + "." + quoteIdentifier(ref.Column)
 
-				if definedTables[ref.Table] {
-					params = append(params,
-						fmt.Sprintf("ref: %s %s.%s", rel, ref.Table, ref.Column))
+				if definedTables.Contains(ref.Table) {
+					params = append(params, fmt.Sprintf("ref: %s %s", rel, target))
 				} else {
 					deferredRefs = append(deferredRefs,
-						fmt.Sprintf("Ref: %s.%s %s %s.%s",
-							table.Name, column.Name, rel, ref.Table, ref.Column),
+						fmt.Sprintf("Ref: %s.%s %s %s",
+							quoteIdentifier(table.Name), quoteIdentifier(column.Name), rel, target),
 					)
 				}
 			}
 			if column.Description != "" {
-				params = append(params, fmt.Sprintf("note: \"%s\"", column.Description))
+				params = append(params, "note: "+quoteString(column.Description))
 			}
 
-			columnType := column.Type
-			if column.Size > 0 {
-				if column.Scale > 0 {
-					columnType = fmt.Sprintf("%s(%d,%d)", column.Type, column.Size, column.Scale)
-				} else {
-					columnType = fmt.Sprintf("%s(%d)", column.Type, column.Size)
-				}
+			columnDef := fmt.Sprintf("  %s %s", quoteIdentifier(column.Name), getColumnType(table, column))
+			if len(params) > 0 {
+				columnDef += fmt.Sprintf(" [%s]", strings.Join(params, ", "))
 			}
+			result = append(result, columnDef)
+		}
 
-			if len(params) == 0 {
-				result = append(result, fmt.Sprintf("  %s %s", column.Name, columnType))
-			} else {
-				result = append(result, fmt.Sprintf("  %s %s [%s]", column.Name, columnType, strings.Join(params, ", ")))
-			}
+		if table.Description != "" {
+			result = append(result, "", "  Note: "+quoteString(table.Description))
 		}
-		result = append(result, "}")
-		result = append(result, "")
 
-		definedTables[table.Name] = true
+		// indexes
+		var indexes []string
+		if len(pkNames) > 1 {
+			indexes = append(indexes, fmt.Sprintf("    %s [pk]", indexColumns(pkNames)))
+		}
+		if len(uniqueNames) > 1 {
+			indexes = append(indexes, fmt.Sprintf("    %s [unique]", indexColumns(uniqueNames)))
+		}
+		for _, index := range table.Indices {
+			indexes = append(indexes,
+				fmt.Sprintf("    %s [name: %s]", indexColumns(index.Columns), quoteString(index.Name)))
+		}
+		if len(indexes) > 0 {
+			result = append(result, "", "  indexes {")
+			result = append(result, indexes...)
+			result = append(result, "  }")
+		}
+
+		result = append(result, "}", "")
+		definedTables.Add(table.Name)
 	}
 
-	result = append(result, strings.Join(deferredRefs, "\n"))
+	// table groups
+	var groups []string
+	tablesByGroup := make(map[string][]string)
+	for _, table := range tables {
+		if table.Group == "" {
+			continue
+		}
+		if _, ok := tablesByGroup[table.Group]; !ok {
+			groups = append(groups, table.Group)
+		}
+		tablesByGroup[table.Group] = append(tablesByGroup[table.Group], table.Name)
+	}
+	for _, group := range groups {
+		result = append(result, fmt.Sprintf("TableGroup %s {", quoteIdentifier(group)))
+		for _, tableName := range tablesByGroup[group] {
+			result = append(result, "  "+quoteIdentifier(tableName))
+		}
+		result = append(result, "}", "")
+	}
+
+	if len(deferredRefs) > 0 {
+		result = append(result, deferredRefs...)
+	} else if len(result) > 0 {
+		// remove last empty line
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// columnNames returns names of matching columns in column order.
+func columnNames(table *octopus.Table, fn func(column *octopus.Column) bool) []string {
+	var names []string
+	for _, column := range table.Columns {
+		if fn(column) {
+			names = append(names, column.Name)
+		}
+	}
+	return names
+}
+
+// enumName returns enum type name of the column.
+func enumName(table *octopus.Table, column *octopus.Column) string {
+	return table.Name + "_" + column.Name
+}
+
+func getColumnType(table *octopus.Table, column *octopus.Column) string {
+	switch column.Type {
+	case octopus.ColTypeEnum:
+		if len(column.Values) > 0 {
+			return quoteIdentifier(enumName(table, column))
+		}
+	case octopus.ColTypeSet:
+		var values []string
+		for _, value := range column.Values {
+			values = append(values, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+		}
+		return quoteDoubleQuotes(fmt.Sprintf("set(%s)", strings.Join(values, ",")))
+	}
+	return column.Format()
+}
 
-	return []byte(strings.Join(result, "\n")), nil
+func indexColumns(columns []string) string {
+	if len(columns) == 1 {
+		return quoteIdentifier(columns[0])
+	}
+	var quoted []string
+	for _, column := range columns {
+		quoted = append(quoted, quoteIdentifier(column))
+	}
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+func formatDefaultValue(column *octopus.Column) string {
+	value, isFn := column.GetDefaultValue()
+	if isFn {
+		// function call like mysql exporter. ex: 'fn::CURRENT_TIMESTAMP' -> `CURRENT_TIMESTAMP()`
+		if !strings.Contains(value, "(") {
+			value += "()"
+		}
+		return "`" + value + "`"
+	}
+	if !util.IsStringType(column.Type) && literalRegex.MatchString(value) {
+		return value
+	}
+	return quoteString(value)
+}
+
+// quoteIdentifier quotes name with double quotes if it is not a simple identifier.
+func quoteIdentifier(name string) string {
+	if identifierRegex.MatchString(name) {
+		return name
+	}
+	return quoteDoubleQuotes(name)
+}
+
+func quoteDoubleQuotes(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// quoteString returns single-quoted string. multi-line string is quoted with triple quotes.
+func quoteString(s string) string {
+	escaped := strings.ReplaceAll(s, `\`, `\\`)
+	if strings.Contains(s, "\n") {
+		return "'''" + strings.ReplaceAll(escaped, "'''", `\'''`) + "'''"
+	}
+	return "'" + strings.ReplaceAll(escaped, "'", `\'`) + "'"
 }
 
 func getRelationshipType(ref *octopus.Reference) string {
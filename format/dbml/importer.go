@@ -0,0 +1,883 @@
+package dbml
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"io"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var quotedTypeRegex = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_ ]*?)\s*(?:\((.*)\))?\s*$`)
+
+// typeAliases maps DBML column types which are not octopus column types.
+var typeAliases = map[string]string{
+	"bigserial":         octopus.ColTypeInt64,
+	"bool":              octopus.ColTypeBoolean,
+	"bytea":             octopus.ColTypeBlob32,
+	"character":         octopus.ColTypeChar,
+	"character varying": octopus.ColTypeVarchar,
+	"cidr":              octopus.ColTypeVarchar,
+	"citext":            octopus.ColTypeText16,
+	"double precision":  octopus.ColTypeDouble,
+	"float4":            octopus.ColTypeFloat,
+	"float8":            octopus.ColTypeDouble,
+	"inet":              octopus.ColTypeVarchar,
+	"int2":              octopus.ColTypeInt16,
+	"int4":              octopus.ColTypeInt32,
+	"int8":              octopus.ColTypeInt64,
+	"jsonb":             octopus.ColTypeJSON,
+	"macaddr":           octopus.ColTypeVarchar,
+	"money":             octopus.ColTypeDecimal,
+	"serial":            octopus.ColTypeInt32,
+	"smallserial":       octopus.ColTypeInt16,
+	"timestamptz":       octopus.ColTypeDateTime,
+	"uuid":              octopus.ColTypeChar,
+	"xml":               octopus.ColTypeText32,
+}
+
+// typeAliasSizes holds size and scale of aliased types used when no size is given.
+var typeAliasSizes = map[string][2]uint16{
+	"cidr":    {49, 0},
+	"inet":    {49, 0},
+	"macaddr": {17, 0},
+	"money":   {19, 2},
+	"uuid":    {36, 0},
+}
+
+type ImportOption struct {
+	Author  string
+	Version string
+}
+
+type Importer struct {
+	option *ImportOption
+}
+
+func NewImporter(option *ImportOption) *Importer {
+	return &Importer{option: option}
+}
+
+func (c *Importer) Import(reader io.Reader) (*octopus.Schema, error) {
+	if bytes, err := ioutil.ReadAll(reader); err != nil {
+		return nil, err
+	} else {
+		return c.ImportDbml(string(bytes))
+	}
+}
+
+func (c *Importer) ImportFile(filename string) (*octopus.Schema, error) {
+	if data, err := ioutil.ReadFile(filename); err != nil {
+		return nil, err
+	} else {
+		return c.ImportDbml(string(data))
+	}
+}
+
+func (c *Importer) ImportDbml(dbml string) (*octopus.Schema, error) {
+	if c.option == nil {
+		return nil, errors.New("option is nil")
+	}
+
+	tokens, err := tokenize(dbml)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		tokens:       tokens,
+		enums:        make(map[string][]string),
+		tableByAlias: make(map[string]*octopus.Table),
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	if err := p.resolve(); err != nil {
+		return nil, err
+	}
+
+	return &octopus.Schema{
+		Author:  c.option.Author,
+		Name:    p.projectName,
+		Tables:  p.tables,
+		Version: c.option.Version,
+	}, nil
+}
+
+// endpoint is a column reference in 'table.column' format.
+type endpoint struct {
+	table  string
+	column string
+}
+
+// ref is a reference between columns.
+type ref struct {
+	from         endpoint
+	to           endpoint
+	relationship string
+	line         int
+}
+
+type tableGroup struct {
+	name   string
+	tables []string
+	line   int
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+
+	projectName  string
+	tables       []*octopus.Table
+	tableByAlias map[string]*octopus.Table
+	enums        map[string][]string
+	// columnEnums is the enum type name of columns.
+	columnEnums map[*octopus.Column]string
+	refs        []*ref
+	groups      []*tableGroup
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset < len(p.tokens) {
+		return p.tokens[p.pos+offset]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isSymbol(symbol string) bool {
+	tok := p.peek()
+	return tok.kind == tokenSymbol && tok.text == symbol
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if tok := p.next(); tok.kind != tokenSymbol || tok.text != symbol {
+		return p.errorf(tok, "expected '%s' but found '%s'", symbol, tok)
+	}
+	return nil
+}
+
+// name reads identifier. schema name of 'schema.name' is ignored.
+func (p *parser) name() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenWord && tok.kind != tokenQuoted {
+		return "", p.errorf(tok, "expected name but found '%s'", tok)
+	}
+	name := tok.text
+	for p.isSymbol(".") {
+		p.next()
+		tok = p.next()
+		if tok.kind != tokenWord && tok.kind != tokenQuoted {
+			return "", p.errorf(tok, "expected name but found '%s'", tok)
+		}
+		name = tok.text
+	}
+	return name, nil
+}
+
+// stringValue reads string.
+func (p *parser) stringValue() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenString {
+		return "", p.errorf(tok, "expected string but found '%s'", tok)
+	}
+	return tok.text, nil
+}
+
+// skipBlock skips tokens until matching '}'.
+func (p *parser) skipBlock() error {
+	if err := p.expectSymbol("{"); err != nil {
+		return err
+	}
+	depth := 1
+	for depth > 0 {
+		tok := p.next()
+		switch {
+		case tok.kind == tokenEOF:
+			return p.errorf(tok, "unexpected EOF")
+		case tok.kind == tokenSymbol && tok.text == "{":
+			depth++
+		case tok.kind == tokenSymbol && tok.text == "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+func (p *parser) parse() error {
+	p.columnEnums = make(map[*octopus.Column]string)
+	for {
+		tok := p.peek()
+		if tok.kind == tokenEOF {
+			return nil
+		}
+		if tok.kind != tokenWord {
+			return p.errorf(tok, "unexpected '%s'", tok)
+		}
+
+		var err error
+		switch strings.ToLower(tok.text) {
+		case "project":
+			p.next()
+			if p.projectName, err = p.name(); err == nil {
+				err = p.skipBlock()
+			}
+		case "table":
+			p.next()
+			err = p.parseTable()
+		case "tablegroup":
+			p.next()
+			err = p.parseTableGroup()
+		case "enum":
+			p.next()
+			err = p.parseEnum()
+		case "ref":
+			p.next()
+			err = p.parseRef()
+		case "note":
+			// sticky note
+			p.next()
+			if !p.isSymbol("{") {
+				_, err = p.name()
+			}
+			if err == nil {
+				err = p.skipBlock()
+			}
+		default:
+			err = p.errorf(tok, "unexpected '%s'", tok)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseTable() error {
+	nameToken := p.peek()
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.tableByAlias[name] != nil {
+		return p.errorf(nameToken, "duplicate table: %s", name)
+	}
+	table := &octopus.Table{Name: name}
+	p.tables = append(p.tables, table)
+	p.tableByAlias[name] = table
+
+	if p.isKeyword("as") {
+		p.next()
+		if table.ClassName, err = p.name(); err != nil {
+			return err
+		}
+		p.tableByAlias[table.ClassName] = table
+	}
+
+	if p.isSymbol("[") {
+		settings, err := p.parseSettings()
+		if err != nil {
+			return err
+		}
+		if s := settings.get("note"); s != nil {
+			table.Description = s.value
+		}
+	}
+
+	if err := p.expectSymbol("{"); err != nil {
+		return err
+	}
+	for !p.isSymbol("}") {
+		tok := p.peek()
+		next := p.peekAt(1)
+		switch {
+		case tok.kind == tokenEOF:
+			return p.errorf(tok, "unexpected EOF")
+		case p.isKeyword("note") && next.kind == tokenSymbol && (next.text == ":" || next.text == "{"):
+			p.next()
+			if table.Description, err = p.parseNote(); err != nil {
+				return err
+			}
+		case p.isKeyword("indexes") && next.kind == tokenSymbol && next.text == "{":
+			p.next()
+			if err := p.parseIndexes(table); err != nil {
+				return err
+			}
+		default:
+			if err := p.parseColumn(table); err != nil {
+				return err
+			}
+		}
+	}
+	p.next()
+	return nil
+}
+
+// parseNote reads note in "Note: 'text'" or "Note { 'text' }" format.
+func (p *parser) parseNote() (string, error) {
+	if p.isSymbol(":") {
+		p.next()
+		return p.stringValue()
+	}
+	if err := p.expectSymbol("{"); err != nil {
+		return "", err
+	}
+	note, err := p.stringValue()
+	if err != nil {
+		return "", err
+	}
+	return note, p.expectSymbol("}")
+}
+
+func (p *parser) parseColumn(table *octopus.Table) error {
+	nameToken := p.peek()
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	column := &octopus.Column{Name: name}
+
+	if err := p.parseColumnType(column); err != nil {
+		return err
+	}
+
+	if p.isSymbol("[") {
+		settings, err := p.parseSettings()
+		if err != nil {
+			return err
+		}
+		for _, s := range settings {
+			switch s.key {
+			case "pk", "primary key":
+				column.PrimaryKey = true
+			case "unique":
+				column.UniqueKey = true
+			case "increment":
+				column.AutoIncremental = true
+			case "not null":
+				column.NotNull = true
+			case "null":
+				column.NotNull = false
+			case "note":
+				column.Description = s.value
+			case "default":
+				switch s.kind {
+				case tokenExpr:
+					// function name is stored without '()' like other importers
+					column.SetDefaultValueFn(strings.TrimSuffix(s.value, "()"))
+				case tokenWord:
+					if !strings.EqualFold(s.value, "null") {
+						column.DefaultValue = s.value
+					}
+				default:
+					column.DefaultValue = s.value
+				}
+			case "ref":
+				p.refs = append(p.refs, &ref{
+					from:         endpoint{table: table.Name, column: column.Name},
+					to:           s.ref.to,
+					relationship: s.ref.relationship,
+					line:         s.line,
+				})
+			}
+		}
+	}
+
+	if table.ColumnByName(column.Name) != nil {
+		return p.errorf(nameToken, "duplicate column: %s.%s", table.Name, column.Name)
+	}
+	table.AddColumn(column)
+	return nil
+}
+
+func (p *parser) parseColumnType(column *octopus.Column) error {
+	tok := p.peek()
+	var typeName string
+	var args []string
+
+	switch tok.kind {
+	case tokenQuoted:
+		p.next()
+		match := quotedTypeRegex.FindStringSubmatch(tok.text)
+		if match == nil {
+			return p.errorf(tok, "invalid column type: %s", tok.text)
+		}
+		typeName = match[1]
+		if match[2] != "" {
+			args = splitArgs(match[2])
+		}
+	case tokenWord:
+		var err error
+		if typeName, err = p.name(); err != nil {
+			return err
+		}
+	default:
+		return p.errorf(tok, "expected column type but found '%s'", tok)
+	}
+
+	if p.isSymbol("(") {
+		p.next()
+		var arg []string
+		for !p.isSymbol(")") {
+			argToken := p.next()
+			switch {
+			case argToken.kind == tokenEOF:
+				return p.errorf(argToken, "unexpected EOF")
+			case argToken.kind == tokenSymbol && argToken.text == ",":
+				args = append(args, strings.Join(arg, ""))
+				arg = nil
+			default:
+				arg = append(arg, argToken.text)
+			}
+		}
+		p.next()
+		args = append(args, strings.Join(arg, ""))
+	}
+
+	// array type
+	if p.isSymbol("[") && p.peekAt(1).kind == tokenSymbol && p.peekAt(1).text == "]" {
+		return p.errorf(tok, "array type is not supported: %s", column.Name)
+	}
+
+	if _, ok := p.enums[typeName]; ok {
+		column.Type = octopus.ColTypeEnum
+		p.columnEnums[column] = typeName
+		return nil
+	}
+
+	lowerType := strings.ToLower(typeName)
+	if colType, ok := typeAliases[lowerType]; ok {
+		column.AutoIncremental = strings.HasSuffix(lowerType, "serial")
+		if sizes, ok := typeAliasSizes[lowerType]; ok {
+			column.Size, column.Scale = sizes[0], sizes[1]
+		}
+		lowerType = colType
+	}
+	column.Type = lowerType
+	column.NormalizeType()
+
+	if column.Type == octopus.ColTypeEnum || column.Type == octopus.ColTypeSet {
+		column.Values = args
+		return nil
+	}
+	if !octopus.IsValidColType(column.Type) {
+		// enum can be defined after table
+		p.columnEnums[column] = typeName
+		return nil
+	}
+
+	if len(args) > 0 {
+		size, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			return p.errorf(tok, "invalid column size: %s", args[0])
+		}
+		column.Size = uint16(size)
+	}
+	if len(args) > 1 {
+		scale, err := strconv.ParseUint(args[1], 10, 16)
+		if err != nil {
+			return p.errorf(tok, "invalid column scale: %s", args[1])
+		}
+		column.Scale = uint16(scale)
+	}
+	return nil
+}
+
+// splitArgs splits comma separated arguments. single-quoted arguments are unquoted.
+func splitArgs(s string) []string {
+	var args []string
+	var sb strings.Builder
+	quoted := false
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' && quoted && i+1 < len(runes) && runes[i+1] == '\'':
+			sb.WriteRune(r)
+			i++
+		case r == '\'':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			args = append(args, strings.TrimSpace(sb.String()))
+			sb.Reset()
+		case r == ' ' && !quoted:
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return append(args, strings.TrimSpace(sb.String()))
+}
+
+// setting is a key-value pair in '[...]'.
+type setting struct {
+	key   string
+	value string
+	kind  tokenKind
+	ref   *ref
+	line  int
+}
+
+type settings []*setting
+
+func (s settings) get(key string) *setting {
+	for _, item := range s {
+		if item.key == key {
+			return item
+		}
+	}
+	return nil
+}
+
+// parseSettings reads settings in '[key, key: value, ...]' format.
+func (p *parser) parseSettings() (settings, error) {
+	if err := p.expectSymbol("["); err != nil {
+		return nil, err
+	}
+
+	var result settings
+	for {
+		tok := p.peek()
+		var words []string
+		for p.peek().kind == tokenWord {
+			words = append(words, strings.ToLower(p.next().text))
+		}
+		if len(words) == 0 {
+			return nil, p.errorf(p.peek(), "expected setting but found '%s'", p.peek())
+		}
+		s := &setting{key: strings.Join(words, " "), line: tok.line}
+
+		if p.isSymbol(":") {
+			p.next()
+			if s.key == "ref" {
+				r, err := p.parseRelationship()
+				if err != nil {
+					return nil, err
+				}
+				s.ref = r
+			} else {
+				valueToken := p.next()
+				if valueToken.kind == tokenSymbol && valueToken.text == "-" && p.peek().kind == tokenWord {
+					valueToken = p.next()
+					valueToken.text = "-" + valueToken.text
+				}
+				if valueToken.kind == tokenEOF || valueToken.kind == tokenSymbol {
+					return nil, p.errorf(valueToken, "expected value but found '%s'", valueToken)
+				}
+				s.value = valueToken.text
+				s.kind = valueToken.kind
+			}
+		}
+		result = append(result, s)
+
+		tok = p.next()
+		if tok.kind == tokenSymbol && tok.text == "]" {
+			return result, nil
+		}
+		if tok.kind != tokenSymbol || tok.text != "," {
+			return nil, p.errorf(tok, "expected ',' or ']' but found '%s'", tok)
+		}
+	}
+}
+
+// parseRelationship reads relationship and target endpoint of inline reference.
+func (p *parser) parseRelationship() (*ref, error) {
+	tok := p.next()
+	relationship, err := p.toRelationship(tok)
+	if err != nil {
+		return nil, err
+	}
+	to, err := p.parseEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	return &ref{to: to, relationship: relationship, line: tok.line}, nil
+}
+
+func (p *parser) toRelationship(tok token) (string, error) {
+	if tok.kind == tokenSymbol {
+		switch tok.text {
+		case ">":
+			return octopus.RefManyToOne, nil
+		case "<":
+			return octopus.RefOneToMany, nil
+		case "-":
+			return octopus.RefOneToOne, nil
+		case "<>":
+			return "", p.errorf(tok, "many-to-many relationship is not supported")
+		}
+	}
+	return "", p.errorf(tok, "expected relationship but found '%s'", tok)
+}
+
+// parseEndpoint reads endpoint in '[schema.]table.column' format.
+func (p *parser) parseEndpoint() (endpoint, error) {
+	var names []string
+	for {
+		tok := p.next()
+		if tok.kind == tokenSymbol && tok.text == "(" {
+			return endpoint{}, p.errorf(tok, "composite reference is not supported")
+		}
+		if tok.kind != tokenWord && tok.kind != tokenQuoted {
+			return endpoint{}, p.errorf(tok, "expected name but found '%s'", tok)
+		}
+		names = append(names, tok.text)
+		if !p.isSymbol(".") {
+			break
+		}
+		p.next()
+	}
+	if len(names) < 2 {
+		return endpoint{}, p.errorf(p.peek(), "expected 'table.column' but found '%s'", names[0])
+	}
+	return endpoint{table: names[len(names)-2], column: names[len(names)-1]}, nil
+}
+
+func (p *parser) parseRef() error {
+	// optional name
+	if !p.isSymbol(":") && !p.isSymbol("{") {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+	}
+
+	if p.isSymbol(":") {
+		p.next()
+		return p.parseRefBody()
+	}
+	if err := p.expectSymbol("{"); err != nil {
+		return err
+	}
+	for !p.isSymbol("}") {
+		if err := p.parseRefBody(); err != nil {
+			return err
+		}
+	}
+	p.next()
+	return nil
+}
+
+// parseRefBody reads 'table.column > table.column [settings]'.
+func (p *parser) parseRefBody() error {
+	from, err := p.parseEndpoint()
+	if err != nil {
+		return err
+	}
+	r, err := p.parseRelationship()
+	if err != nil {
+		return err
+	}
+	r.from = from
+	p.refs = append(p.refs, r)
+
+	// ignore settings such as 'delete: cascade'
+	if p.isSymbol("[") {
+		if _, err := p.parseSettings(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseIndexes(table *octopus.Table) error {
+	if err := p.expectSymbol("{"); err != nil {
+		return err
+	}
+	for !p.isSymbol("}") {
+		tok := p.peek()
+		var columns []string
+		supported := true
+
+		switch {
+		case tok.kind == tokenEOF:
+			return p.errorf(tok, "unexpected EOF")
+		case tok.kind == tokenSymbol && tok.text == "(":
+			p.next()
+			for !p.isSymbol(")") {
+				colToken := p.next()
+				switch {
+				case colToken.kind == tokenWord || colToken.kind == tokenQuoted:
+					columns = append(columns, colToken.text)
+				case colToken.kind == tokenExpr:
+					supported = false
+				case colToken.kind == tokenSymbol && colToken.text == ",":
+				default:
+					return p.errorf(colToken, "unexpected '%s'", colToken)
+				}
+			}
+			p.next()
+		case tok.kind == tokenExpr:
+			p.next()
+			supported = false
+		default:
+			name, err := p.name()
+			if err != nil {
+				return err
+			}
+			columns = append(columns, name)
+		}
+
+		var indexSettings settings
+		if p.isSymbol("[") {
+			var err error
+			if indexSettings, err = p.parseSettings(); err != nil {
+				return err
+			}
+		}
+
+		if !supported {
+			log.Printf("line %d: expression index is not supported. skipped.", tok.line)
+			continue
+		}
+		for _, column := range columns {
+			if table.ColumnByName(column) == nil {
+				return p.errorf(tok, "column not found: %s.%s", table.Name, column)
+			}
+		}
+
+		switch {
+		case indexSettings.get("pk") != nil || indexSettings.get("primary key") != nil:
+			for _, column := range columns {
+				table.ColumnByName(column).PrimaryKey = true
+			}
+		case indexSettings.get("unique") != nil:
+			for _, column := range columns {
+				table.ColumnByName(column).UniqueKey = true
+			}
+		default:
+			name := "idx_" + table.Name + "_" + strings.Join(columns, "_")
+			if s := indexSettings.get("name"); s != nil {
+				name = s.value
+			}
+			table.Indices = append(table.Indices, &octopus.Index{Name: name, Columns: columns})
+		}
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseEnum() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expectSymbol("{"); err != nil {
+		return err
+	}
+	values := make([]string, 0)
+	for !p.isSymbol("}") {
+		tok := p.next()
+		if tok.kind != tokenWord && tok.kind != tokenQuoted {
+			return p.errorf(tok, "expected enum value but found '%s'", tok)
+		}
+		values = append(values, tok.text)
+		// ignore value note
+		if p.isSymbol("[") {
+			if _, err := p.parseSettings(); err != nil {
+				return err
+			}
+		}
+	}
+	p.next()
+	p.enums[name] = values
+	return nil
+}
+
+func (p *parser) parseTableGroup() error {
+	tok := p.peek()
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expectSymbol("{"); err != nil {
+		return err
+	}
+	group := &tableGroup{name: name, line: tok.line}
+	for !p.isSymbol("}") {
+		if p.peek().kind == tokenEOF {
+			return p.errorf(p.peek(), "unexpected EOF")
+		}
+		tableName, err := p.name()
+		if err != nil {
+			return err
+		}
+		group.tables = append(group.tables, tableName)
+	}
+	p.next()
+	p.groups = append(p.groups, group)
+	return nil
+}
+
+// resolve sets enum values, references and table groups.
+func (p *parser) resolve() error {
+	for _, table := range p.tables {
+		for _, column := range table.Columns {
+			typeName, ok := p.columnEnums[column]
+			if !ok {
+				continue
+			}
+			values, ok := p.enums[typeName]
+			if !ok {
+				return fmt.Errorf("unsupported column type: %s.%s: %s", table.Name, column.Name, typeName)
+			}
+			column.Type = octopus.ColTypeEnum
+			column.Values = values
+		}
+	}
+
+	for _, r := range p.refs {
+		fromTable, ok := p.tableByAlias[r.from.table]
+		if !ok {
+			return fmt.Errorf("line %d: table not found: %s", r.line, r.from.table)
+		}
+		column := fromTable.ColumnByName(r.from.column)
+		if column == nil {
+			return fmt.Errorf("line %d: column not found: %s.%s", r.line, r.from.table, r.from.column)
+		}
+		toTable, ok := p.tableByAlias[r.to.table]
+		if !ok {
+			return fmt.Errorf("line %d: table not found: %s", r.line, r.to.table)
+		}
+		if toTable.ColumnByName(r.to.column) == nil {
+			return fmt.Errorf("line %d: column not found: %s.%s", r.line, r.to.table, r.to.column)
+		}
+		column.Ref = &octopus.Reference{
+			Table:        toTable.Name,
+			Column:       r.to.column,
+			Relationship: r.relationship,
+		}
+	}
+
+	for _, group := range p.groups {
+		for _, tableName := range group.tables {
+			table, ok := p.tableByAlias[tableName]
+			if !ok {
+				return fmt.Errorf("line %d: table not found: %s", group.line, tableName)
+			}
+			table.Group = group.name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenWord is identifier, keyword, number or color.
+	tokenWord
+	// tokenQuoted is double-quoted identifier.
+	tokenQuoted
+	// tokenString is single-quoted or triple-quoted string.
+	tokenString
+	// tokenExpr is backtick expression.
+	tokenExpr
+	// tokenSymbol is punctuation.
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "EOF"
+	case tokenQuoted:
+		return `"` + t.text + `"`
+	case tokenString:
+		return "'" + t.text + "'"
+	case tokenExpr:
+		return "`" + t.text + "`"
+	default:
+		return t.text
+	}
+}
+
+type lexer struct {
+	src  []rune
+	pos  int
+	line int
+}
+
+// tokenize splits DBML source into tokens. Comments are skipped.
+func tokenize(src string) ([]token, error) {
+	l := &lexer{src: []rune(src), line: 1}
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) peekRune(offset int) rune {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *lexer) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(l.src[l.pos:]), prefix)
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+	}
+	return r
+}
+
+func (l *lexer) skipSpacesAndComments() error {
+	for l.pos < len(l.src) {
+		switch {
+		case unicode.IsSpace(l.peekRune(0)):
+			l.advance()
+		case l.hasPrefix("//"):
+			for l.pos < len(l.src) && l.peekRune(0) != '\n' {
+				l.advance()
+			}
+		case l.hasPrefix("/*"):
+			line := l.line
+			l.pos += 2
+			for !l.hasPrefix("*/") {
+				if l.pos >= len(l.src) {
+					return fmt.Errorf("line %d: unterminated comment", line)
+				}
+				l.advance()
+			}
+			l.pos += 2
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpacesAndComments(); err != nil {
+		return token{}, err
+	}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, line: l.line}, nil
+	}
+
+	line := l.line
+	r := l.peekRune(0)
+	switch {
+	case isWordRune(r) || (r == '#' && isWordRune(l.peekRune(1))):
+		start := l.pos
+		l.advance()
+		for l.pos < len(l.src) && isWordRune(l.peekRune(0)) {
+			l.advance()
+		}
+		// decimal number
+		if unicode.IsDigit(r) && l.peekRune(0) == '.' && unicode.IsDigit(l.peekRune(1)) {
+			l.advance()
+			for l.pos < len(l.src) && unicode.IsDigit(l.peekRune(0)) {
+				l.advance()
+			}
+		}
+		return token{kind: tokenWord, text: string(l.src[start:l.pos]), line: line}, nil
+	case r == '"':
+		text, err := l.readQuoted(`"`)
+		return token{kind: tokenQuoted, text: text, line: line}, err
+	case l.hasPrefix("'''"):
+		text, err := l.readQuoted("'''")
+		return token{kind: tokenString, text: text, line: line}, err
+	case r == '\'':
+		text, err := l.readQuoted("'")
+		return token{kind: tokenString, text: text, line: line}, err
+	case r == '`':
+		text, err := l.readQuoted("`")
+		return token{kind: tokenExpr, text: text, line: line}, err
+	case l.hasPrefix("<>"):
+		l.pos += 2
+		return token{kind: tokenSymbol, text: "<>", line: line}, nil
+	case strings.ContainsRune("{}[](),:.<>-~", r):
+		l.advance()
+		return token{kind: tokenSymbol, text: string(r), line: line}, nil
+	}
+	return token{}, fmt.Errorf("line %d: unexpected character: %q", line, r)
+}
+
+// readQuoted reads text enclosed by quote. backslash escapes the next character.
+func (l *lexer) readQuoted(quote string) (string, error) {
+	line := l.line
+	l.pos += len([]rune(quote))
+
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return "", fmt.Errorf("line %d: unterminated quote: %s", line, quote)
+		}
+		if l.hasPrefix(quote) {
+			l.pos += len([]rune(quote))
+			return sb.String(), nil
+		}
+		r := l.advance()
+		if r == '\\' && quote != "`" && l.pos < len(l.src) {
+			next := l.advance()
+			if next != '\\' && next != '\'' && next != '"' {
+				sb.WriteRune(r)
+			}
+			r = next
+		}
+		sb.WriteRune(r)
+	}
+}
@@ -0,0 +1,472 @@
+package diff
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/format/sqlalchemy"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const alembicIndent = "    "
+
+type AlembicOption struct {
+	Revision     string
+	DownRevision string
+	Message      string
+}
+
+type AlembicChangeSetWriter struct {
+	writer        io.Writer
+	option        *Option
+	alembicOption *AlembicOption
+	fromTables    map[string]*octopus.Table
+}
+
+func NewAlembicChangeSetWriter(w io.Writer, option *Option, alembicOption *AlembicOption) *AlembicChangeSetWriter {
+	return &AlembicChangeSetWriter{
+		writer:        w,
+		option:        option,
+		alembicOption: alembicOption,
+	}
+}
+
+func (w *AlembicChangeSetWriter) writeLine(s string) error {
+	_, err := w.writer.Write([]byte(s + "\n"))
+	return err
+}
+
+// Write writes alembic revision file.
+// downgrade operations are written in reverse order of upgrade operations.
+func (w *AlembicChangeSetWriter) Write(result *Result) error {
+	w.fromTables = result.From.TablesByName()
+
+	var upgrades []string
+	var downgrades [][]string
+	for _, changeSet := range result.ChangeSets {
+		for _, change := range changeSet.Changes {
+			upgrade, downgrade, err := w.toOperations(change)
+			if err != nil {
+				return err
+			}
+			upgrades = append(upgrades, upgrade...)
+			downgrades = append(downgrades, downgrade)
+		}
+	}
+
+	var downgradeLines []string
+	for i := len(downgrades) - 1; i >= 0; i-- {
+		downgradeLines = append(downgradeLines, downgrades[i]...)
+	}
+
+	option := w.alembicOption
+	message := option.Message
+	if message == "" {
+		message = fmt.Sprintf("from %s to %s", result.From.Version, result.To.Version)
+	}
+	downRevision := "None"
+	if option.DownRevision != "" {
+		downRevision = pyQuote(option.DownRevision)
+	}
+
+	lines := []string{
+		`"""` + message,
+		"",
+		"Revision ID: " + option.Revision,
+		"Revises: " + option.DownRevision,
+		"",
+		`"""`,
+		"from alembic import op",
+		"import sqlalchemy as sa",
+		"",
+		"",
+		"# revision identifiers, used by Alembic.",
+		"revision = " + pyQuote(option.Revision),
+		"down_revision = " + downRevision,
+		"branch_labels = None",
+		"depends_on = None",
+		"",
+		"",
+		"def upgrade():",
+	}
+	lines = append(lines, functionBody(upgrades)...)
+	lines = append(lines, "", "", "def downgrade():")
+	lines = append(lines, functionBody(downgradeLines)...)
+
+	for _, line := range lines {
+		if err := w.writeLine(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func functionBody(operations []string) []string {
+	if len(operations) == 0 {
+		return []string{alembicIndent + "pass"}
+	}
+	var lines []string
+	for _, operation := range operations {
+		for _, line := range strings.Split(operation, "\n") {
+			lines = append(lines, alembicIndent+line)
+		}
+	}
+	return lines
+}
+
+// toOperations returns upgrade and downgrade operations of the change.
+func (w *AlembicChangeSetWriter) toOperations(change Change) ([]string, []string, error) {
+	switch change.(type) {
+	case *CreateTable:
+		return w.toCreateTableOps(change.(*CreateTable))
+	case *DropTable:
+		return w.toDropTableOps(change.(*DropTable))
+	case *RenameTable:
+		return w.toRenameTableOps(change.(*RenameTable))
+	case *UpdatePrimaryKey:
+		return w.toUpdatePrimaryKeyOps(change.(*UpdatePrimaryKey))
+	case *CreateUniqueConstraint:
+		return w.toCreateUniqueConstraintOps(change.(*CreateUniqueConstraint))
+	case *DropUniqueConstraint:
+		return w.toDropUniqueConstraintOps(change.(*DropUniqueConstraint))
+	case *SetTableComment:
+		return w.toSetTableCommentOps(change.(*SetTableComment))
+	case *AddColumn:
+		return w.toAddColumnOps(change.(*AddColumn))
+	case *DropColumn:
+		return w.toDropColumnOps(change.(*DropColumn))
+	case *SetColumnComment:
+		return w.toSetColumnCommentOps(change.(*SetColumnComment))
+	case *ChangeColumnType:
+		return w.toChangeColumnTypeOps(change.(*ChangeColumnType))
+	case *RenameColumn:
+		return w.toRenameColumnOps(change.(*RenameColumn))
+	case *SetNotNullConstraint:
+		return w.toSetNotNullConstraintOps(change.(*SetNotNullConstraint))
+	case *SetAutoIncrement:
+		return w.toSetAutoIncrementOps(change.(*SetAutoIncrement))
+	case *SetDefaultValue:
+		return w.toSetDefaultValueOps(change.(*SetDefaultValue))
+	default:
+		return nil, nil, fmt.Errorf("unhandled change type: %v", reflect.TypeOf(change))
+	}
+}
+
+// oldColumn returns column of 'from' schema.
+func (w *AlembicChangeSetWriter) oldColumn(tableName, columnName string) (*octopus.Column, error) {
+	if table := w.fromTables[tableName]; table != nil {
+		if column := table.ColumnByName(columnName); column != nil {
+			return column, nil
+		}
+	}
+	return nil, fmt.Errorf("column not found: %s.%s", tableName, columnName)
+}
+
+func (w *AlembicChangeSetWriter) toCreateTableOps(c *CreateTable) ([]string, []string, error) {
+	return []string{w.createTableOp(c.Table)},
+		[]string{fmt.Sprintf("op.drop_table(%s)", pyQuote(c.Table.Name))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toDropTableOps(c *DropTable) ([]string, []string, error) {
+	return []string{fmt.Sprintf("op.drop_table(%s)", pyQuote(c.Table.Name))},
+		[]string{w.createTableOp(c.Table)},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toRenameTableOps(c *RenameTable) ([]string, []string, error) {
+	oldName := pyQuote(c.OldTable.Name)
+	newName := pyQuote(c.NewTable.Name)
+	return []string{fmt.Sprintf("op.rename_table(%s, %s)", oldName, newName)},
+		[]string{fmt.Sprintf("op.rename_table(%s, %s)", newName, oldName)},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toUpdatePrimaryKeyOps(c *UpdatePrimaryKey) ([]string, []string, error) {
+	tableName := c.NewTable.Name
+	constraintName := c.ConstraintName
+	if constraintName == "" {
+		constraintName = tableName + "_pkey"
+	}
+	updatePrimaryKey := func(table *octopus.Table) []string {
+		ops := []string{dropConstraintOp(constraintName, tableName, "primary")}
+		if columnNames := primaryKeyColumnNames(table); len(columnNames) > 0 {
+			ops = append(ops, fmt.Sprintf("op.create_primary_key(%s, %s, [%s])",
+				pyQuote(constraintName), pyQuote(tableName), pyQuoteAndJoin(columnNames)))
+		}
+		return ops
+	}
+	return updatePrimaryKey(c.NewTable), updatePrimaryKey(c.OldTable), nil
+}
+
+func (w *AlembicChangeSetWriter) toCreateUniqueConstraintOps(c *CreateUniqueConstraint) ([]string, []string, error) {
+	return []string{createUniqueConstraintOp(c.ConstraintName, c.Table)},
+		[]string{dropConstraintOp(c.ConstraintName, c.Table.Name, "unique")},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toDropUniqueConstraintOps(c *DropUniqueConstraint) ([]string, []string, error) {
+	return []string{dropConstraintOp(c.ConstraintName, c.Table.Name, "unique")},
+		[]string{createUniqueConstraintOp(c.ConstraintName, c.Table)},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toSetTableCommentOps(c *SetTableComment) ([]string, []string, error) {
+	tableName := c.Table.Name
+	var oldComment string
+	if oldTable := w.fromTables[tableName]; oldTable != nil {
+		oldComment = oldTable.Description
+	}
+	setTableComment := func(comment, existingComment string) string {
+		if comment == "" {
+			return fmt.Sprintf("op.drop_table_comment(%s, existing_comment=%s)",
+				pyQuote(tableName), pyQuote(existingComment))
+		}
+		return fmt.Sprintf("op.create_table_comment(%s, %s, existing_comment=%s)",
+			pyQuote(tableName), pyQuote(comment), pyNullableQuote(existingComment))
+	}
+	return []string{setTableComment(c.Table.Description, oldComment)},
+		[]string{setTableComment(oldComment, c.Table.Description)},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toAddColumnOps(c *AddColumn) ([]string, []string, error) {
+	tableName := pyQuote(c.Table.Name)
+	return []string{fmt.Sprintf("op.add_column(%s, %s)", tableName, w.columnExpr(c.Column))},
+		[]string{fmt.Sprintf("op.drop_column(%s, %s)", tableName, pyQuote(c.Column.Name))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toDropColumnOps(c *DropColumn) ([]string, []string, error) {
+	oldColumn, err := w.oldColumn(c.Table.Name, c.ColumnName)
+	if err != nil {
+		return nil, nil, err
+	}
+	tableName := pyQuote(c.Table.Name)
+	return []string{fmt.Sprintf("op.drop_column(%s, %s)", tableName, pyQuote(c.ColumnName))},
+		[]string{fmt.Sprintf("op.add_column(%s, %s)", tableName, w.columnExpr(oldColumn))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toSetColumnCommentOps(c *SetColumnComment) ([]string, []string, error) {
+	oldColumn, err := w.oldColumn(c.Table.Name, c.Column.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{alterColumnOp(c.Table.Name, oldColumn,
+			"comment="+pyNullableQuote(c.Column.Description),
+			"existing_comment="+pyNullableQuote(oldColumn.Description))},
+		[]string{alterColumnOp(c.Table.Name, c.Column,
+			"comment="+pyNullableQuote(oldColumn.Description),
+			"existing_comment="+pyNullableQuote(c.Column.Description))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toChangeColumnTypeOps(c *ChangeColumnType) ([]string, []string, error) {
+	return []string{alterColumnOp(c.Table.Name, c.OldColumn, "type_="+columnTypeExpr(c.NewColumn))},
+		[]string{alterColumnOp(c.Table.Name, c.NewColumn, "type_="+columnTypeExpr(c.OldColumn))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toRenameColumnOps(c *RenameColumn) ([]string, []string, error) {
+	return []string{alterColumnOp(c.Table.Name, c.OldColumn, "new_column_name="+pyQuote(c.NewColumn.Name))},
+		[]string{alterColumnOp(c.Table.Name, c.NewColumn, "new_column_name="+pyQuote(c.OldColumn.Name))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toSetNotNullConstraintOps(c *SetNotNullConstraint) ([]string, []string, error) {
+	oldColumn, err := w.oldColumn(c.Table.Name, c.Column.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{alterColumnOp(c.Table.Name, oldColumn, "nullable="+pyBool(!c.Column.NotNull))},
+		[]string{alterColumnOp(c.Table.Name, c.Column, "nullable="+pyBool(!oldColumn.NotNull))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toSetAutoIncrementOps(c *SetAutoIncrement) ([]string, []string, error) {
+	oldColumn, err := w.oldColumn(c.Table.Name, c.Column.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{alterColumnOp(c.Table.Name, oldColumn, "autoincrement="+pyBool(c.Column.AutoIncremental))},
+		[]string{alterColumnOp(c.Table.Name, c.Column, "autoincrement="+pyBool(oldColumn.AutoIncremental))},
+		nil
+}
+
+func (w *AlembicChangeSetWriter) toSetDefaultValueOps(c *SetDefaultValue) ([]string, []string, error) {
+	oldColumn, err := w.oldColumn(c.Table.Name, c.Column.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{alterColumnOp(c.Table.Name, oldColumn,
+			"server_default="+serverDefaultExpr(c.Column),
+			"existing_server_default="+serverDefaultExpr(oldColumn))},
+		[]string{alterColumnOp(c.Table.Name, c.Column,
+			"server_default="+serverDefaultExpr(oldColumn),
+			"existing_server_default="+serverDefaultExpr(c.Column))},
+		nil
+}
+
+// createTableOp returns 'op.create_table()' with columns and constraints.
+func (w *AlembicChangeSetWriter) createTableOp(table *octopus.Table) string {
+	args := []string{pyQuote(table.Name)}
+	for _, column := range table.Columns {
+		args = append(args, w.columnExpr(column))
+	}
+	if columnNames := primaryKeyColumnNames(table); len(columnNames) > 0 {
+		args = append(args, fmt.Sprintf("sa.PrimaryKeyConstraint(%s)", pyQuoteAndJoin(columnNames)))
+	}
+	if columnNames := uniqueKeyColumnNames(table); len(columnNames) > 0 {
+		args = append(args, fmt.Sprintf("sa.UniqueConstraint(%s, name=%s)",
+			pyQuoteAndJoin(columnNames), pyQuote(table.Name+w.option.UniqueNameSuffix)))
+	}
+	if w.option.UseComments && table.Description != "" {
+		args = append(args, "comment="+pyQuote(table.Description))
+	}
+
+	lines := []string{"op.create_table("}
+	for _, arg := range args {
+		lines = append(lines, alembicIndent+arg+",")
+	}
+	lines = append(lines, ")")
+	return strings.Join(lines, "\n")
+}
+
+// columnExpr returns 'sa.Column()' expression of the column.
+func (w *AlembicChangeSetWriter) columnExpr(column *octopus.Column) string {
+	args := []string{pyQuote(column.Name), columnTypeExpr(column)}
+	if column.AutoIncremental {
+		args = append(args, "autoincrement=True")
+	}
+	args = append(args, "nullable="+pyBool(!column.NotNull && !column.PrimaryKey))
+	if column.DefaultValue != "" {
+		args = append(args, "server_default="+serverDefaultExpr(column))
+	}
+	if w.option.UseComments && column.Description != "" {
+		args = append(args, "comment="+pyQuote(column.Description))
+	}
+	return fmt.Sprintf("sa.Column(%s)", strings.Join(args, ", "))
+}
+
+// columnTypeExpr returns sqlalchemy type expression of the column. ex: 'sa.String(length=40)'
+func columnTypeExpr(column *octopus.Column) string {
+	typeExpr := sqlalchemy.NewSaField(column).TypeExpr()
+	if typeExpr == "" {
+		switch strings.ToLower(column.Type) {
+		case octopus.ColTypeEnum:
+			fallthrough
+		case octopus.ColTypeSet:
+			typeExpr = fmt.Sprintf("Enum(%s)", pyQuoteAndJoin(column.Values))
+		case octopus.ColTypeJSON:
+			typeExpr = "JSON"
+		case octopus.ColTypeBinary:
+			fallthrough
+		case octopus.ColTypeVarbinary:
+			typeExpr = "LargeBinary"
+		default:
+			typeExpr = "String"
+		}
+	}
+	if !strings.HasSuffix(typeExpr, ")") {
+		typeExpr += "()"
+	}
+	return "sa." + typeExpr
+}
+
+// alterColumnOp returns 'op.alter_column()' with existing column type and nullability.
+func alterColumnOp(tableName string, existingColumn *octopus.Column, args ...string) string {
+	args = append([]string{pyQuote(tableName), pyQuote(existingColumn.Name)}, args...)
+	args = append(args,
+		"existing_type="+columnTypeExpr(existingColumn),
+		"existing_nullable="+pyBool(!existingColumn.NotNull))
+	return fmt.Sprintf("op.alter_column(%s)", strings.Join(args, ", "))
+}
+
+func dropConstraintOp(constraintName, tableName, constraintType string) string {
+	return fmt.Sprintf("op.drop_constraint(%s, %s, type_=%s)",
+		pyQuote(constraintName), pyQuote(tableName), pyQuote(constraintType))
+}
+
+func createUniqueConstraintOp(constraintName string, table *octopus.Table) string {
+	return fmt.Sprintf("op.create_unique_constraint(%s, %s, [%s])",
+		pyQuote(constraintName), pyQuote(table.Name), pyQuoteAndJoin(uniqueKeyColumnNames(table)))
+}
+
+func primaryKeyColumnNames(table *octopus.Table) []string {
+	var names []string
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			names = append(names, column.Name)
+		}
+	}
+	return names
+}
+
+func uniqueKeyColumnNames(table *octopus.Table) []string {
+	var names []string
+	for _, column := range table.Columns {
+		if column.UniqueKey {
+			names = append(names, column.Name)
+		}
+	}
+	return names
+}
+
+// serverDefaultExpr returns 'server_default' value of the column.
+// function is called with 'sa.text()', and default value of string type is quoted.
+func serverDefaultExpr(column *octopus.Column) string {
+	if column.DefaultValue == "" {
+		return "None"
+	}
+	defaultValue, fn := column.GetDefaultValue()
+	if fn {
+		return fmt.Sprintf("sa.text(%s)", pyQuote(defaultValue+"()"))
+	}
+	if isQuotedDefaultType(column.Type) {
+		return pyQuote(defaultValue)
+	}
+	return fmt.Sprintf("sa.text(%s)", pyQuote(defaultValue))
+}
+
+// isQuotedDefaultType returns true if default value of the column type is string literal.
+func isQuotedDefaultType(colType string) bool {
+	switch colType {
+	case octopus.ColTypeEnum, octopus.ColTypeSet,
+		octopus.ColTypeText8, octopus.ColTypeText16, octopus.ColTypeText24, octopus.ColTypeText32:
+		return true
+	}
+	return util.IsStringType(colType)
+}
+
+// pyQuote returns single-quoted python string literal.
+func pyQuote(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "'", "\\'")
+	return "'" + s + "'"
+}
+
+// pyNullableQuote returns 'None' if s is empty.
+func pyNullableQuote(s string) string {
+	if s == "" {
+		return "None"
+	}
+	return pyQuote(s)
+}
+
+func pyQuoteAndJoin(values []string) string {
+	var quoted []string
+	for _, value := range values {
+		quoted = append(quoted, pyQuote(value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func pyBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
@@ -0,0 +1,300 @@
+package diff
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func TestAlembicChangeSetWriter_Write(t *testing.T) {
+	Convey("Write", t, func() {
+		fromSchema := &octopus.Schema{
+			Version: "1.0.0",
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							NotNull:         true,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name: "name",
+							Type: octopus.ColTypeVarchar,
+							Size: 40,
+						},
+						{
+							Name: "nickname",
+							Type: octopus.ColTypeVarchar,
+							Size: 20,
+						},
+					},
+				},
+			},
+		}
+		toSchema := &octopus.Schema{
+			Version: "1.0.1",
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							NotNull:         true,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name:    "name",
+							Type:    octopus.ColTypeVarchar,
+							Size:    40,
+							NotNull: true,
+						},
+						{
+							Name:         "point",
+							Type:         octopus.ColTypeDecimal,
+							Size:         10,
+							Scale:        2,
+							DefaultValue: "0",
+						},
+					},
+				},
+				{
+					Name: "group",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							NotNull:         true,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name:      "name",
+							Type:      octopus.ColTypeVarchar,
+							Size:      40,
+							NotNull:   true,
+							UniqueKey: true,
+						},
+						{
+							Name:   "type",
+							Type:   octopus.ColTypeEnum,
+							Values: []string{"admin", "user"},
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			DiffFrom:         fromSchema,
+			DiffTo:           toSchema,
+			UniqueNameSuffix: "_uq",
+		}
+		alembicOption := &AlembicOption{
+			Revision:     "1f2e3d4c5b6a",
+			DownRevision: "0a1b2c3d4e5f",
+		}
+		expected := `"""from 1.0.0 to 1.0.1
+
+Revision ID: 1f2e3d4c5b6a
+Revises: 0a1b2c3d4e5f
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '1f2e3d4c5b6a'
+down_revision = '0a1b2c3d4e5f'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.alter_column('user', 'name', nullable=False, existing_type=sa.String(40), existing_nullable=True)
+    op.drop_column('user', 'nickname')
+    op.add_column('user', sa.Column('point', sa.Numeric(precision=10, scale=2), nullable=True, server_default=sa.text('0')))
+    op.create_table(
+        'group',
+        sa.Column('id', sa.BigInteger(), autoincrement=True, nullable=False),
+        sa.Column('name', sa.String(40), nullable=False),
+        sa.Column('type', sa.Enum('admin', 'user'), nullable=True),
+        sa.PrimaryKeyConstraint('id'),
+        sa.UniqueConstraint('name', name='group_uq'),
+    )
+
+
+def downgrade():
+    op.drop_table('group')
+    op.drop_column('user', 'point')
+    op.add_column('user', sa.Column('nickname', sa.String(20), nullable=True))
+    op.alter_column('user', 'name', nullable=True, existing_type=sa.String(40), existing_nullable=False)
+`
+
+		result, err := GetDiff(option)
+		if err != nil {
+			t.Error(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := NewAlembicChangeSetWriter(buf, option, alembicOption).Write(result); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestAlembicChangeSetWriter_ServerDefault(t *testing.T) {
+	Convey("ServerDefault", t, func() {
+		fromSchema := &octopus.Schema{
+			Version: "1.0.0",
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:       "id",
+							Type:       octopus.ColTypeInt64,
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:         "level",
+							Type:         octopus.ColTypeInt32,
+							NotNull:      true,
+							DefaultValue: "0",
+						},
+					},
+				},
+			},
+		}
+		createdAt := &octopus.Column{
+			Name:    "created_at",
+			Type:    octopus.ColTypeDateTime,
+			NotNull: true,
+		}
+		createdAt.SetDefaultValueFn("NOW")
+		updatedAt := &octopus.Column{
+			Name: "updated_at",
+			Type: octopus.ColTypeDateTime,
+		}
+		updatedAt.SetDefaultValueFn("NOW")
+		toSchema := &octopus.Schema{
+			Version: "1.0.1",
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:       "id",
+							Type:       octopus.ColTypeInt64,
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:         "level",
+							Type:         octopus.ColTypeInt32,
+							NotNull:      true,
+							DefaultValue: "1",
+						},
+						{
+							Name:         "nickname",
+							Type:         octopus.ColTypeVarchar,
+							Size:         20,
+							DefaultValue: "it's me",
+						},
+						updatedAt,
+					},
+				},
+				{
+					Name: "group",
+					Columns: []*octopus.Column{
+						{
+							Name:       "id",
+							Type:       octopus.ColTypeInt64,
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:         "type",
+							Type:         octopus.ColTypeEnum,
+							Values:       []string{"admin", "user"},
+							NotNull:      true,
+							DefaultValue: "user",
+						},
+						createdAt,
+					},
+				},
+			},
+		}
+		option := &Option{
+			DiffFrom: fromSchema,
+			DiffTo:   toSchema,
+		}
+		alembicOption := &AlembicOption{
+			Revision:     "1f2e3d4c5b6a",
+			DownRevision: "0a1b2c3d4e5f",
+		}
+		expected := `"""from 1.0.0 to 1.0.1
+
+Revision ID: 1f2e3d4c5b6a
+Revises: 0a1b2c3d4e5f
+
+"""
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision = '1f2e3d4c5b6a'
+down_revision = '0a1b2c3d4e5f'
+branch_labels = None
+depends_on = None
+
+
+def upgrade():
+    op.alter_column('user', 'level', server_default=sa.text('1'), existing_server_default=sa.text('0'), existing_type=sa.Integer(), existing_nullable=False)
+    op.add_column('user', sa.Column('nickname', sa.String(20), nullable=True, server_default='it\'s me'))
+    op.add_column('user', sa.Column('updated_at', sa.DateTime(), nullable=True, server_default=sa.text('NOW()')))
+    op.create_table(
+        'group',
+        sa.Column('id', sa.BigInteger(), nullable=False),
+        sa.Column('type', sa.Enum('admin', 'user'), nullable=False, server_default='user'),
+        sa.Column('created_at', sa.DateTime(), nullable=False, server_default=sa.text('NOW()')),
+        sa.PrimaryKeyConstraint('id'),
+    )
+
+
+def downgrade():
+    op.drop_table('group')
+    op.drop_column('user', 'updated_at')
+    op.drop_column('user', 'nickname')
+    op.alter_column('user', 'level', server_default=sa.text('0'), existing_server_default=sa.text('1'), existing_type=sa.Integer(), existing_nullable=False)
+`
+
+		result, err := GetDiff(option)
+		if err != nil {
+			t.Error(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := NewAlembicChangeSetWriter(buf, option, alembicOption).Write(result); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
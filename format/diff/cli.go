@@ -2,6 +2,8 @@ package diff
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"github.com/lechuckroh/octopus-db-tools/format/liquibase"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
@@ -10,9 +12,12 @@ import (
 
 const (
 	FlagAuthor           = "author"
+	FlagDownRevision     = "downRevision"
 	FlagFrom             = "from"
 	FlagGroups           = "groups"
+	FlagMessage          = "message"
 	FlagOutput           = "output"
+	FlagRevision         = "revision"
 	FlagTo               = "to"
 	FlagUniqueNameSuffix = "uniqueNameSuffix"
 	FlagUseComments      = "comments"
@@ -45,7 +50,7 @@ func FlywayAction(c *cli.Context) error {
 		UniqueNameSuffix: c.String(FlagUniqueNameSuffix),
 		UseComments:      c.Bool(FlagUseComments),
 	}
-	result, err := getDiff(option)
+	result, err := GetDiff(option)
 	if err != nil {
 		return err
 	}
@@ -58,6 +63,54 @@ func FlywayAction(c *cli.Context) error {
 	return util.WriteStringToFile(c.String(FlagOutput), buf.String())
 }
 
+func AlembicAction(c *cli.Context) error {
+	fromSchema, toSchema, err := loadSchema(c.String(FlagFrom), c.String(FlagTo))
+	if err != nil {
+		return err
+	}
+
+	// diff
+	option := &Option{
+		TableFilter:      octopus.GetTableFilterFn(c.String(FlagGroups)),
+		DiffFrom:         fromSchema,
+		DiffTo:           toSchema,
+		Author:           c.String(FlagAuthor),
+		UniqueNameSuffix: c.String(FlagUniqueNameSuffix),
+		UseComments:      c.Bool(FlagUseComments),
+	}
+	result, err := GetDiff(option)
+	if err != nil {
+		return err
+	}
+
+	revision := c.String(FlagRevision)
+	if revision == "" {
+		if revision, err = newRevision(); err != nil {
+			return err
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := NewAlembicChangeSetWriter(buf, option, &AlembicOption{
+		Revision:     revision,
+		DownRevision: c.String(FlagDownRevision),
+		Message:      c.String(FlagMessage),
+	}).Write(result); err != nil {
+		return err
+	}
+	// write to file
+	return util.WriteStringToFile(c.String(FlagOutput), buf.String())
+}
+
+// newRevision returns random 12 hex digits revision ID.
+func newRevision() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func LiquibaseAction(c *cli.Context) error {
 	fromSchema, err := octopus.LoadSchema(c.String(FlagFrom))
 	if err != nil {
@@ -94,7 +147,7 @@ func MarkdownAction(c *cli.Context) error {
 		UniqueNameSuffix: c.String(FlagUniqueNameSuffix),
 		UseComments:      c.Bool(FlagUseComments),
 	}
-	result, err := getDiff(option)
+	result, err := GetDiff(option)
 	if err != nil {
 		return err
 	}
@@ -154,3 +207,24 @@ var CliFlags = []cli.Flag{
 		EnvVars: []string{"OCTOPUS_COMMENTS"},
 	},
 }
+
+var AlembicCliFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:    FlagDownRevision,
+		Aliases: []string{"d"},
+		Usage:   "previous alembic revision ID",
+		EnvVars: []string{"OCTOPUS_DOWN_REVISION"},
+	},
+	&cli.StringFlag{
+		Name:    FlagMessage,
+		Aliases: []string{"m"},
+		Usage:   "alembic revision message",
+		EnvVars: []string{"OCTOPUS_MESSAGE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRevision,
+		Aliases: []string{"r"},
+		Usage:   "alembic revision ID. random ID is generated if not set",
+		EnvVars: []string{"OCTOPUS_REVISION"},
+	},
+}, CliFlags...)
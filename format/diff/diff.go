@@ -23,7 +23,8 @@ type Option struct {
 	UseComments      bool
 }
 
-func getDiff(option *Option) (*Result, error) {
+// GetDiff compares DiffFrom and DiffTo schemas and returns changes.
+func GetDiff(option *Option) (*Result, error) {
 	result := Result{From: option.DiffFrom, To: option.DiffTo}
 
 	uniqueNameSuffix := option.UniqueNameSuffix
@@ -249,6 +250,7 @@ func diffTable(
 				AfterColumn:  nil,
 			})
 		}
+		changeSets = append(changeSets, changeSet)
 	}
 
 	// primary key
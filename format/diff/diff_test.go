@@ -0,0 +1,81 @@
+package diff
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+// newAddColumnOption returns diff option where 'point' column is added to 'user' table.
+func newAddColumnOption() *Option {
+	newUserTable := func(columns ...*octopus.Column) *octopus.Table {
+		return &octopus.Table{
+			Name: "user",
+			Columns: append([]*octopus.Column{
+				{
+					Name:       "id",
+					Type:       octopus.ColTypeInt64,
+					NotNull:    true,
+					PrimaryKey: true,
+				},
+			}, columns...),
+		}
+	}
+	return &Option{
+		DiffFrom: &octopus.Schema{
+			Version: "1.0.0",
+			Tables:  []*octopus.Table{newUserTable()},
+		},
+		DiffTo: &octopus.Schema{
+			Version: "1.0.1",
+			Tables: []*octopus.Table{newUserTable(&octopus.Column{
+				Name:    "point",
+				Type:    octopus.ColTypeInt32,
+				NotNull: true,
+			})},
+		},
+	}
+}
+
+func TestDiff_AddColumn(t *testing.T) {
+	Convey("GetDiff with added column", t, func() {
+		option := newAddColumnOption()
+		result, err := GetDiff(option)
+		So(err, ShouldBeNil)
+		So(result.ChangeSets, ShouldHaveLength, 1)
+		So(result.ChangeSets[0].Changes, ShouldHaveLength, 1)
+
+		Convey("flyway", func() {
+			expected := "ALTER TABLE user ADD COLUMN point int NOT NULL;\n\n"
+
+			buf := new(bytes.Buffer)
+			So(NewFlywayChangeSetWirter(buf, option).Write(result), ShouldBeNil)
+			actual := buf.String()
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("markdown", func() {
+			expected := "# DB Schema changes\n" +
+				"\n" +
+				"* from: `1.0.0`\n" +
+				"* to: `1.0.1`\n" +
+				"\n" +
+				"## user\n" +
+				"* add column: `point`\n"
+
+			buf := new(bytes.Buffer)
+			So(NewMarkdownChangeSetWirter(buf, option).Write(result), ShouldBeNil)
+			actual := buf.String()
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+	})
+}
@@ -60,6 +60,36 @@ func (w *MarkdownWriter) WriteSQLs(lines []string) {
 	w.WriteCodes("sql", lines)
 }
 
+// WriteTable writes a table. '|', '<', '>' and line breaks in cells are escaped.
+func (w *MarkdownWriter) WriteTable(headers []string, rows [][]string) {
+	w.WriteLine(toTableRow(headers))
+	separators := make([]string, len(headers))
+	for i, header := range headers {
+		separators[i] = strings.Repeat("-", len(header))
+	}
+	w.WriteLine(toTableRow(separators))
+	for _, row := range rows {
+		w.WriteLine(toTableRow(row))
+	}
+}
+
+func toTableRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = EscapeMarkdown(cell)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// EscapeMarkdown escapes '|', '<', '>' and line breaks, so that text can be written in a single line or a table cell.
+func EscapeMarkdown(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "<", "&lt;")
+	text = strings.ReplaceAll(text, ">", "&gt;")
+	text = strings.ReplaceAll(text, "\r\n", "<br />")
+	return strings.ReplaceAll(text, "\n", "<br />")
+}
+
 func NewMarkdownWriter(writer io.Writer) *MarkdownWriter {
 	return &MarkdownWriter{writer: writer}
 }
@@ -118,6 +148,11 @@ func (w *MarkdownChangeSetWriter) Write(result *Result) error {
 	return nil
 }
 
+// DescribeChange returns a markdown line describing the change.
+func DescribeChange(change Change) (string, error) {
+	return (&MarkdownChangeSetWriter{}).toMarkdownLine(change)
+}
+
 func (w *MarkdownChangeSetWriter) toMarkdownLine(change Change) (string, error) {
 	switch change.(type) {
 	case *CreateTable:
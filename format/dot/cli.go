@@ -0,0 +1,114 @@
+package dot
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagColumns = "columns"
+	FlagFocus   = "focus"
+	FlagGroups  = "groups"
+	FlagHops    = "hops"
+	FlagInput   = "input"
+	FlagOutput  = "output"
+	FlagRankDir = "rankdir"
+)
+
+func ExportAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	columns := c.String(FlagColumns)
+	if columns != ColumnsAll && columns != ColumnsKeys {
+		return fmt.Errorf("invalid columns: %s", columns)
+	}
+	rankDir := strings.ToUpper(c.String(FlagRankDir))
+	if !util.NewStringSet("TB", "LR", "BT", "RL").Contains(rankDir) {
+		return fmt.Errorf("invalid rankdir: %s", rankDir)
+	}
+
+	exporter := NewExporter(schema, &Option{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		RankDir:     rankDir,
+		Columns:     columns,
+		Focus:       c.String(FlagFocus),
+		Hops:        c.Int(FlagHops),
+	})
+
+	outputPath := c.String(FlagOutput)
+	var filename string
+	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".dot" || ext == ".gv" {
+		filename = outputPath
+	} else {
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, "output.dot")
+	}
+
+	buf := new(bytes.Buffer)
+	if err = exporter.Export(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+var ExportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "export graphviz DOT to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagColumns,
+		Aliases: []string{"c"},
+		Usage:   "columns to show. available values: all, keys",
+		Value:   ColumnsAll,
+		EnvVars: []string{"OCTOPUS_COLUMNS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagFocus,
+		Aliases: []string{"f"},
+		Usage:   "render only tables around the `TABLE`",
+		EnvVars: []string{"OCTOPUS_FOCUS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.IntFlag{
+		Name:    FlagHops,
+		Usage:   "number of reference hops from the focused table",
+		Value:   1,
+		EnvVars: []string{"OCTOPUS_HOPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRankDir,
+		Aliases: []string{"r"},
+		Usage:   "graph layout direction. available values: TB, LR, BT, RL",
+		Value:   "LR",
+		EnvVars: []string{"OCTOPUS_RANKDIR"},
+	},
+}
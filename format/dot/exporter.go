@@ -0,0 +1,265 @@
+package dot
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	ColumnsAll  = "all"
+	ColumnsKeys = "keys"
+)
+
+type Option struct {
+	TableFilter octopus.TableFilterFn
+	RankDir     string
+	Columns     string
+	Focus       string
+	Hops        int
+}
+
+type Exporter struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewExporter(schema *octopus.Schema, option *Option) *Exporter {
+	return &Exporter{schema: schema, option: option}
+}
+
+// edge is a reference between columns. edge starts from foreign key column.
+type edge struct {
+	fromTable    string
+	fromColumn   string
+	toTable      string
+	toColumn     string
+	relationship string
+}
+
+// edges returns references between tables. '1:n' reference is reversed to start from foreign key column.
+func edges(tables []*octopus.Table) []*edge {
+	var result []*edge
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			ref := column.Ref
+			if ref == nil {
+				continue
+			}
+			if ref.Relationship == octopus.RefOneToMany {
+				result = append(result, &edge{
+					fromTable:    ref.Table,
+					fromColumn:   ref.Column,
+					toTable:      table.Name,
+					toColumn:     column.Name,
+					relationship: octopus.RefManyToOne,
+				})
+			} else {
+				result = append(result, &edge{
+					fromTable:    table.Name,
+					fromColumn:   column.Name,
+					toTable:      ref.Table,
+					toColumn:     ref.Column,
+					relationship: ref.Relationship,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// filterTables returns tables to export.
+// If Focus is set, only tables within Hops references from the focused table are returned.
+func (c *Exporter) filterTables() ([]*octopus.Table, error) {
+	tables := c.schema.FilteredTables(c.option.TableFilter)
+
+	focus := c.option.Focus
+	if focus == "" {
+		return tables, nil
+	}
+
+	found := false
+	for _, table := range tables {
+		if table.Name == focus {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("focus table not found: %s", focus)
+	}
+
+	neighbors := make(map[string][]string)
+	for _, e := range edges(tables) {
+		neighbors[e.fromTable] = append(neighbors[e.fromTable], e.toTable)
+		neighbors[e.toTable] = append(neighbors[e.toTable], e.fromTable)
+	}
+
+	visited := util.NewStringSet(focus)
+	current := []string{focus}
+	for hop := 0; hop < c.option.Hops && len(current) > 0; hop++ {
+		var next []string
+		for _, name := range current {
+			for _, neighbor := range neighbors[name] {
+				if !visited.Contains(neighbor) {
+					visited.Add(neighbor)
+					next = append(next, neighbor)
+				}
+			}
+		}
+		current = next
+	}
+
+	var result []*octopus.Table
+	for _, table := range tables {
+		if visited.Contains(table.Name) {
+			result = append(result, table)
+		}
+	}
+	return result, nil
+}
+
+// Export writes graphviz DOT graph. Tables of same group are clustered.
+func (c *Exporter) Export(wr io.Writer) error {
+	tables, err := c.filterTables()
+	if err != nil {
+		return err
+	}
+
+	tableNames := util.NewStringSet()
+	for _, table := range tables {
+		tableNames.Add(table.Name)
+	}
+	var tableEdges []*edge
+	referencedColumns := util.NewStringSet()
+	for _, e := range edges(tables) {
+		if tableNames.Contains(e.fromTable) && tableNames.Contains(e.toTable) {
+			tableEdges = append(tableEdges, e)
+			referencedColumns.Add(e.fromTable + "." + e.fromColumn)
+			referencedColumns.Add(e.toTable + "." + e.toColumn)
+		}
+	}
+
+	rankDir := c.option.RankDir
+	if rankDir == "" {
+		rankDir = "LR"
+	}
+
+	result := []string{
+		"digraph schema {",
+		fmt.Sprintf("  graph [rankdir=%s];", rankDir),
+		`  node [shape=plaintext, fontname="Helvetica"];`,
+		`  edge [fontname="Helvetica", dir=both];`,
+	}
+
+	// group tables
+	var groupNames []string
+	tablesByGroup := make(map[string][]*octopus.Table)
+	for _, table := range tables {
+		if _, ok := tablesByGroup[table.Group]; !ok {
+			groupNames = append(groupNames, table.Group)
+		}
+		tablesByGroup[table.Group] = append(tablesByGroup[table.Group], table)
+	}
+
+	for _, group := range groupNames {
+		indent := "  "
+		result = append(result, "")
+		if group != "" {
+			result = append(result,
+				fmt.Sprintf("  subgraph %s {", quote("cluster_"+clusterName(group))),
+				fmt.Sprintf("    label=%s;", quote(group)))
+			indent = "    "
+		}
+		for _, table := range tablesByGroup[group] {
+			for _, line := range c.nodeLines(table, referencedColumns) {
+				result = append(result, indent+line)
+			}
+		}
+		if group != "" {
+			result = append(result, "  }")
+		}
+	}
+
+	if len(tableEdges) > 0 {
+		result = append(result, "")
+	}
+	for _, e := range tableEdges {
+		result = append(result, fmt.Sprintf("  %s -> %s [%s];",
+			endpoint(e.fromTable, e.fromColumn),
+			endpoint(e.toTable, e.toColumn),
+			getArrows(e.relationship)))
+	}
+	result = append(result, "}")
+
+	_, err = io.WriteString(wr, strings.Join(result, "\n")+"\n")
+	return err
+}
+
+func (c *Exporter) showColumn(table *octopus.Table, column *octopus.Column, referencedColumns *util.StringSet) bool {
+	if c.option.Columns != ColumnsKeys {
+		return true
+	}
+	return column.PrimaryKey || column.UniqueKey || column.Ref != nil ||
+		referencedColumns.Contains(table.Name+"."+column.Name)
+}
+
+// endpoint returns node port of the column. referenced columns are always shown, so the port exists.
+func endpoint(tableName, columnName string) string {
+	return quote(tableName) + ":" + quote(columnName)
+}
+
+// nodeLines returns node definition lines of the table with HTML-like label.
+func (c *Exporter) nodeLines(table *octopus.Table, referencedColumns *util.StringSet) []string {
+	result := []string{
+		fmt.Sprintf("%s [label=<", quote(table.Name)),
+		`  <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+		fmt.Sprintf(`    <tr><td colspan="3" bgcolor="lightgrey"><b>%s</b></td></tr>`, html.EscapeString(table.Name)),
+	}
+	for _, column := range table.Columns {
+		if !c.showColumn(table, column, referencedColumns) {
+			continue
+		}
+		name := html.EscapeString(column.Name)
+		if column.PrimaryKey {
+			name = "<u>" + name + "</u>"
+		}
+		result = append(result, fmt.Sprintf(
+			`    <tr><td port="%s" align="left">%s</td><td align="left">%s</td><td>%s</td></tr>`,
+			html.EscapeString(column.Name), name, html.EscapeString(getColumnType(column)), strings.Join(column.Keys(), ",")))
+	}
+	return append(result, "  </table>", ">];")
+}
+
+func getColumnType(column *octopus.Column) string {
+	if column.Size > 0 {
+		if column.Scale > 0 {
+			return fmt.Sprintf("%s(%d,%d)", column.Type, column.Size, column.Scale)
+		}
+		return fmt.Sprintf("%s(%d)", column.Type, column.Size)
+	}
+	return column.Type
+}
+
+// getArrows returns edge attributes from foreign key column to referenced column.
+func getArrows(relationship string) string {
+	switch relationship {
+	case octopus.RefOneToOne:
+		return "arrowtail=teeodot, arrowhead=tee"
+	default:
+		return "arrowtail=crowodot, arrowhead=tee"
+	}
+}
+
+var nonIdentifierRegexp = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func clusterName(group string) string {
+	return nonIdentifierRegexp.ReplaceAllString(group, "_")
+}
+
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
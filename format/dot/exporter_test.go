@@ -0,0 +1,176 @@
+package dot
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"strings"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:  "user",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{Name: "name", Type: "varchar", Size: 40, UniqueKey: true},
+				{Name: "group_id", Type: "int64", Ref: &octopus.Reference{Table: "group", Column: "id"}},
+			},
+		},
+		{
+			Name:  "group",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{Name: "title", Type: "varchar", Size: 20},
+			},
+		},
+		{
+			Name:  "order",
+			Group: "shop & pay",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{Name: "amount", Type: "decimal", Size: 10, Scale: 2},
+				{Name: "user_id", Type: "int64", Ref: &octopus.Reference{Table: "user", Column: "id", Relationship: octopus.RefOneToOne}},
+				{Name: "code", Type: "varchar", Ref: &octopus.Reference{Table: "order_item", Column: "order_code", Relationship: octopus.RefOneToMany}},
+			},
+		},
+		{
+			Name: "order_item",
+			Columns: []*octopus.Column{
+				{Name: "order_code", Type: "varchar"},
+				{Name: "quantity", Type: "int32"},
+			},
+		},
+	},
+}
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		expected := strings.Join([]string{
+			"digraph schema {",
+			"  graph [rankdir=TB];",
+			`  node [shape=plaintext, fontname="Helvetica"];`,
+			`  edge [fontname="Helvetica", dir=both];`,
+			"",
+			`  subgraph "cluster_common" {`,
+			`    label="common";`,
+			`    "user" [label=<`,
+			`      <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`        <tr><td colspan="3" bgcolor="lightgrey"><b>user</b></td></tr>`,
+			`        <tr><td port="id" align="left"><u>id</u></td><td align="left">int64</td><td>PK</td></tr>`,
+			`        <tr><td port="name" align="left">name</td><td align="left">varchar(40)</td><td>UK</td></tr>`,
+			`        <tr><td port="group_id" align="left">group_id</td><td align="left">int64</td><td>FK</td></tr>`,
+			`      </table>`,
+			`    >];`,
+			`    "group" [label=<`,
+			`      <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`        <tr><td colspan="3" bgcolor="lightgrey"><b>group</b></td></tr>`,
+			`        <tr><td port="id" align="left"><u>id</u></td><td align="left">int64</td><td>PK</td></tr>`,
+			`        <tr><td port="title" align="left">title</td><td align="left">varchar(20)</td><td></td></tr>`,
+			`      </table>`,
+			`    >];`,
+			"  }",
+			"",
+			`  subgraph "cluster_shop___pay" {`,
+			`    label="shop & pay";`,
+			`    "order" [label=<`,
+			`      <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`        <tr><td colspan="3" bgcolor="lightgrey"><b>order</b></td></tr>`,
+			`        <tr><td port="id" align="left"><u>id</u></td><td align="left">int64</td><td>PK</td></tr>`,
+			`        <tr><td port="amount" align="left">amount</td><td align="left">decimal(10,2)</td><td></td></tr>`,
+			`        <tr><td port="user_id" align="left">user_id</td><td align="left">int64</td><td>FK</td></tr>`,
+			`        <tr><td port="code" align="left">code</td><td align="left">varchar</td><td></td></tr>`,
+			`      </table>`,
+			`    >];`,
+			"  }",
+			"",
+			`  "order_item" [label=<`,
+			`    <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`      <tr><td colspan="3" bgcolor="lightgrey"><b>order_item</b></td></tr>`,
+			`      <tr><td port="order_code" align="left">order_code</td><td align="left">varchar</td><td></td></tr>`,
+			`      <tr><td port="quantity" align="left">quantity</td><td align="left">int32</td><td></td></tr>`,
+			`    </table>`,
+			`  >];`,
+			"",
+			`  "user":"group_id" -> "group":"id" [arrowtail=crowodot, arrowhead=tee];`,
+			`  "order":"user_id" -> "user":"id" [arrowtail=teeodot, arrowhead=tee];`,
+			`  "order_item":"order_code" -> "order":"code" [arrowtail=crowodot, arrowhead=tee];`,
+			"}",
+			"",
+		}, "\n")
+
+		exporter := NewExporter(testSchema, &Option{RankDir: "TB", Columns: ColumnsAll})
+		buf := new(bytes.Buffer)
+		So(exporter.Export(buf), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("Export keys around focused table", t, func() {
+		expected := strings.Join([]string{
+			"digraph schema {",
+			"  graph [rankdir=LR];",
+			`  node [shape=plaintext, fontname="Helvetica"];`,
+			`  edge [fontname="Helvetica", dir=both];`,
+			"",
+			`  subgraph "cluster_common" {`,
+			`    label="common";`,
+			`    "user" [label=<`,
+			`      <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`        <tr><td colspan="3" bgcolor="lightgrey"><b>user</b></td></tr>`,
+			`        <tr><td port="id" align="left"><u>id</u></td><td align="left">int64</td><td>PK</td></tr>`,
+			`        <tr><td port="name" align="left">name</td><td align="left">varchar(40)</td><td>UK</td></tr>`,
+			`        <tr><td port="group_id" align="left">group_id</td><td align="left">int64</td><td>FK</td></tr>`,
+			`      </table>`,
+			`    >];`,
+			`    "group" [label=<`,
+			`      <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`        <tr><td colspan="3" bgcolor="lightgrey"><b>group</b></td></tr>`,
+			`        <tr><td port="id" align="left"><u>id</u></td><td align="left">int64</td><td>PK</td></tr>`,
+			`      </table>`,
+			`    >];`,
+			"  }",
+			"",
+			`  subgraph "cluster_shop___pay" {`,
+			`    label="shop & pay";`,
+			`    "order" [label=<`,
+			`      <table border="0" cellborder="1" cellspacing="0" cellpadding="4">`,
+			`        <tr><td colspan="3" bgcolor="lightgrey"><b>order</b></td></tr>`,
+			`        <tr><td port="id" align="left"><u>id</u></td><td align="left">int64</td><td>PK</td></tr>`,
+			`        <tr><td port="user_id" align="left">user_id</td><td align="left">int64</td><td>FK</td></tr>`,
+			`        <tr><td port="code" align="left">code</td><td align="left">varchar</td><td></td></tr>`,
+			`      </table>`,
+			`    >];`,
+			"  }",
+			"",
+			`  "user":"group_id" -> "group":"id" [arrowtail=crowodot, arrowhead=tee];`,
+			`  "order":"user_id" -> "user":"id" [arrowtail=teeodot, arrowhead=tee];`,
+			"}",
+			"",
+		}, "\n")
+
+		exporter := NewExporter(testSchema, &Option{Columns: ColumnsKeys, Focus: "user", Hops: 1})
+		buf := new(bytes.Buffer)
+		So(exporter.Export(buf), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("Unknown focus table", t, func() {
+		exporter := NewExporter(testSchema, &Option{Focus: "unknown"})
+		So(exporter.Export(new(bytes.Buffer)), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,84 @@
+package drawio
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagGroups       = "groups"
+	FlagInput        = "input"
+	FlagOutput       = "output"
+	FlagTablesPerRow = "tablesPerRow"
+)
+
+func ExportAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	tablesPerRow := c.Int(FlagTablesPerRow)
+	if tablesPerRow <= 0 {
+		return fmt.Errorf("invalid tablesPerRow: %d", tablesPerRow)
+	}
+
+	exporter := NewExporter(schema, &Option{
+		TableFilter:  octopus.GetTableFilterFn(c.String(FlagGroups)),
+		TablesPerRow: tablesPerRow,
+	})
+
+	outputPath := c.String(FlagOutput)
+	var filename string
+	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".drawio" || ext == ".xml" {
+		filename = outputPath
+	} else {
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, "output.drawio")
+	}
+
+	buf := new(bytes.Buffer)
+	if err = exporter.Export(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+var ExportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "export draw.io diagram to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.IntFlag{
+		Name:    FlagTablesPerRow,
+		Usage:   "number of tables in a row of the grid layout",
+		Value:   4,
+		EnvVars: []string{"OCTOPUS_TABLES_PER_ROW"},
+	},
+}
@@ -0,0 +1,412 @@
+package drawio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"io"
+	"strings"
+)
+
+// diagram layout
+const (
+	charWidth        = 7
+	containerPadding = 20
+	headerHeight     = 30
+	keyWidth         = 40
+	minTableWidth    = 160
+	rowHeight        = 30
+	tableSpacing     = 40
+)
+
+const (
+	containerStyle = "swimlane;startSize=30;fontStyle=1;collapsible=0;"
+	tableStyle     = "shape=table;startSize=30;container=1;collapsible=1;childLayout=tableLayout;fixedRows=1;" +
+		"rowLines=0;fontStyle=1;align=center;resizeLast=1;"
+	rowStyle = "shape=tableRow;horizontal=0;startSize=0;swimlaneHead=0;swimlaneBody=0;fillColor=none;" +
+		"collapsible=0;dropTarget=0;points=[[0,0.5],[1,0.5]];portConstraint=eastwest;top=0;left=0;right=0;bottom=0;"
+	keyCellStyle  = "shape=partialRectangle;connectable=0;fillColor=none;top=0;left=0;bottom=0;right=0;fontStyle=1;overflow=hidden;"
+	nameCellStyle = "shape=partialRectangle;connectable=0;fillColor=none;top=0;left=0;bottom=0;right=0;align=left;spacingLeft=6;overflow=hidden;"
+	edgeStyle     = "edgeStyle=entityRelationEdgeStyle;fontSize=12;html=1;startFill=0;endFill=0;"
+)
+
+type Option struct {
+	TableFilter  octopus.TableFilterFn
+	TablesPerRow int
+}
+
+type Exporter struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewExporter(schema *octopus.Schema, option *Option) *Exporter {
+	return &Exporter{schema: schema, option: option}
+}
+
+type mxFile struct {
+	XMLName xml.Name  `xml:"mxfile"`
+	Host    string    `xml:"host,attr"`
+	Diagram mxDiagram `xml:"diagram"`
+}
+
+type mxDiagram struct {
+	ID    string       `xml:"id,attr"`
+	Name  string       `xml:"name,attr"`
+	Model mxGraphModel `xml:"mxGraphModel"`
+}
+
+type mxGraphModel struct {
+	Grid     int       `xml:"grid,attr"`
+	GridSize int       `xml:"gridSize,attr"`
+	Guides   int       `xml:"guides,attr"`
+	Tooltips int       `xml:"tooltips,attr"`
+	Connect  int       `xml:"connect,attr"`
+	Arrows   int       `xml:"arrows,attr"`
+	Fold     int       `xml:"fold,attr"`
+	Page     int       `xml:"page,attr"`
+	Cells    []*mxCell `xml:"root>mxCell"`
+}
+
+type mxCell struct {
+	ID       string      `xml:"id,attr"`
+	Value    string      `xml:"value,attr,omitempty"`
+	Style    string      `xml:"style,attr,omitempty"`
+	Vertex   string      `xml:"vertex,attr,omitempty"`
+	Edge     string      `xml:"edge,attr,omitempty"`
+	Parent   string      `xml:"parent,attr,omitempty"`
+	Source   string      `xml:"source,attr,omitempty"`
+	Target   string      `xml:"target,attr,omitempty"`
+	Geometry *mxGeometry `xml:"mxGeometry"`
+}
+
+type mxGeometry struct {
+	X        int    `xml:"x,attr,omitempty"`
+	Y        int    `xml:"y,attr,omitempty"`
+	Width    int    `xml:"width,attr,omitempty"`
+	Height   int    `xml:"height,attr,omitempty"`
+	Relative string `xml:"relative,attr,omitempty"`
+	As       string `xml:"as,attr"`
+}
+
+// edge is a reference between columns. edge starts from foreign key column.
+type edge struct {
+	fromTable    string
+	fromColumn   string
+	toTable      string
+	toColumn     string
+	relationship string
+	nullable     bool
+}
+
+func (c *Exporter) Export(wr io.Writer) error {
+	tables := c.schema.FilteredTables(c.option.TableFilter)
+
+	name := c.schema.Name
+	if name == "" {
+		name = "ERD"
+	}
+	file := &mxFile{
+		Host: "octopus-db-tools",
+		Diagram: mxDiagram{
+			ID:   "erd",
+			Name: name,
+			Model: mxGraphModel{
+				Grid:     1,
+				GridSize: 10,
+				Guides:   1,
+				Tooltips: 1,
+				Connect:  1,
+				Arrows:   1,
+				Fold:     1,
+				Cells:    []*mxCell{{ID: "0"}, {ID: "1", Parent: "0"}},
+			},
+		},
+	}
+	model := &file.Diagram.Model
+	model.Cells = append(model.Cells, c.layoutCells(tables)...)
+	for _, e := range edges(tables) {
+		model.Cells = append(model.Cells, newEdgeCell(e))
+	}
+
+	data, err := xml.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = wr.Write(append(data, '\n'))
+	return err
+}
+
+func (c *Exporter) tablesPerRow() int {
+	if c.option.TablesPerRow > 0 {
+		return c.option.TablesPerRow
+	}
+	return 4
+}
+
+// layoutCells returns cells of tables laid out in grids.
+// tables are grouped in the order of the first table of each group,
+// and tables of each group are placed in a container.
+func (c *Exporter) layoutCells(tables []*octopus.Table) []*mxCell {
+	var groups []string
+	tablesByGroup := make(map[string][]*octopus.Table)
+	for _, table := range tables {
+		if _, ok := tablesByGroup[table.Group]; !ok {
+			groups = append(groups, table.Group)
+		}
+		tablesByGroup[table.Group] = append(tablesByGroup[table.Group], table)
+	}
+
+	var cells []*mxCell
+	top := 0
+	for _, group := range groups {
+		if group == "" {
+			tableCells, _, bottom := c.gridCells(tablesByGroup[group], "1", 0, top)
+			cells = append(cells, tableCells...)
+			top = bottom + tableSpacing
+			continue
+		}
+
+		container := &mxCell{
+			ID:     groupID(group),
+			Value:  group,
+			Style:  containerStyle,
+			Vertex: "1",
+			Parent: "1",
+		}
+		tableCells, right, bottom := c.gridCells(tablesByGroup[group], container.ID,
+			containerPadding, headerHeight+containerPadding)
+		container.Geometry = &mxGeometry{
+			Y:      top,
+			Width:  right + containerPadding,
+			Height: bottom + containerPadding,
+			As:     "geometry",
+		}
+		cells = append(cells, container)
+		cells = append(cells, tableCells...)
+		top += container.Geometry.Height + tableSpacing
+	}
+	return cells
+}
+
+// gridCells returns cells of tables placed in a grid starting from (left, top).
+// right and bottom of the grid are returned.
+func (c *Exporter) gridCells(tables []*octopus.Table, parent string, left, top int) ([]*mxCell, int, int) {
+	var cells []*mxCell
+	x, y := left, top
+	right, rowBottom := left, top
+	for i, table := range tables {
+		if i > 0 && i%c.tablesPerRow() == 0 {
+			x = left
+			y = rowBottom + tableSpacing
+		}
+		tableCells := newTableCells(table, parent, x, y)
+		cells = append(cells, tableCells...)
+
+		geometry := tableCells[0].Geometry
+		x += geometry.Width + tableSpacing
+		if x-tableSpacing > right {
+			right = x - tableSpacing
+		}
+		if y+geometry.Height > rowBottom {
+			rowBottom = y + geometry.Height
+		}
+	}
+	return cells, right, rowBottom
+}
+
+func newTableCells(table *octopus.Table, parent string, x, y int) []*mxCell {
+	keyW := keyWidth
+	for _, column := range table.Columns {
+		if w := len(keyLabel(column))*charWidth + 5; w > keyW {
+			keyW = w
+		}
+	}
+	width := len(table.Name)*charWidth + keyW
+	for _, column := range table.Columns {
+		if w := len(columnLabel(column))*charWidth + keyW + 20; w > width {
+			width = w
+		}
+	}
+	if width < minTableWidth {
+		width = minTableWidth
+	}
+	// round up to grid size
+	width = (width + 9) / 10 * 10
+
+	tableCell := &mxCell{
+		ID:     tableID(table.Name),
+		Value:  table.Name,
+		Style:  tableStyle,
+		Vertex: "1",
+		Parent: parent,
+		Geometry: &mxGeometry{
+			X:      x,
+			Y:      y,
+			Width:  width,
+			Height: headerHeight + len(table.Columns)*rowHeight,
+			As:     "geometry",
+		},
+	}
+	cells := []*mxCell{tableCell}
+
+	for i, column := range table.Columns {
+		rowID := columnID(table.Name, column.Name)
+		nameStyle := nameCellStyle
+		if column.PrimaryKey {
+			// bold and underline
+			nameStyle += "fontStyle=5;"
+		}
+		cells = append(cells,
+			&mxCell{
+				ID:     rowID,
+				Style:  rowStyle,
+				Vertex: "1",
+				Parent: tableCell.ID,
+				Geometry: &mxGeometry{
+					Y:      headerHeight + i*rowHeight,
+					Width:  width,
+					Height: rowHeight,
+					As:     "geometry",
+				},
+			},
+			&mxCell{
+				ID:     rowID + "-key",
+				Value:  keyLabel(column),
+				Style:  keyCellStyle,
+				Vertex: "1",
+				Parent: rowID,
+				Geometry: &mxGeometry{
+					Width:  keyW,
+					Height: rowHeight,
+					As:     "geometry",
+				},
+			},
+			&mxCell{
+				ID:     rowID + "-name",
+				Value:  columnLabel(column),
+				Style:  nameStyle,
+				Vertex: "1",
+				Parent: rowID,
+				Geometry: &mxGeometry{
+					X:      keyW,
+					Width:  width - keyW,
+					Height: rowHeight,
+					As:     "geometry",
+				},
+			},
+		)
+	}
+	return cells
+}
+
+func newEdgeCell(e *edge) *mxCell {
+	// start arrow is at the foreign key column.
+	startArrow := "ERzeroToMany"
+	if e.relationship == octopus.RefOneToOne {
+		startArrow = "ERzeroToOne"
+	}
+	endArrow := "ERmandOne"
+	if e.nullable {
+		endArrow = "ERzeroToOne"
+	}
+
+	return &mxCell{
+		ID:     edgeID(e),
+		Style:  fmt.Sprintf("%sstartArrow=%s;endArrow=%s;", edgeStyle, startArrow, endArrow),
+		Edge:   "1",
+		Parent: "1",
+		Source: columnID(e.fromTable, e.fromColumn),
+		Target: columnID(e.toTable, e.toColumn),
+		Geometry: &mxGeometry{
+			Relative: "1",
+			As:       "geometry",
+		},
+	}
+}
+
+// edges returns references between tables. '1:n' reference is reversed to start from foreign key column.
+// references to tables which are not exported are removed.
+func edges(tables []*octopus.Table) []*edge {
+	tableByName := make(map[string]*octopus.Table)
+	for _, table := range tables {
+		tableByName[table.Name] = table
+	}
+
+	var result []*edge
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			ref := column.Ref
+			if ref == nil {
+				continue
+			}
+			target := tableByName[ref.Table]
+			if target == nil {
+				continue
+			}
+			targetColumn := target.ColumnByName(ref.Column)
+			if targetColumn == nil {
+				continue
+			}
+
+			if ref.Relationship == octopus.RefOneToMany {
+				result = append(result, &edge{
+					fromTable:    ref.Table,
+					fromColumn:   ref.Column,
+					toTable:      table.Name,
+					toColumn:     column.Name,
+					relationship: octopus.RefManyToOne,
+					nullable:     !targetColumn.NotNull,
+				})
+			} else {
+				result = append(result, &edge{
+					fromTable:    table.Name,
+					fromColumn:   column.Name,
+					toTable:      ref.Table,
+					toColumn:     ref.Column,
+					relationship: ref.Relationship,
+					nullable:     !column.NotNull,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// idEscaper escapes '-' used as a separator of cell ID parts, so that different names never make the same ID.
+var idEscaper = strings.NewReplacer("%", "%25", "-", "%2D")
+
+// cellID returns cell ID of prefix and escaped parts joined with '-'.
+func cellID(prefix string, parts ...string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for _, part := range parts {
+		sb.WriteString("-")
+		sb.WriteString(idEscaper.Replace(part))
+	}
+	return sb.String()
+}
+
+func groupID(group string) string {
+	return cellID("group", group)
+}
+
+func tableID(table string) string {
+	return cellID("table", table)
+}
+
+func columnID(table, column string) string {
+	return cellID("column", table, column)
+}
+
+func edgeID(e *edge) string {
+	return cellID("ref", e.fromTable, e.fromColumn, e.toTable, e.toColumn)
+}
+
+func keyLabel(column *octopus.Column) string {
+	return strings.Join(column.Keys(), ",")
+}
+
+func columnLabel(column *octopus.Column) string {
+	return column.Name + " " + column.Format()
+}
@@ -0,0 +1,165 @@
+package drawio
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func newTestSchema() *octopus.Schema {
+	return &octopus.Schema{
+		Name: "sample",
+		Tables: []*octopus.Table{
+			{
+				Name:  "user",
+				Group: "common",
+				Columns: []*octopus.Column{
+					{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+					{Name: "group_id", Type: "int64", Ref: &octopus.Reference{Table: "group", Column: "id", Relationship: octopus.RefManyToOne}},
+				},
+			},
+			{
+				Name:  "group",
+				Group: "common",
+				Columns: []*octopus.Column{
+					{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				},
+			},
+			{
+				Name: "profile",
+				Columns: []*octopus.Column{
+					{Name: "user_id", Type: "int64", PrimaryKey: true, NotNull: true, Ref: &octopus.Reference{Table: "user", Column: "id", Relationship: octopus.RefOneToOne}},
+				},
+			},
+		},
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		expected := `<mxfile host="octopus-db-tools">
+  <diagram id="erd" name="sample">
+    <mxGraphModel grid="1" gridSize="10" guides="1" tooltips="1" connect="1" arrows="1" fold="1" page="0">
+      <root>
+        <mxCell id="0"></mxCell>
+        <mxCell id="1" parent="0"></mxCell>
+        <mxCell id="group-common" value="common" style="` + containerStyle + `" vertex="1" parent="1">
+          <mxGeometry width="200" height="260" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="table-user" value="user" style="` + tableStyle + `" vertex="1" parent="group-common">
+          <mxGeometry x="20" y="50" width="160" height="90" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-user-id" style="` + rowStyle + `" vertex="1" parent="table-user">
+          <mxGeometry y="30" width="160" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-user-id-key" value="PK" style="` + keyCellStyle + `" vertex="1" parent="column-user-id">
+          <mxGeometry width="40" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-user-id-name" value="id int64" style="` + nameCellStyle + `fontStyle=5;" vertex="1" parent="column-user-id">
+          <mxGeometry x="40" width="120" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-user-group_id" style="` + rowStyle + `" vertex="1" parent="table-user">
+          <mxGeometry y="60" width="160" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-user-group_id-key" value="FK" style="` + keyCellStyle + `" vertex="1" parent="column-user-group_id">
+          <mxGeometry width="40" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-user-group_id-name" value="group_id int64" style="` + nameCellStyle + `" vertex="1" parent="column-user-group_id">
+          <mxGeometry x="40" width="120" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="table-group" value="group" style="` + tableStyle + `" vertex="1" parent="group-common">
+          <mxGeometry x="20" y="180" width="160" height="60" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-group-id" style="` + rowStyle + `" vertex="1" parent="table-group">
+          <mxGeometry y="30" width="160" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-group-id-key" value="PK" style="` + keyCellStyle + `" vertex="1" parent="column-group-id">
+          <mxGeometry width="40" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-group-id-name" value="id int64" style="` + nameCellStyle + `fontStyle=5;" vertex="1" parent="column-group-id">
+          <mxGeometry x="40" width="120" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="table-profile" value="profile" style="` + tableStyle + `" vertex="1" parent="1">
+          <mxGeometry y="300" width="160" height="60" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-profile-user_id" style="` + rowStyle + `" vertex="1" parent="table-profile">
+          <mxGeometry y="30" width="160" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-profile-user_id-key" value="PK,FK" style="` + keyCellStyle + `" vertex="1" parent="column-profile-user_id">
+          <mxGeometry width="40" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="column-profile-user_id-name" value="user_id int64" style="` + nameCellStyle + `fontStyle=5;" vertex="1" parent="column-profile-user_id">
+          <mxGeometry x="40" width="120" height="30" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="ref-user-group_id-group-id" style="` + edgeStyle + `startArrow=ERzeroToMany;endArrow=ERzeroToOne;" edge="1" parent="1" source="column-user-group_id" target="column-group-id">
+          <mxGeometry relative="1" as="geometry"></mxGeometry>
+        </mxCell>
+        <mxCell id="ref-profile-user_id-user-id" style="` + edgeStyle + `startArrow=ERzeroToOne;endArrow=ERmandOne;" edge="1" parent="1" source="column-profile-user_id" target="column-user-id">
+          <mxGeometry relative="1" as="geometry"></mxGeometry>
+        </mxCell>
+      </root>
+    </mxGraphModel>
+  </diagram>
+</mxfile>
+`
+
+		buf := new(bytes.Buffer)
+		So(NewExporter(newTestSchema(), &Option{TablesPerRow: 1}).Export(buf), ShouldBeNil)
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("Export tables in a row", t, func() {
+		buf := new(bytes.Buffer)
+		So(NewExporter(newTestSchema(), &Option{}).Export(buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring,
+			`<mxCell id="table-group" value="group" style="`+tableStyle+`" vertex="1" parent="group-common">
+          <mxGeometry x="220" y="50" width="160" height="60" as="geometry"></mxGeometry>`)
+	})
+
+	Convey("Export filtered tables", t, func() {
+		filter := func(table *octopus.Table) bool {
+			return table.Group == ""
+		}
+		buf := new(bytes.Buffer)
+		So(NewExporter(newTestSchema(), &Option{TableFilter: filter}).Export(buf), ShouldBeNil)
+		actual := buf.String()
+		So(actual, ShouldContainSubstring, `id="table-profile"`)
+		So(actual, ShouldNotContainSubstring, `id="table-user"`)
+		So(actual, ShouldNotContainSubstring, `id="ref-profile-user_id-user-id"`)
+	})
+	Convey("Export names containing separator", t, func() {
+		schema := &octopus.Schema{
+			Name: "sample",
+			Tables: []*octopus.Table{
+				{
+					Name: "a-b",
+					Columns: []*octopus.Column{
+						{Name: "c", Type: "int64", PrimaryKey: true, AutoIncremental: true, NotNull: true},
+					},
+				},
+				{
+					Name: "a",
+					Columns: []*octopus.Column{
+						{Name: "b-c", Type: "int64", PrimaryKey: true, NotNull: true, Ref: &octopus.Reference{Table: "a-b", Column: "c", Relationship: octopus.RefOneToMany}},
+					},
+				},
+			},
+		}
+		buf := new(bytes.Buffer)
+		So(NewExporter(schema, &Option{}).Export(buf), ShouldBeNil)
+		actual := buf.String()
+		So(actual, ShouldContainSubstring, `<mxCell id="column-a%2Db-c" style=`)
+		So(actual, ShouldContainSubstring, `<mxCell id="column-a-b%2Dc" style=`)
+		So(actual, ShouldContainSubstring, `<mxCell id="column-a%2Db-c-key" value="PK,AI"`)
+		// FK is not marked on 1:n reference column
+		So(actual, ShouldContainSubstring, `<mxCell id="column-a-b%2Dc-key" value="PK"`)
+		So(actual, ShouldContainSubstring, `id="ref-a%2Db-c-a-b%2Dc"`)
+	})
+}
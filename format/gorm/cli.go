@@ -14,11 +14,15 @@ const (
 	FlagEmbed              = "embed"
 	FlagGroups             = "groups"
 	FlagInput              = "input"
+	FlagOnDelete           = "onDelete"
+	FlagOnUpdate           = "onUpdate"
 	FlagOutput             = "output"
 	FlagPackage            = "package"
 	FlagPointerAssociation = "pointerAssociation"
 	FlagPrefix             = "prefix"
 	FlagRemovePrefix       = "removePrefix"
+	FlagRepository         = "repository"
+	FlagTemplate           = "template"
 	FlagUniqueNameSuffix   = "uniqueNameSuffix"
 )
 
@@ -34,23 +38,30 @@ func Action(c *cli.Context) error {
 			Embed:              c.String(FlagEmbed),
 			Package:            c.String(FlagPackage),
 			PointerAssociation: c.Bool(FlagPointerAssociation),
+			OnUpdate:           c.String(FlagOnUpdate),
+			OnDelete:           c.String(FlagOnDelete),
 			PrefixMapper:       common.NewPrefixMapper(c.String(FlagPrefix)),
 			RemovePrefixes:     strings.Split(c.String(FlagRemovePrefix), ","),
+			Repository:         c.Bool(FlagRepository),
 			TableFilter:        octopus.GetTableFilterFn(c.String(FlagGroups)),
+			Template:           c.String(FlagTemplate),
 			UniqueNameSuffix:   c.String(FlagUniqueNameSuffix),
 		},
 	}
 
 	outputPath := c.String(FlagOutput)
 	var filename string
+	var repositoryFilename string
 	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".go" {
 		filename = outputPath
+		repositoryFilename = strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + "_repository.go"
 	} else {
 		// ensure directory is created
 		if _, err := util.Mkdir(outputPath); err != nil {
 			return err
 		}
 		filename = filepath.Join(outputPath, "output.go")
+		repositoryFilename = filepath.Join(outputPath, "repository.go")
 	}
 
 	buf := new(bytes.Buffer)
@@ -59,7 +70,19 @@ func Action(c *cli.Context) error {
 	}
 
 	// write to file
-	return util.WriteStringToFile(filename, buf.String())
+	if err = util.WriteStringToFile(filename, buf.String()); err != nil {
+		return err
+	}
+
+	if !gen.option.Repository {
+		return nil
+	}
+
+	repoBuf := new(bytes.Buffer)
+	if err = gen.GenerateRepository(repoBuf); err != nil {
+		return err
+	}
+	return util.WriteStringToFile(repositoryFilename, repoBuf.String())
 }
 
 var CliFlags = []cli.Flag{
@@ -95,6 +118,16 @@ var CliFlags = []cli.Flag{
 		Usage:   "filter table groups to generate. set multiple values with comma separated.",
 		EnvVars: []string{"OCTOPUS_GROUPS"},
 	},
+	&cli.StringFlag{
+		Name:    FlagOnDelete,
+		Usage:   "set foreign key constraint on delete. ex: CASCADE, SET NULL, RESTRICT",
+		EnvVars: []string{"OCTOPUS_ON_DELETE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagOnUpdate,
+		Usage:   "set foreign key constraint on update. ex: CASCADE, SET NULL, RESTRICT",
+		EnvVars: []string{"OCTOPUS_ON_UPDATE"},
+	},
 	&cli.StringFlag{
 		Name:    FlagPackage,
 		Aliases: []string{"k"},
@@ -113,6 +146,17 @@ var CliFlags = []cli.Flag{
 		Usage:   "set prefixes to remove from model struct name. set multiple values with comma separated.",
 		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
 	},
+	&cli.BoolFlag{
+		Name:    FlagRepository,
+		Usage:   "generate repository interfaces, GORM implementations and mocks",
+		EnvVars: []string{"OCTOPUS_REPOSITORY"},
+	},
+	&cli.StringFlag{
+		Name:    FlagTemplate,
+		Aliases: []string{"t"},
+		Usage:   "read custom struct template from `FILE`",
+		EnvVars: []string{"OCTOPUS_TEMPLATE"},
+	},
 	&cli.StringFlag{
 		Name:    FlagUniqueNameSuffix,
 		Aliases: []string{"u"},
@@ -11,6 +11,72 @@ import (
 	"text/template"
 )
 
+const (
+	// StructTemplate is the default template to generate GORM struct.
+	// Template is executed with TplData.
+	StructTemplate = `{{"" -}}
+type {{.Struct.Name}} struct {
+{{- range .Struct.EmbeddedModelNames}}
+	{{.}}
+{{- end}}
+{{- range .Fields}}
+	{{fieldToString .}}
+{{- end}}
+}
+
+func (c *{{.Struct.Name}}) TableName() string { return "{{.Table.Name}}" }
+
+`
+
+	// EnumTemplate is the template to generate string type of enum column.
+	// Template is executed with GoEnum.
+	EnumTemplate = `{{"" -}}
+type {{.Name}} string
+
+const (
+{{- range .Values}}
+	{{.Name}} {{$.Name}} = {{printf "%q" .Value}}
+{{- end}}
+)
+
+// IsValid returns true if the value is one of {{.Name}} constants.
+func (e {{.Name}}) IsValid() bool {
+	switch e {
+	case {{valueNames .}}:
+		return true
+	}
+	return false
+}
+
+// Scan implements sql.Scanner interface.
+func (e *{{.Name}}) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("unsupported type for {{.Name}}: %T", value)
+	}
+	*e = {{.Name}}(s)
+	if !e.IsValid() {
+		return fmt.Errorf("invalid {{.Name}}: %s", s)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer interface.
+func (e {{.Name}}) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid {{.Name}}: %s", string(e))
+	}
+	return string(e), nil
+}
+
+`
+)
+
 // -------------------------------
 
 type Option struct {
@@ -19,7 +85,11 @@ type Option struct {
 	Embed              string
 	Package            string
 	PointerAssociation bool
+	OnUpdate           string
+	OnDelete           string
 	RemovePrefixes     []string
+	Repository         bool
+	Template           string
 	UniqueNameSuffix   string
 }
 
@@ -53,11 +123,59 @@ func (c *GoStructProcessor) Reference(ref octopus.Reference) (*octopus.Table, *o
 // -------------------------------
 
 type Generator struct {
-	schema *octopus.Schema
-	option *Option
+	schema    *octopus.Schema
+	option    *Option
+	structTpl *template.Template
 }
 
 func (g *Generator) Generate(wr io.Writer) error {
+	goStructs := g.goStructs()
+
+	// create import set
+	importSet := util.NewStringSet()
+	for _, goStruct := range goStructs {
+		for _, modelName := range goStruct.EmbeddedModelNames {
+			if modelName == "gorm.Model" {
+				importSet.Add("gorm.io/gorm")
+			}
+		}
+
+		for _, field := range goStruct.Fields {
+			for _, imp := range field.Imports {
+				importSet.Add(imp)
+			}
+		}
+
+		if len(goStruct.Enums) > 0 {
+			importSet.Add("database/sql/driver")
+			importSet.Add("fmt")
+		}
+	}
+
+	// generate header
+	if err := g.generateHeader(wr, importSet.Slice()); err != nil {
+		return err
+	}
+
+	// write structs
+	for _, goStruct := range goStructs {
+		// write GORM struct
+		if err := g.GenerateStruct(wr, goStruct); err != nil {
+			return err
+		}
+		// write enum types
+		for _, enum := range goStruct.Enums {
+			if err := g.GenerateEnum(wr, enum); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// goStructs returns GORM structs of filtered tables with associations populated.
+func (g *Generator) goStructs() []*GoStruct {
 	option := g.option
 	embed := option.Embed
 	if embed != "" {
@@ -65,10 +183,6 @@ func (g *Generator) Generate(wr io.Writer) error {
 			registerEmbeddedModel(name, columnNames)
 		}
 	}
-	pkg := option.Package
-	if pkg == "" {
-		pkg = "main"
-	}
 
 	var goStructs []*GoStruct
 	tableFilter := option.TableFilter
@@ -79,24 +193,18 @@ func (g *Generator) Generate(wr io.Writer) error {
 			goStructs = append(goStructs, goStruct)
 		}
 	}
+	resolveEnumNames(goStructs)
+	populateAssociations(goStructs)
+	return goStructs
+}
 
-	// create import set
-	importSet := util.NewStringSet()
-	for _, goStruct := range goStructs {
-		for _, modelName := range goStruct.EmbeddedModelNames {
-			if modelName == "gorm.Model" {
-				importSet.Add("github.com/jinzhu/gorm")
-			}
-		}
-
-		for _, field := range goStruct.Fields {
-			for _, imp := range field.Imports {
-				importSet.Add(imp)
-			}
-		}
+// generateHeader writes package declaration and imports.
+func (g *Generator) generateHeader(wr io.Writer, imports []string) error {
+	pkg := g.option.Package
+	if pkg == "" {
+		pkg = "main"
 	}
 
-	// generate header
 	funcMap := template.FuncMap{}
 	tplText := `{{"" -}}
 package {{.Package}}
@@ -113,22 +221,27 @@ import (
 		return err
 	}
 
-	if err := tmpl.Execute(wr, &TplHeaderData{
+	return tmpl.Execute(wr, &TplHeaderData{
 		Package: pkg,
-		Imports: importSet.Slice(),
-	}); err != nil {
-		return err
-	}
+		Imports: imports,
+	})
+}
 
-	// write structs
-	for _, goStruct := range goStructs {
-		// write GORM struct
-		if err := g.GenerateStruct(wr, goStruct); err != nil {
-			return err
-		}
+func (g *Generator) GenerateEnum(wr io.Writer, enum *GoEnum) error {
+	funcMap := template.FuncMap{
+		"valueNames": func(enum *GoEnum) string {
+			var names []string
+			for _, value := range enum.Values {
+				names = append(names, value.Name)
+			}
+			return strings.Join(names, ", ")
+		},
 	}
-
-	return nil
+	tmpl, err := util.NewTemplate("gormEnum", EnumTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(wr, enum)
 }
 
 type TplHeaderData struct {
@@ -136,6 +249,7 @@ type TplHeaderData struct {
 	Imports []string
 }
 
+// TplData is the data passed to the struct template.
 type TplData struct {
 	Package       string
 	Struct        *GoStruct
@@ -144,6 +258,8 @@ type TplData struct {
 	Fields        []*TplFieldData
 }
 
+// TplFieldData is a struct field passed to the struct template.
+// Tag is a complete struct tag including backquotes, or empty if not required.
 type TplFieldData struct {
 	Name string
 	Type string
@@ -190,11 +306,9 @@ func (g *Generator) GenerateStruct(
 	wr io.Writer,
 	goStruct *GoStruct,
 ) error {
-	funcMap := template.FuncMap{
-		"join": strings.Join,
-		"fieldToString": func(field *TplFieldData) string {
-			return field.ToString()
-		},
+	tmpl, err := g.structTemplate()
+	if err != nil {
+		return err
 	}
 
 	// unique constraint name
@@ -226,14 +340,14 @@ func (g *Generator) GenerateStruct(
 
 		// PK
 		if column.PrimaryKey {
-			gormTags = append(gormTags, "primary_key")
+			gormTags = append(gormTags, "primaryKey")
 		}
 		// Unique
 		if column.UniqueKey {
 			if uniqueCstName == "" {
 				gormTags = append(gormTags, "unique")
 			} else {
-				gormTags = append(gormTags, fmt.Sprintf("unique_index:%s", uniqueCstName))
+				gormTags = append(gormTags, fmt.Sprintf("uniqueIndex:%s", uniqueCstName))
 			}
 		}
 		// Index
@@ -245,9 +359,9 @@ func (g *Generator) GenerateStruct(
 			}
 		}
 
-		// auto_increment
+		// autoIncrement
 		if column.AutoIncremental {
-			gormTags = append(gormTags, "auto_increment")
+			gormTags = append(gormTags, "autoIncrement")
 		}
 		// not null
 		if column.NotNull && !column.AutoIncremental {
@@ -264,16 +378,27 @@ func (g *Generator) GenerateStruct(
 	// association fields
 	for _, associationField := range goStruct.AssociationFields {
 		fieldType := associationField.Type
-		if associationField.Array {
-			fieldType = "[]" + associationField.Type
-		}
-		if g.option.PointerAssociation {
+		if g.option.PointerAssociation || associationField.Pointer {
 			fieldType = "*" + fieldType
 		}
+		if associationField.Array {
+			fieldType = "[]" + fieldType
+		}
 
 		var tags []string
-		tags = append(tags, fmt.Sprintf("foreignKey:%s", associationField.ForeignKey))
-		tags = append(tags, fmt.Sprintf("references:%s", associationField.Reference))
+		if associationField.JoinTable != "" {
+			tags = append(tags, fmt.Sprintf("many2many:%s", associationField.JoinTable))
+			tags = append(tags, fmt.Sprintf("foreignKey:%s", associationField.ForeignKey))
+			tags = append(tags, fmt.Sprintf("joinForeignKey:%s", associationField.JoinForeignKey))
+			tags = append(tags, fmt.Sprintf("references:%s", associationField.Reference))
+			tags = append(tags, fmt.Sprintf("joinReferences:%s", associationField.JoinReference))
+		} else {
+			tags = append(tags, fmt.Sprintf("foreignKey:%s", associationField.ForeignKey))
+			tags = append(tags, fmt.Sprintf("references:%s", associationField.Reference))
+		}
+		if tag := g.constraintTag(); tag != "" {
+			tags = append(tags, tag)
+		}
 
 		tplFields = append(tplFields, &TplFieldData{
 			Name: associationField.Name,
@@ -291,25 +416,46 @@ func (g *Generator) GenerateStruct(
 		Fields:        tplFields,
 	}
 
-	tplString := `{{"" -}}
-type {{.Struct.Name}} struct {
-{{- range .Struct.EmbeddedModelNames}}
-	{{.}}
-{{- end}}
-{{- range .Fields}}
-	{{fieldToString .}}
-{{- end}}
+	return tmpl.Execute(wr, &data)
 }
 
-func (c *{{.Struct.Name}}) TableName() string { return "{{.Table.Name}}" }
+// structTemplate returns struct template. template file is read and parsed only once.
+func (g *Generator) structTemplate() (*template.Template, error) {
+	if g.structTpl != nil {
+		return g.structTpl, nil
+	}
 
-`
-	tmpl, err := template.New("gormStruct").Funcs(funcMap).Parse(tplString)
+	funcMap := template.FuncMap{
+		"join": strings.Join,
+		"fieldToString": func(field *TplFieldData) string {
+			return field.ToString()
+		},
+	}
+	tplText, err := util.ReadTemplateText(g.option.Template, StructTemplate)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	tmpl, err := util.NewTemplate("gormStruct", tplText, funcMap)
+	if err != nil {
+		return nil, err
 	}
+	g.structTpl = tmpl
+	return tmpl, nil
+}
 
-	return tmpl.Execute(wr, &data)
+// constraintTag returns 'constraint' tag of association fields. returns empty string if not required.
+func (g *Generator) constraintTag() string {
+	var constraints []string
+	if onUpdate := g.option.OnUpdate; onUpdate != "" {
+		constraints = append(constraints, "OnUpdate:"+strings.ToUpper(onUpdate))
+	}
+	if onDelete := g.option.OnDelete; onDelete != "" {
+		constraints = append(constraints, "OnDelete:"+strings.ToUpper(onDelete))
+	}
+	if len(constraints) == 0 {
+		return ""
+	}
+	return "constraint:" + strings.Join(constraints, ",")
 }
 
 func gormTag(tags []string) string {
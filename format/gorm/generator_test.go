@@ -7,7 +7,9 @@ import (
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
 	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
 	"log"
+	"os"
 	"strings"
 	"testing"
 )
@@ -278,9 +280,9 @@ func TestGorm_Generate(t *testing.T) {
 				"package " + util.IfThenElseString(pkg != "", pkg, "main"),
 				"",
 				"import (",
-				"	\"github.com/jinzhu/gorm\"",
 				"	\"github.com/shopspring/decimal\"",
 				"	\"gopkg.in/guregu/null.v4\"",
+				"	\"gorm.io/gorm\"",
 				"	\"time\"",
 				")",
 				"",
@@ -314,15 +316,16 @@ func TestGorm_Generate(t *testing.T) {
 				"	Bit1Null *byte `gorm:\"column:bit1_null;type:bit(1)\"`",
 				"	Bit2 *byte `gorm:\"column:bit2;type:bit(2)\"`",
 				"	Invalid interface{}",
+				"	G2User2s []*G2User2 `gorm:\"foreignKey:UserID;references:ID\"`",
 				"}",
 				"",
 				"func (c *G1User1) TableName() string { return \"tbl_user1\" }",
 				"",
 				"type G2User2 struct {",
-				"	ID int64 `gorm:\"primary_key;auto_increment\"`",
+				"	ID int64 `gorm:\"primaryKey;autoIncrement\"`",
 				"	UserID int64 `gorm:\"not null\"`",
-				"	Name string `gorm:\"type:varchar(100);unique_index:user2_uq;index:idx2,priority:2;not null\"`",
-				"	PassportNo string `gorm:\"type:varchar(20);unique_index:user2_uq;not null\"`",
+				"	Name string `gorm:\"type:varchar(100);uniqueIndex:user2_uq;index:idx2,priority:2;not null\"`",
+				"	PassportNo string `gorm:\"type:varchar(20);uniqueIndex:user2_uq;not null\"`",
 				"	Ch null.String `gorm:\"type:char(10)\"`",
 				"	Dec decimal.Decimal `gorm:\"type:decimal(20,5);index:dec_idx;index:idx2,priority:1;not null\"`",
 				"	TimeNotnull time.Time `gorm:\"not null\"`",
@@ -381,7 +384,7 @@ func TestGorm_CustomEmbeddedStruct(t *testing.T) {
 				"type User2 struct {",
 				"	IdName",
 				"	UserID int64 `gorm:\"not null\"`",
-				"	PassportNo string `gorm:\"type:varchar(20);unique_index:user2_uq;not null\"`",
+				"	PassportNo string `gorm:\"type:varchar(20);uniqueIndex:user2_uq;not null\"`",
 				"	Ch null.String `gorm:\"type:char(10)\"`",
 				"	Dec decimal.Decimal `gorm:\"type:decimal(20,5);index:dec_idx;index:idx2,priority:1;not null\"`",
 				"	TimeNotnull time.Time `gorm:\"not null\"`",
@@ -410,3 +413,323 @@ func TestGorm_CustomEmbeddedStruct(t *testing.T) {
 		}
 	})
 }
+
+func TestGorm_Template(t *testing.T) {
+	Convey("Custom template", t, func() {
+		tplFile, err := ioutil.TempFile("", "gorm-*.tpl")
+		So(err, ShouldBeNil)
+		defer os.Remove(tplFile.Name())
+
+		tplText := `{{"" -}}
+// {{.Struct.Name}} is mapped to '{{.Table.Name}}'
+type {{.Struct.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+`
+		_, err = tplFile.WriteString(tplText)
+		So(err, ShouldBeNil)
+		So(tplFile.Close(), ShouldBeNil)
+
+		option := &Option{
+			PrefixMapper:   common.NewPrefixMapper(""),
+			RemovePrefixes: []string{"tbl_"},
+			Package:        "model",
+			Template:       tplFile.Name(),
+		}
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "tbl_tag",
+					Columns: []*octopus.Column{
+						{
+							Name:       "code",
+							Type:       octopus.ColTypeVarchar,
+							Size:       10,
+							PrimaryKey: true,
+							NotNull:    true,
+						},
+						{
+							Name: "label",
+							Type: octopus.ColTypeVarchar,
+							Size: 100,
+						},
+					},
+				},
+			},
+		}
+
+		expectedStrings := []string{
+			"package model",
+			"",
+			"import (",
+			"	\"gopkg.in/guregu/null.v4\"",
+			")",
+			"",
+			"// Tag is mapped to 'tbl_tag'",
+			"type Tag struct {",
+			"	Code string",
+			"	Label null.String",
+			"}",
+			"",
+		}
+		expected := strings.Join(expectedStrings, "\n")
+
+		gen := Generator{schema: schema, option: option}
+
+		buf := new(bytes.Buffer)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldResemble, expected)
+	})
+}
+
+func TestGorm_Associations(t *testing.T) {
+	Convey("Associations and enums", t, func() {
+		idColumn := func() *octopus.Column {
+			return &octopus.Column{
+				Name:            "id",
+				Type:            octopus.ColTypeInt64,
+				PrimaryKey:      true,
+				AutoIncremental: true,
+				NotNull:         true,
+			}
+		}
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{
+							Name:    "status",
+							Type:    octopus.ColTypeEnum,
+							Values:  []string{"active", "in-active"},
+							NotNull: true,
+						},
+						{
+							Name: "manager_id",
+							Type: octopus.ColTypeInt64,
+							Ref: &octopus.Reference{
+								Table:  "user",
+								Column: "id",
+							},
+						},
+					},
+				},
+				{
+					Name: "profile",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{
+							Name:    "user_id",
+							Type:    octopus.ColTypeInt64,
+							NotNull: true,
+							Ref: &octopus.Reference{
+								Table:        "user",
+								Column:       "id",
+								Relationship: octopus.RefOneToOne,
+							},
+						},
+					},
+				},
+				{
+					Name: "role",
+					Columns: []*octopus.Column{
+						idColumn(),
+					},
+				},
+				{
+					Name: "user_role",
+					Columns: []*octopus.Column{
+						{
+							Name:       "user_id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+							NotNull:    true,
+							Ref: &octopus.Reference{
+								Table:  "user",
+								Column: "id",
+							},
+						},
+						{
+							Name:       "role_id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+							NotNull:    true,
+							Ref: &octopus.Reference{
+								Table:  "role",
+								Column: "id",
+							},
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper(""),
+			Package:      "model",
+			OnUpdate:     "cascade",
+			OnDelete:     "SET NULL",
+		}
+
+		expectedStrings := []string{
+			"package model",
+			"",
+			"import (",
+			"	\"database/sql/driver\"",
+			"	\"fmt\"",
+			"	\"gopkg.in/guregu/null.v4\"",
+			")",
+			"",
+			"type User struct {",
+			"	ID int64 `gorm:\"primaryKey;autoIncrement\"`",
+			"	Status UserStatus `gorm:\"not null\"`",
+			"	ManagerID null.Int",
+			"	Manager *User `gorm:\"foreignKey:ManagerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"	Profile *Profile `gorm:\"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"	Roles []Role `gorm:\"many2many:user_role;foreignKey:ID;joinForeignKey:UserID;references:ID;joinReferences:RoleID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"}",
+			"",
+			"func (c *User) TableName() string { return \"user\" }",
+			"",
+			"type UserStatus string",
+			"",
+			"const (",
+			"	UserStatusActive UserStatus = \"active\"",
+			"	UserStatusInActive UserStatus = \"in-active\"",
+			")",
+			"",
+			"// IsValid returns true if the value is one of UserStatus constants.",
+			"func (e UserStatus) IsValid() bool {",
+			"	switch e {",
+			"	case UserStatusActive, UserStatusInActive:",
+			"		return true",
+			"	}",
+			"	return false",
+			"}",
+			"",
+			"// Scan implements sql.Scanner interface.",
+			"func (e *UserStatus) Scan(value interface{}) error {",
+			"	var s string",
+			"	switch v := value.(type) {",
+			"	case []byte:",
+			"		s = string(v)",
+			"	case string:",
+			"		s = v",
+			"	default:",
+			"		return fmt.Errorf(\"unsupported type for UserStatus: %T\", value)",
+			"	}",
+			"	*e = UserStatus(s)",
+			"	if !e.IsValid() {",
+			"		return fmt.Errorf(\"invalid UserStatus: %s\", s)",
+			"	}",
+			"	return nil",
+			"}",
+			"",
+			"// Value implements driver.Valuer interface.",
+			"func (e UserStatus) Value() (driver.Value, error) {",
+			"	if !e.IsValid() {",
+			"		return nil, fmt.Errorf(\"invalid UserStatus: %s\", string(e))",
+			"	}",
+			"	return string(e), nil",
+			"}",
+			"",
+			"type Profile struct {",
+			"	ID int64 `gorm:\"primaryKey;autoIncrement\"`",
+			"	UserID int64 `gorm:\"not null\"`",
+			"	User User `gorm:\"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"}",
+			"",
+			"func (c *Profile) TableName() string { return \"profile\" }",
+			"",
+			"type Role struct {",
+			"	ID int64 `gorm:\"primaryKey;autoIncrement\"`",
+			"	Users []User `gorm:\"many2many:user_role;foreignKey:ID;joinForeignKey:RoleID;references:ID;joinReferences:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"}",
+			"",
+			"func (c *Role) TableName() string { return \"role\" }",
+			"",
+			"type UserRole struct {",
+			"	UserID int64 `gorm:\"primaryKey;not null\"`",
+			"	RoleID int64 `gorm:\"primaryKey;not null\"`",
+			"	User User `gorm:\"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"	Role Role `gorm:\"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL\"`",
+			"}",
+			"",
+			"func (c *UserRole) TableName() string { return \"user_role\" }",
+			"",
+			"",
+		}
+		expected := strings.Join(expectedStrings, "\n")
+
+		gen := Generator{schema: schema, option: option}
+
+		buf := new(bytes.Buffer)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldResemble, expected)
+	})
+}
+
+func TestGorm_EnumValueNames(t *testing.T) {
+	Convey("newGoEnum", t, func() {
+		enum := newGoEnum("UserStatus", &octopus.Column{
+			Name:   "status",
+			Type:   octopus.ColTypeEnum,
+			Values: []string{"", "in-active", "in_active", "active"},
+		})
+
+		var names []string
+		for _, value := range enum.Values {
+			names = append(names, value.Name)
+		}
+		So(names, ShouldResemble, []string{
+			"UserStatusEmpty",
+			"UserStatusInActive",
+			"UserStatusInActive2",
+			"UserStatusActive",
+		})
+	})
+}
+
+func TestGorm_EnumNameCollision(t *testing.T) {
+	Convey("resolveEnumNames", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+						{Name: "status", Type: octopus.ColTypeEnum, Values: []string{"active"}},
+					},
+				},
+				{
+					Name: "user_status",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+					},
+				},
+			},
+		}
+		gen := Generator{schema: schema, option: &Option{PrefixMapper: common.NewPrefixMapper("")}}
+		goStructs := gen.goStructs()
+
+		user := goStructs[0]
+		So(user.Enums[0].Name, ShouldEqual, "UserStatusEnum")
+		So(user.Enums[0].Values[0].Name, ShouldEqual, "UserStatusEnumActive")
+		So(user.Fields[1].Type, ShouldEqual, "*UserStatusEnum")
+		So(goStructs[1].Name, ShouldEqual, "UserStatus")
+	})
+}
@@ -1,6 +1,9 @@
 package gorm
 
 import (
+	"fmt"
+	"github.com/gertd/go-pluralize"
+	"github.com/iancoleman/strcase"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
 	"regexp"
@@ -8,11 +11,29 @@ import (
 )
 
 type GoAssocationField struct {
-	Name       string
-	Type       string
-	Array      bool
+	Name  string
+	Type  string
+	Array bool
+	// Pointer is set if pointer type is required to avoid recursive struct type
+	Pointer    bool
 	ForeignKey string
 	Reference  string
+	// many-to-many association only
+	JoinTable      string
+	JoinForeignKey string
+	JoinReference  string
+}
+
+// GoEnum is a string type generated from enum column.
+type GoEnum struct {
+	column *octopus.Column
+	Name   string
+	Values []*GoEnumValue
+}
+
+type GoEnumValue struct {
+	Name  string
+	Value string
 }
 
 type GoStruct struct {
@@ -23,6 +44,39 @@ type GoStruct struct {
 	PKFields           []*GoField
 	UniqueFields       []*GoField
 	AssociationFields  []*GoAssocationField
+	Enums              []*GoEnum
+}
+
+// hasMemberName returns true if the struct has a field or an association field of the name.
+func (s *GoStruct) hasMemberName(name string) bool {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	for _, field := range s.AssociationFields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// associationName returns unused association field name.
+// suffix is appended to the name if the name is already used.
+func (s *GoStruct) associationName(name string, suffix string) string {
+	if s.hasMemberName(name) {
+		name = name + suffix
+	}
+	for s.hasMemberName(name) {
+		name = name + "Ref"
+	}
+	return name
+}
+
+func (s *GoStruct) addAssociationField(field *GoAssocationField, suffix string) {
+	field.Name = s.associationName(field.Name, suffix)
+	s.AssociationFields = append(s.AssociationFields, field)
 }
 
 type IProcessor interface {
@@ -34,35 +88,33 @@ func NewGoStruct(
 	table *octopus.Table,
 	p IProcessor,
 ) *GoStruct {
+	structName := p.StructName(table)
+	client := pluralize.NewClient()
+
 	var fields []*GoField
 	var pkFields []*GoField
 	var uniqueFields []*GoField
-	var associationFields []*GoAssocationField
+	var enums []*GoEnum
 	for _, column := range table.Columns {
 		field := NewGoField(column)
 		fields = append(fields, field)
 
+		// enum type
+		if enum := newGoEnum(structName+field.Name, column); enum != nil {
+			enums = append(enums, enum)
+			field.Type = enum.Name
+			field.Imports = nil
+			if !column.NotNull {
+				field.Type = "*" + enum.Name
+			}
+		}
+
 		if column.PrimaryKey {
 			pkFields = append(pkFields, field)
 		}
 		if column.UniqueKey {
 			uniqueFields = append(uniqueFields, field)
 		}
-
-		// reference
-		if ref := column.Ref; ref != nil {
-			refTable, refColumn := p.Reference(*ref)
-			if refTable != nil && refColumn != nil {
-				refType := p.StructName(refTable)
-				associationFields = append(associationFields, &GoAssocationField{
-					Name:       refType,
-					Type:       refType,
-					Array:      ref.Relationship == octopus.RefOneToMany,
-					ForeignKey: field.Name,
-					Reference:  NewGoField(refColumn).Name,
-				})
-			}
-		}
 	}
 	embeddedModels := extractEmbeddedModels(fields)
 
@@ -74,14 +126,233 @@ func NewGoStruct(
 		}
 	}
 
-	return &GoStruct{
+	goStruct := &GoStruct{
 		table:              table,
-		Name:               p.StructName(table),
+		Name:               structName,
 		EmbeddedModelNames: embeddedModels.Names(),
 		Fields:             finalFields,
 		PKFields:           pkFields,
 		UniqueFields:       uniqueFields,
-		AssociationFields:  associationFields,
+		Enums:              enums,
+	}
+
+	// references
+	for _, field := range fields {
+		ref := field.Column.Ref
+		if ref == nil {
+			continue
+		}
+		refTable, refColumn := p.Reference(*ref)
+		if refTable == nil || refColumn == nil {
+			continue
+		}
+		refType := p.StructName(refTable)
+		refFieldName := NewGoField(refColumn).Name
+
+		if ref.Relationship == octopus.RefOneToMany {
+			// has many: foreign key field is in the referenced struct
+			goStruct.addAssociationField(&GoAssocationField{
+				Name:       client.Plural(refType),
+				Type:       refType,
+				Array:      true,
+				ForeignKey: refFieldName,
+				Reference:  field.Name,
+			}, strings.TrimSuffix(field.Name, "ID"))
+		} else if refTable == table {
+			// self reference
+			goStruct.addAssociationField(&GoAssocationField{
+				Name:       strings.TrimSuffix(field.Name, "ID"),
+				Type:       refType,
+				Pointer:    true,
+				ForeignKey: field.Name,
+				Reference:  refFieldName,
+			}, "Ref")
+		} else {
+			// belongs to
+			goStruct.addAssociationField(&GoAssocationField{
+				Name:       refType,
+				Type:       refType,
+				ForeignKey: field.Name,
+				Reference:  refFieldName,
+			}, strings.TrimSuffix(field.Name, "ID"))
+		}
+	}
+	return goStruct
+}
+
+// newGoEnum returns enum type of the column. returns nil if the column is not an enum column.
+// empty value is named '{Type}Empty', and values colliding after conversion get numeric suffix.
+func newGoEnum(name string, column *octopus.Column) *GoEnum {
+	if strings.ToLower(column.Type) != octopus.ColTypeEnum || len(column.Values) == 0 {
+		return nil
+	}
+	var values []*GoEnumValue
+	valueNameSet := util.NewStringSet(name)
+	for _, value := range column.Values {
+		baseName := strcase.ToCamel(value)
+		if baseName == "" {
+			baseName = "Empty"
+		}
+		valueName := name + baseName
+		for suffix := 2; valueNameSet.Contains(valueName); suffix++ {
+			valueName = fmt.Sprintf("%s%s%d", name, baseName, suffix)
+		}
+		valueNameSet.Add(valueName)
+		values = append(values, &GoEnumValue{
+			Name:  valueName,
+			Value: value,
+		})
+	}
+	return &GoEnum{column: column, Name: name, Values: values}
+}
+
+// resolveEnumNames renames enum types colliding with struct names or other enum types.
+// 'Enum' suffix is added to the colliding name. e.g. column 'status' of 'user' table -> 'UserStatusEnum' if 'user_status' table exists.
+func resolveEnumNames(goStructs []*GoStruct) {
+	nameSet := util.NewStringSet()
+	for _, goStruct := range goStructs {
+		nameSet.Add(goStruct.Name)
+	}
+	for _, goStruct := range goStructs {
+		for i, enum := range goStruct.Enums {
+			if !nameSet.Contains(enum.Name) {
+				nameSet.Add(enum.Name)
+				continue
+			}
+			name := enum.Name + "Enum"
+			for suffix := 2; nameSet.Contains(name); suffix++ {
+				name = fmt.Sprintf("%sEnum%d", enum.Name, suffix)
+			}
+			nameSet.Add(name)
+
+			renamed := newGoEnum(name, enum.column)
+			goStruct.Enums[i] = renamed
+			for _, field := range goStruct.Fields {
+				if field.Column == enum.column {
+					field.Type = strings.Replace(field.Type, enum.Name, renamed.Name, 1)
+				}
+			}
+		}
+	}
+}
+
+// isJoinTable returns true if the struct is a join table of many-to-many association.
+// join table has two many-to-one references to different tables, and no other columns except primary keys.
+func isJoinTable(goStruct *GoStruct) bool {
+	var refColumns []*octopus.Column
+	for _, column := range goStruct.table.Columns {
+		if ref := column.Ref; ref != nil && (ref.Relationship == "" || ref.Relationship == octopus.RefManyToOne) {
+			refColumns = append(refColumns, column)
+		} else if !column.PrimaryKey {
+			return false
+		}
+	}
+	return len(refColumns) == 2 && refColumns[0].Ref.Table != refColumns[1].Ref.Table
+}
+
+func structByTableName(goStructs []*GoStruct, tableName string) *GoStruct {
+	for _, goStruct := range goStructs {
+		if goStruct.table.Name == tableName {
+			return goStruct
+		}
+	}
+	return nil
+}
+
+// fieldByColumnName returns field of the column including embedded model fields.
+func fieldByColumnName(goStruct *GoStruct, columnName string) *GoField {
+	if column := goStruct.table.ColumnByName(columnName); column != nil {
+		return NewGoField(column)
+	}
+	return nil
+}
+
+// populateAssociations adds inverse association fields to the referenced structs,
+// and many-to-many association fields of join tables.
+func populateAssociations(goStructs []*GoStruct) {
+	client := pluralize.NewClient()
+
+	for _, source := range goStructs {
+		joinTable := isJoinTable(source)
+		var joinRefs []*GoField
+
+		for _, column := range source.table.Columns {
+			ref := column.Ref
+			if ref == nil {
+				continue
+			}
+			target := structByTableName(goStructs, ref.Table)
+			if target == nil {
+				continue
+			}
+			field := NewGoField(column)
+			if joinTable {
+				joinRefs = append(joinRefs, field)
+				continue
+			}
+			// self reference has no inverse side
+			if target == source {
+				continue
+			}
+			targetField := fieldByColumnName(target, ref.Column)
+			if targetField == nil {
+				continue
+			}
+
+			suffix := "By" + strings.TrimSuffix(field.Name, "ID")
+			switch ref.Relationship {
+			case octopus.RefOneToMany:
+				// belongs to
+				target.addAssociationField(&GoAssocationField{
+					Name:       source.Name,
+					Type:       source.Name,
+					ForeignKey: targetField.Name,
+					Reference:  field.Name,
+				}, suffix)
+			case octopus.RefOneToOne:
+				// has one
+				target.addAssociationField(&GoAssocationField{
+					Name:       source.Name,
+					Type:       source.Name,
+					Pointer:    true,
+					ForeignKey: field.Name,
+					Reference:  targetField.Name,
+				}, suffix)
+			default:
+				// has many
+				target.addAssociationField(&GoAssocationField{
+					Name:       client.Plural(source.Name),
+					Type:       source.Name,
+					Array:      true,
+					ForeignKey: field.Name,
+					Reference:  targetField.Name,
+				}, suffix)
+			}
+		}
+
+		if len(joinRefs) != 2 {
+			continue
+		}
+		for i, joinRef := range joinRefs {
+			otherRef := joinRefs[1-i]
+			owner := structByTableName(goStructs, joinRef.Column.Ref.Table)
+			other := structByTableName(goStructs, otherRef.Column.Ref.Table)
+			ownerField := fieldByColumnName(owner, joinRef.Column.Ref.Column)
+			otherField := fieldByColumnName(other, otherRef.Column.Ref.Column)
+			if ownerField == nil || otherField == nil {
+				continue
+			}
+			owner.addAssociationField(&GoAssocationField{
+				Name:           client.Plural(other.Name),
+				Type:           other.Name,
+				Array:          true,
+				ForeignKey:     ownerField.Name,
+				Reference:      otherField.Name,
+				JoinTable:      source.table.Name,
+				JoinForeignKey: joinRef.Name,
+				JoinReference:  otherRef.Name,
+			}, "By"+strings.TrimSuffix(joinRef.Name, "ID"))
+		}
 	}
 }
 
@@ -196,6 +467,7 @@ func NewGoField(column *octopus.Column) *GoField {
 			fieldType = "byte"
 		}
 	case octopus.ColTypeEnum:
+		// replaced with enum type if values are defined
 		fieldType = "string"
 	default:
 		fieldType = "interface{}"
@@ -0,0 +1,304 @@
+package gorm
+
+import (
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	// RepositoryTemplate is the template to generate repository interface, GORM implementation and mock.
+	// Template is executed with TplRepositoryData.
+	RepositoryTemplate = `{{"" -}}
+// {{.Name}} is the repository of {{.Struct.Name}}.
+type {{.Name}} interface {
+{{- range .Finders}}
+	{{.Name}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) ({{resultType $ .}}, error)
+{{- end}}
+	FindAll(ctx context.Context, offset, limit int) ([]*{{.Struct.Name}}, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, m *{{.Struct.Name}}) error
+	Update(ctx context.Context, m *{{.Struct.Name}}) error
+	Delete(ctx context.Context, m *{{.Struct.Name}}) error
+}
+
+type {{.ImplName}} struct {
+	db *gorm.DB
+}
+
+// New{{.Name}} returns GORM implementation of {{.Name}}.
+func New{{.Name}}(db *gorm.DB) {{.Name}} {
+	return &{{.ImplName}}{db: db}
+}
+{{- range .Finders}}
+
+func (r *{{$.ImplName}}) {{.Name}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) ({{resultType $ .}}, error) {
+{{- if .Unique}}
+	var m {{$.Struct.Name}}
+	if err := r.db.WithContext(ctx).Where({{whereMap .}}).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+{{- else}}
+	var ms []*{{$.Struct.Name}}
+	if err := r.db.WithContext(ctx).Where({{whereMap .}}).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	return ms, nil
+{{- end}}
+}
+{{- end}}
+
+func (r *{{.ImplName}}) FindAll(ctx context.Context, offset, limit int) ([]*{{.Struct.Name}}, error) {
+	var ms []*{{.Struct.Name}}
+	if err := r.db.WithContext(ctx){{range .OrderColumns}}.Order(clause.OrderByColumn{Column: clause.Column{Name: {{quote .}}}}){{end}}.Offset(offset).Limit(limit).Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (r *{{.ImplName}}) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&{{.Struct.Name}}{}).Count(&count).Error
+	return count, err
+}
+
+func (r *{{.ImplName}}) Create(ctx context.Context, m *{{.Struct.Name}}) error {
+	return r.db.WithContext(ctx).Create(m).Error
+}
+
+func (r *{{.ImplName}}) Update(ctx context.Context, m *{{.Struct.Name}}) error {
+	return r.db.WithContext(ctx).Save(m).Error
+}
+
+func (r *{{.ImplName}}) Delete(ctx context.Context, m *{{.Struct.Name}}) error {
+	return r.db.WithContext(ctx).Delete(m).Error
+}
+
+// {{.MockName}} is a mock of {{.Name}} for tests.
+type {{.MockName}} struct {
+{{- range .Finders}}
+	{{.Name}}Fn func(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) ({{resultType $ .}}, error)
+{{- end}}
+	FindAllFn func(ctx context.Context, offset, limit int) ([]*{{.Struct.Name}}, error)
+	CountFn func(ctx context.Context) (int64, error)
+	CreateFn func(ctx context.Context, m *{{.Struct.Name}}) error
+	UpdateFn func(ctx context.Context, m *{{.Struct.Name}}) error
+	DeleteFn func(ctx context.Context, m *{{.Struct.Name}}) error
+}
+{{- range .Finders}}
+
+func (r *{{$.MockName}}) {{.Name}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) ({{resultType $ .}}, error) {
+	return r.{{.Name}}Fn(ctx{{range .Params}}, {{.Name}}{{end}})
+}
+{{- end}}
+
+func (r *{{.MockName}}) FindAll(ctx context.Context, offset, limit int) ([]*{{.Struct.Name}}, error) {
+	return r.FindAllFn(ctx, offset, limit)
+}
+
+func (r *{{.MockName}}) Count(ctx context.Context) (int64, error) {
+	return r.CountFn(ctx)
+}
+
+func (r *{{.MockName}}) Create(ctx context.Context, m *{{.Struct.Name}}) error {
+	return r.CreateFn(ctx, m)
+}
+
+func (r *{{.MockName}}) Update(ctx context.Context, m *{{.Struct.Name}}) error {
+	return r.UpdateFn(ctx, m)
+}
+
+func (r *{{.MockName}}) Delete(ctx context.Context, m *{{.Struct.Name}}) error {
+	return r.DeleteFn(ctx, m)
+}
+
+`
+)
+
+// TplRepositoryData is the data passed to the repository template.
+type TplRepositoryData struct {
+	Struct       *GoStruct
+	Name         string
+	ImplName     string
+	MockName     string
+	Finders      []*RepoFinder
+	OrderColumns []string
+}
+
+// RepoFinder is a 'FindBy' method of the repository.
+// Unique finder returns single model, otherwise returns a slice.
+type RepoFinder struct {
+	Name   string
+	Params []*RepoParam
+	Unique bool
+}
+
+type RepoParam struct {
+	Name   string
+	Type   string
+	Column string
+}
+
+// repoLocalNames are names used in the generated finder methods, which cannot be parameter names.
+var repoLocalNames = util.NewStringSet("ctx", "m", "ms", "r", "clause")
+
+// newRepoParam returns finder parameter of the column.
+func newRepoParam(field *GoField) *RepoParam {
+	name := strcase.ToLowerCamel(field.Name)
+	if util.IsGoReservedWord(name) || repoLocalNames.Contains(name) {
+		name = name + "_"
+	}
+	return &RepoParam{
+		Name:   name,
+		Type:   field.Type,
+		Column: field.Column.Name,
+	}
+}
+
+// newTplRepositoryData returns repository template data of the struct.
+// finders are generated for primary key, unique key and indices. duplicated finders are skipped.
+func newTplRepositoryData(goStruct *GoStruct) *TplRepositoryData {
+	table := goStruct.table
+	fieldByColumnName := make(map[string]*GoField)
+	for _, column := range table.Columns {
+		field := NewGoField(column)
+		// use enum type of the struct field
+		for _, structField := range goStruct.Fields {
+			if structField.Column == column {
+				field = structField
+			}
+		}
+		fieldByColumnName[column.Name] = field
+	}
+
+	var finders []*RepoFinder
+	columnSetKeys := util.NewStringSet()
+	addFinder := func(name string, columnNames []string, unique bool) {
+		if len(columnNames) == 0 {
+			return
+		}
+		key := strings.Join(columnNames, ",")
+		if columnSetKeys.Contains(key) {
+			return
+		}
+		var params []*RepoParam
+		var fieldNames []string
+		for _, columnName := range columnNames {
+			field := fieldByColumnName[columnName]
+			if field == nil {
+				return
+			}
+			params = append(params, newRepoParam(field))
+			fieldNames = append(fieldNames, field.Name)
+		}
+		if name == "" {
+			name = "FindBy" + strings.Join(fieldNames, "And")
+		}
+		columnSetKeys.Add(key)
+		finders = append(finders, &RepoFinder{
+			Name:   name,
+			Params: params,
+			Unique: unique,
+		})
+	}
+
+	var pkColumnNames []string
+	var uniqueColumnNames []string
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			pkColumnNames = append(pkColumnNames, column.Name)
+		}
+		if column.UniqueKey {
+			uniqueColumnNames = append(uniqueColumnNames, column.Name)
+		}
+	}
+
+	addFinder("FindByID", pkColumnNames, true)
+	// unique columns of a table are a single unique constraint
+	addFinder("", uniqueColumnNames, true)
+	for _, index := range table.Indices {
+		addFinder("", index.Columns, false)
+	}
+
+	name := goStruct.Name + "Repository"
+	return &TplRepositoryData{
+		Struct:       goStruct,
+		Name:         name,
+		ImplName:     strcase.ToLowerCamel(goStruct.Name) + "Repository",
+		MockName:     "Mock" + name,
+		Finders:      finders,
+		OrderColumns: pkColumnNames,
+	}
+}
+
+// GenerateRepository writes repository interfaces, GORM implementations and mocks.
+func (g *Generator) GenerateRepository(wr io.Writer) error {
+	goStructs := g.goStructs()
+
+	var repositories []*TplRepositoryData
+	importSet := util.NewStringSet("context", "gorm.io/gorm")
+	for _, goStruct := range goStructs {
+		repository := newTplRepositoryData(goStruct)
+		repositories = append(repositories, repository)
+		if len(repository.OrderColumns) > 0 {
+			importSet.Add("gorm.io/gorm/clause")
+		}
+
+		for _, finder := range repository.Finders {
+			for _, param := range finder.Params {
+				for _, imp := range fieldImports(goStruct, param.Column) {
+					importSet.Add(imp)
+				}
+			}
+		}
+	}
+
+	if err := g.generateHeader(wr, importSet.Slice()); err != nil {
+		return err
+	}
+
+	funcMap := template.FuncMap{
+		"resultType": func(data *TplRepositoryData, finder *RepoFinder) string {
+			if finder.Unique {
+				return "*" + data.Struct.Name
+			}
+			return "[]*" + data.Struct.Name
+		},
+		// map conditions let GORM quote column names
+		"whereMap": func(finder *RepoFinder) string {
+			var conditions []string
+			for _, param := range finder.Params {
+				conditions = append(conditions, strconv.Quote(param.Column)+": "+param.Name)
+			}
+			return "map[string]interface{}{" + strings.Join(conditions, ", ") + "}"
+		},
+		"quote": strconv.Quote,
+	}
+	tmpl, err := util.NewTemplate("gormRepository", RepositoryTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+	for _, repository := range repositories {
+		if err := tmpl.Execute(wr, repository); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldImports returns imports required by the field type of the column.
+func fieldImports(goStruct *GoStruct, columnName string) []string {
+	for _, field := range goStruct.Fields {
+		if field.Column.Name == columnName {
+			return field.Imports
+		}
+	}
+	if column := goStruct.table.ColumnByName(columnName); column != nil {
+		return NewGoField(column).Imports
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package gorm
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestGorm_GenerateRepository(t *testing.T) {
+	Convey("GenerateRepository", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "account",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+							NotNull:         true,
+						},
+						{
+							Name:      "email",
+							Type:      octopus.ColTypeVarchar,
+							Size:      100,
+							UniqueKey: true,
+							NotNull:   true,
+						},
+						{
+							Name:    "type",
+							Type:    octopus.ColTypeVarchar,
+							Size:    10,
+							NotNull: true,
+						},
+					},
+					Indices: []*octopus.Index{
+						{Name: "idx_type", Columns: []string{"type"}},
+						{Name: "idx_email", Columns: []string{"email"}},
+					},
+				},
+			},
+		}
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper(""),
+			Package:      "model",
+			Repository:   true,
+		}
+
+		expectedStrings := []string{
+			"package model",
+			"",
+			"import (",
+			"	\"context\"",
+			"	\"gorm.io/gorm\"",
+			"	\"gorm.io/gorm/clause\"",
+			")",
+			"",
+			"// AccountRepository is the repository of Account.",
+			"type AccountRepository interface {",
+			"	FindByID(ctx context.Context, id int64) (*Account, error)",
+			"	FindByEmail(ctx context.Context, email string) (*Account, error)",
+			"	FindByType(ctx context.Context, type_ string) ([]*Account, error)",
+			"	FindAll(ctx context.Context, offset, limit int) ([]*Account, error)",
+			"	Count(ctx context.Context) (int64, error)",
+			"	Create(ctx context.Context, m *Account) error",
+			"	Update(ctx context.Context, m *Account) error",
+			"	Delete(ctx context.Context, m *Account) error",
+			"}",
+			"",
+			"type accountRepository struct {",
+			"	db *gorm.DB",
+			"}",
+			"",
+			"// NewAccountRepository returns GORM implementation of AccountRepository.",
+			"func NewAccountRepository(db *gorm.DB) AccountRepository {",
+			"	return &accountRepository{db: db}",
+			"}",
+			"",
+			"func (r *accountRepository) FindByID(ctx context.Context, id int64) (*Account, error) {",
+			"	var m Account",
+			"	if err := r.db.WithContext(ctx).Where(map[string]interface{}{\"id\": id}).First(&m).Error; err != nil {",
+			"		return nil, err",
+			"	}",
+			"	return &m, nil",
+			"}",
+			"",
+			"func (r *accountRepository) FindByEmail(ctx context.Context, email string) (*Account, error) {",
+			"	var m Account",
+			"	if err := r.db.WithContext(ctx).Where(map[string]interface{}{\"email\": email}).First(&m).Error; err != nil {",
+			"		return nil, err",
+			"	}",
+			"	return &m, nil",
+			"}",
+			"",
+			"func (r *accountRepository) FindByType(ctx context.Context, type_ string) ([]*Account, error) {",
+			"	var ms []*Account",
+			"	if err := r.db.WithContext(ctx).Where(map[string]interface{}{\"type\": type_}).Find(&ms).Error; err != nil {",
+			"		return nil, err",
+			"	}",
+			"	return ms, nil",
+			"}",
+			"",
+			"func (r *accountRepository) FindAll(ctx context.Context, offset, limit int) ([]*Account, error) {",
+			"	var ms []*Account",
+			"	if err := r.db.WithContext(ctx).Order(clause.OrderByColumn{Column: clause.Column{Name: \"id\"}}).Offset(offset).Limit(limit).Find(&ms).Error; err != nil {",
+			"		return nil, err",
+			"	}",
+			"	return ms, nil",
+			"}",
+			"",
+			"func (r *accountRepository) Count(ctx context.Context) (int64, error) {",
+			"	var count int64",
+			"	err := r.db.WithContext(ctx).Model(&Account{}).Count(&count).Error",
+			"	return count, err",
+			"}",
+			"",
+			"func (r *accountRepository) Create(ctx context.Context, m *Account) error {",
+			"	return r.db.WithContext(ctx).Create(m).Error",
+			"}",
+			"",
+			"func (r *accountRepository) Update(ctx context.Context, m *Account) error {",
+			"	return r.db.WithContext(ctx).Save(m).Error",
+			"}",
+			"",
+			"func (r *accountRepository) Delete(ctx context.Context, m *Account) error {",
+			"	return r.db.WithContext(ctx).Delete(m).Error",
+			"}",
+			"",
+			"// MockAccountRepository is a mock of AccountRepository for tests.",
+			"type MockAccountRepository struct {",
+			"	FindByIDFn func(ctx context.Context, id int64) (*Account, error)",
+			"	FindByEmailFn func(ctx context.Context, email string) (*Account, error)",
+			"	FindByTypeFn func(ctx context.Context, type_ string) ([]*Account, error)",
+			"	FindAllFn func(ctx context.Context, offset, limit int) ([]*Account, error)",
+			"	CountFn func(ctx context.Context) (int64, error)",
+			"	CreateFn func(ctx context.Context, m *Account) error",
+			"	UpdateFn func(ctx context.Context, m *Account) error",
+			"	DeleteFn func(ctx context.Context, m *Account) error",
+			"}",
+			"",
+			"func (r *MockAccountRepository) FindByID(ctx context.Context, id int64) (*Account, error) {",
+			"	return r.FindByIDFn(ctx, id)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) FindByEmail(ctx context.Context, email string) (*Account, error) {",
+			"	return r.FindByEmailFn(ctx, email)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) FindByType(ctx context.Context, type_ string) ([]*Account, error) {",
+			"	return r.FindByTypeFn(ctx, type_)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) FindAll(ctx context.Context, offset, limit int) ([]*Account, error) {",
+			"	return r.FindAllFn(ctx, offset, limit)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) Count(ctx context.Context) (int64, error) {",
+			"	return r.CountFn(ctx)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) Create(ctx context.Context, m *Account) error {",
+			"	return r.CreateFn(ctx, m)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) Update(ctx context.Context, m *Account) error {",
+			"	return r.UpdateFn(ctx, m)",
+			"}",
+			"",
+			"func (r *MockAccountRepository) Delete(ctx context.Context, m *Account) error {",
+			"	return r.DeleteFn(ctx, m)",
+			"}",
+			"",
+			"",
+		}
+		expected := strings.Join(expectedStrings, "\n")
+
+		gen := Generator{schema: schema, option: option}
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateRepository(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldResemble, expected)
+	})
+}
+
+func TestGorm_RepoParamName(t *testing.T) {
+	Convey("newRepoParam", t, func() {
+		paramName := func(columnName string) string {
+			return newRepoParam(NewGoField(&octopus.Column{Name: columnName, Type: octopus.ColTypeInt64})).Name
+		}
+		So(paramName("name"), ShouldEqual, "name")
+		So(paramName("type"), ShouldEqual, "type_")
+		So(paramName("ctx"), ShouldEqual, "ctx_")
+		So(paramName("m"), ShouldEqual, "m_")
+		So(paramName("ms"), ShouldEqual, "ms_")
+		So(paramName("r"), ShouldEqual, "r_")
+		So(paramName("clause"), ShouldEqual, "clause_")
+	})
+}
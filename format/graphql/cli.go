@@ -1,9 +1,13 @@
 package graphql
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/lechuckroh/octopus-db-tools/format/common"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
 	"github.com/urfave/cli/v2"
+	"path/filepath"
 	"strings"
 )
 
@@ -13,6 +17,7 @@ const (
 	FlagInput          = "input"
 	FlagOutput         = "output"
 	FlagPrefix         = "prefix"
+	FlagRelay          = "relay"
 	FlagRemovePrefix   = "removePrefix"
 )
 
@@ -22,15 +27,43 @@ func Action(c *cli.Context) error {
 		return err
 	}
 
-	gen := Generator{
-		schema: schema,
-		option: &Option{
-			PrefixMapper:   common.NewPrefixMapper(c.String(FlagPrefix)),
-			TableFilter:    octopus.GetTableFilterFn(c.String(FlagGroups)),
-			RemovePrefixes: strings.Split(c.String(FlagRemovePrefix), ","),
-		},
+	gen := NewGenerator(schema, &Option{
+		PrefixMapper:   common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:    octopus.GetTableFilterFn(c.String(FlagGroups)),
+		RemovePrefixes: strings.Split(c.String(FlagRemovePrefix), ","),
+		Relay:          c.Bool(FlagRelay),
+	})
+
+	outputPath := c.String(FlagOutput)
+	var filename string
+	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".graphql" || ext == ".graphqls" {
+		filename = outputPath
+	} else {
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, defaultFilename(schema))
+	}
+
+	buf := new(bytes.Buffer)
+	if err = gen.Generate(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+// defaultFilename returns '{name}-{version}.graphqls' filename of the schema.
+func defaultFilename(schema *octopus.Schema) string {
+	if schema.Name == "" {
+		return "schema.graphqls"
 	}
-	return gen.Generate(c.String(FlagOutput))
+	if schema.Version == "" {
+		return schema.Name + ".graphqls"
+	}
+	return fmt.Sprintf("%s-%s.graphqls", schema.Name, schema.Version)
 }
 
 var CliFlags = []cli.Flag{
@@ -44,7 +77,7 @@ var CliFlags = []cli.Flag{
 	&cli.StringFlag{
 		Name:     FlagOutput,
 		Aliases:  []string{"o"},
-		Usage:    "generate graphql filess to `DIR`",
+		Usage:    "generate graphql schema to `FILE`/`DIR`",
 		EnvVars:  []string{"OCTOPUS_OUTPUT"},
 		Required: true,
 	},
@@ -54,4 +87,27 @@ var CliFlags = []cli.Flag{
 		Usage:   "set target graphql package name",
 		EnvVars: []string{"OCTOPUS_GRAPHQL_PACKAGE"},
 	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"f"},
+		Usage:   "set type name prefix",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagRelay,
+		Usage:   "generate Relay-style connection pagination",
+		EnvVars: []string{"OCTOPUS_RELAY"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"r"},
+		Usage:   "set prefixes to remove from type name. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
 }
@@ -6,30 +6,153 @@ import (
 	"github.com/iancoleman/strcase"
 	"github.com/lechuckroh/octopus-db-tools/format/common"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
-	"io/ioutil"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
 	"log"
-	"os"
-	"path"
+	"regexp"
 	"strings"
+	"text/template"
 )
 
+const (
+	// GraphqlTemplate is the template to generate graphql schema.
+	// Template is executed with TplData.
+	GraphqlTemplate = `{{"" -}}
+schema {
+  query: Query
+{{- if hasMutations .Classes}}
+  mutation: Mutation
+{{- end}}
+}
+{{- if .Scalars}}
+{{range .Scalars}}
+scalar {{.}}
+{{- end}}
+{{- end}}
+
+type Query {
+{{- range .Classes}}
+{{- if $.Relay}}
+  {{listName .Name}}(first: Int, after: String, last: Int, before: String): {{.Name}}Connection!
+{{- else}}
+  {{listName .Name}}: [{{.Name}}]
+{{- end}}
+{{- if .PKFields}}
+  {{lowerCamel .Name}}({{pkArgs .}}): {{.Name}}
+{{- end}}
+{{- end}}
+}
+{{- if hasMutations .Classes}}
+
+type Mutation {
+{{- range .Classes}}
+{{- if createFields .}}
+  create{{.Name}}(input: Create{{.Name}}Input!): {{.Name}}!
+{{- end}}
+{{- if .PKFields}}
+{{- if updateFields .}}
+  update{{.Name}}({{pkArgs .}}, input: Update{{.Name}}Input!): {{.Name}}!
+{{- end}}
+  delete{{.Name}}({{pkArgs .}}): Boolean!
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{- range .Enums}}
+
+enum {{.Name}} {
+{{- range .Values}}
+  {{.}}
+{{- end}}
+}
+{{- end}}
+{{- range .Classes}}
+
+type {{.Name}} {
+{{- range .Fields}}
+  {{.Name}}: {{.Type}}
+{{- end}}
+{{- range .Relations}}
+  {{.Name}}: {{.Type}}
+{{- end}}
+}
+{{- if createFields .}}
+
+input Create{{.Name}}Input {
+{{- range createFields .}}
+  {{.Name}}: {{createType .}}
+{{- end}}
+}
+{{- end}}
+{{- if and .PKFields (updateFields .)}}
+
+input Update{{.Name}}Input {
+{{- range updateFields .}}
+  {{.Name}}: {{nullable .Type}}
+{{- end}}
+}
+{{- end}}
+{{- if $.Relay}}
+
+type {{.Name}}Connection {
+  edges: [{{.Name}}Edge!]!
+  pageInfo: PageInfo!
+  totalCount: Int!
+}
+
+type {{.Name}}Edge {
+  cursor: String!
+  node: {{.Name}}!
+}
+{{- end}}
+{{- end}}
+{{- if .Relay}}
+
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+{{- end}}
+`
+)
+
+// enumValuePattern is the pattern of graphql enum values.
+var enumValuePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
 type Class struct {
-	table    *octopus.Table
-	Name     string
-	Fields   []*Field
-	PKFields []*Field
+	table     *octopus.Table
+	Name      string
+	Fields    []*Field
+	PKFields  []*Field
+	Relations []*Relation
 }
 
 type Field struct {
 	Column *octopus.Column
 	Name   string
 	Type   string
+	Enum   *Enum
+	Scalar string
+}
+
+// Relation is an object field referencing other class.
+type Relation struct {
+	Name string
+	Type string
+}
+
+type Enum struct {
+	Name   string
+	Values []string
 }
 
 type Option struct {
 	TableFilter    octopus.TableFilterFn
 	PrefixMapper   *common.PrefixMapper
 	RemovePrefixes []string
+	Relay          bool
 }
 
 type Generator struct {
@@ -37,6 +160,21 @@ type Generator struct {
 	option *Option
 }
 
+// TplData is the data passed to GraphqlTemplate.
+type TplData struct {
+	Relay   bool
+	Scalars []string
+	Enums   []*Enum
+	Classes []*Class
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	return &Generator{
+		schema: schema,
+		option: option,
+	}
+}
+
 func NewClass(
 	table *octopus.Table,
 	option *Option,
@@ -50,15 +188,17 @@ func NewClass(
 		}
 		className = strcase.ToCamel(tableName)
 
-		if prefix := option.PrefixMapper.GetPrefix(table.Group); prefix != "" {
-			className = prefix + className
+		if option.PrefixMapper != nil {
+			if prefix := option.PrefixMapper.GetPrefix(table.Group); prefix != "" {
+				className = prefix + className
+			}
 		}
 	}
 
 	var fields []*Field
 	var pkFields []*Field
 	for _, column := range table.Columns {
-		field := NewField(column)
+		field := NewField(column, className)
 		fields = append(fields, field)
 
 		if column.PrimaryKey {
@@ -71,29 +211,53 @@ func NewClass(
 	}
 
 	return &Class{
-		table:  table,
-		Name:   className,
-		Fields: fields,
+		table:    table,
+		Name:     className,
+		Fields:   fields,
+		PKFields: pkFields,
 	}
 }
 
-func NewField(column *octopus.Column) *Field {
+// NewField returns a field of the column.
+// className is used to name the enum type of the field.
+func NewField(column *octopus.Column, className string) *Field {
+	field := &Field{
+		Column: column,
+		Name:   strcase.ToLowerCamel(column.Name),
+	}
+
 	var fieldType string
-	notNull := column.NotNull
 	columnType := strings.ToLower(column.Type)
 	switch columnType {
 	case octopus.ColTypeDateTime:
-		fallthrough
+		fieldType = "DateTime"
+		field.Scalar = fieldType
 	case octopus.ColTypeDate:
-		fallthrough
+		fieldType = "Date"
+		field.Scalar = fieldType
 	case octopus.ColTypeTime:
-		fallthrough
+		fieldType = "Time"
+		field.Scalar = fieldType
 	case octopus.ColTypeChar:
 		fallthrough
 	case octopus.ColTypeVarchar:
+		fallthrough
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
 		fieldType = "String"
 	case octopus.ColTypeBoolean:
 		fieldType = "Boolean"
+	case octopus.ColTypeBit:
+		if column.Size == 1 {
+			fieldType = "Boolean"
+		} else {
+			fieldType = "Int"
+		}
 	case octopus.ColTypeInt8:
 		fallthrough
 	case octopus.ColTypeInt16:
@@ -103,88 +267,249 @@ func NewField(column *octopus.Column) *Field {
 	case octopus.ColTypeInt32:
 		fallthrough
 	case octopus.ColTypeInt64:
+		fallthrough
+	case octopus.ColTypeYear:
 		fieldType = "Int"
 	case octopus.ColTypeDecimal:
-		fallthrough
+		fieldType = "Decimal"
+		field.Scalar = fieldType
 	case octopus.ColTypeFloat:
 		fallthrough
 	case octopus.ColTypeDouble:
 		fieldType = "Float"
+	case octopus.ColTypeJSON:
+		fieldType = "JSON"
+		field.Scalar = fieldType
+	case octopus.ColTypeEnum:
+		fallthrough
+	case octopus.ColTypeSet:
+		fieldType = "String"
+		if isValidEnum(column.Values) {
+			field.Enum = &Enum{
+				Name:   className + strcase.ToCamel(column.Name),
+				Values: column.Values,
+			}
+			fieldType = field.Enum.Name
+		} else if len(column.Values) > 0 {
+			log.Printf("Enum type skipped. %s.%s has invalid values: %v", className, column.Name, column.Values)
+		}
+		if columnType == octopus.ColTypeSet {
+			fieldType = fmt.Sprintf("[%s!]", fieldType)
+		}
 	default:
 		log.Printf("unknown column type: '%s', column: %s", column.Type, column.Name)
 		fieldType = "String"
 	}
-	if notNull {
+	if column.NotNull {
 		fieldType = fieldType + "!"
 	}
+	field.Type = fieldType
 
-	return &Field{
-		Column: column,
-		Name:   strcase.ToLowerCamel(column.Name),
-		Type:   fieldType,
-	}
+	return field
 }
 
-func (c *Generator) Generate(outputPath string) error {
-	// TODO: generate with template
+// isValidEnum returns true if values can be used as graphql enum values.
+func isValidEnum(values []string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, value := range values {
+		if !enumValuePattern.MatchString(value) || value == "true" || value == "false" || value == "null" {
+			return false
+		}
+	}
+	return true
+}
 
-	// Create directory
-	if err := os.MkdirAll(outputPath, 0777); err != nil {
-		return err
+// classByTableName returns class of the table name. returns nil if not found.
+func classByTableName(classes []*Class, tableName string) *Class {
+	for _, class := range classes {
+		if class.table.Name == tableName {
+			return class
+		}
 	}
-	log.Printf("[MKDIR] %s", outputPath)
+	return nil
+}
+
+// populateRelations adds relation fields to the classes.
+// the class with foreign key has an object field, and the referenced class has a reverse field.
+// '1:n' reference is reversed, so that the foreign key is always on the 'n' side.
+func populateRelations(classes []*Class) {
+	client := pluralize.NewClient()
 
-	indent := "  "
-	var contents []string
-	appendLine := func(indentLevel int, line string) {
-		contents = append(contents,
-			strings.Repeat(indent, indentLevel)+line,
-		)
+	nameSets := make(map[*Class]*util.StringSet)
+	for _, class := range classes {
+		nameSet := util.NewStringSet()
+		for _, field := range class.Fields {
+			nameSet.Add(field.Name)
+		}
+		nameSets[class] = nameSet
 	}
+	addRelation := func(class *Class, name string, suffix string, relationType string) {
+		nameSet := nameSets[class]
+		if nameSet.Contains(name) {
+			name = name + "By" + suffix
+		}
+		nameSet.Add(name)
+		class.Relations = append(class.Relations, &Relation{Name: name, Type: relationType})
+	}
+
+	for _, class := range classes {
+		for _, field := range class.Fields {
+			ref := field.Column.Ref
+			if ref == nil {
+				continue
+			}
+			target := classByTableName(classes, ref.Table)
+			if target == nil {
+				continue
+			}
+
+			source := class
+			fkField := field
+			if ref.Relationship == octopus.RefOneToMany {
+				targetField := target.fieldByColumnName(ref.Column)
+				if targetField == nil {
+					continue
+				}
+				source, target, fkField = target, class, targetField
+			}
 
-	appendLine(0, `schema {
-    query: Query
+			fkName := strings.TrimSuffix(fkField.Column.Name, "_id")
+			suffix := strcase.ToCamel(fkName)
+			name := strcase.ToLowerCamel(fkName)
+			if name == fkField.Name {
+				name = strcase.ToLowerCamel(target.Name)
+			}
+			relationType := target.Name
+			if fkField.Column.NotNull {
+				relationType = relationType + "!"
+			}
+			addRelation(source, name, suffix, relationType)
+
+			// self reference has no reverse side
+			if source == target {
+				continue
+			}
+			if ref.Relationship == octopus.RefOneToOne {
+				addRelation(target, strcase.ToLowerCamel(source.Name), suffix, source.Name)
+			} else {
+				addRelation(target, client.Plural(strcase.ToLowerCamel(source.Name)), suffix,
+					fmt.Sprintf("[%s!]!", source.Name))
+			}
+		}
+	}
 }
-`)
 
-	var classes []*Class
+func (c *Class) fieldByColumnName(columnName string) *Field {
+	for _, field := range c.Fields {
+		if field.Column.Name == columnName {
+			return field
+		}
+	}
+	return nil
+}
 
+func (c *Generator) funcMap() template.FuncMap {
 	client := pluralize.NewClient()
+	nullable := func(fieldType string) string {
+		return strings.TrimSuffix(fieldType, "!")
+	}
 
-	appendLine(0, "type Query {")
+	return template.FuncMap{
+		// list query name must differ from get query name. e.g. 'news' -> 'newsList'
+		"listName": func(name string) string {
+			singular := strcase.ToLowerCamel(name)
+			if plural := client.Plural(singular); plural != singular {
+				return plural
+			}
+			return singular + "List"
+		},
+		"lowerCamel": strcase.ToLowerCamel,
+		"nullable":   nullable,
+		"pkArgs": func(class *Class) string {
+			var args []string
+			for _, field := range class.PKFields {
+				args = append(args, fmt.Sprintf("%s: %s!", field.Name, nullable(field.Type)))
+			}
+			return strings.Join(args, ", ")
+		},
+		"createFields": createFields,
+		"createType": func(field *Field) string {
+			// columns with default value are optional
+			if field.Column.DefaultValue != "" {
+				return nullable(field.Type)
+			}
+			return field.Type
+		},
+		"updateFields": updateFields,
+		"hasMutations": func(classes []*Class) bool {
+			for _, class := range classes {
+				if len(createFields(class)) > 0 || len(class.PKFields) > 0 {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// createFields returns fields of create input. empty input type and its mutation are not generated.
+func createFields(class *Class) []*Field {
+	var fields []*Field
+	for _, field := range class.Fields {
+		if !field.Column.AutoIncremental {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// updateFields returns fields of update input. empty input type and its mutation are not generated.
+func updateFields(class *Class) []*Field {
+	var fields []*Field
+	for _, field := range class.Fields {
+		if !field.Column.PrimaryKey {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Generate writes graphql schema.
+func (c *Generator) Generate(wr io.Writer) error {
+	var classes []*Class
 	for _, table := range c.schema.Tables {
 		// filter table
 		if c.option.TableFilter != nil && !c.option.TableFilter(table) {
 			continue
 		}
-
-		class := NewClass(table, c.option)
-		classes = append(classes, class)
-
-		lowerClassName := strcase.ToLowerCamel(class.Name)
-		appendLine(1, fmt.Sprintf("%s: [%s]", client.Plural(lowerClassName), class.Name))
+		classes = append(classes, NewClass(table, c.option))
 	}
-	appendLine(0, "}")
-	appendLine(0, "")
+	populateRelations(classes)
 
+	scalarSet := util.NewStringSet()
+	var enums []*Enum
 	for _, class := range classes {
-		appendLine(0, fmt.Sprintf("type %s {", class.Name))
-
 		for _, field := range class.Fields {
-			appendLine(1, fmt.Sprintf("%s: %s", field.Name, field.Type))
+			if field.Scalar != "" {
+				scalarSet.Add(field.Scalar)
+			}
+			if field.Enum != nil {
+				enums = append(enums, field.Enum)
+			}
 		}
-		appendLine(0, "}")
-		appendLine(0, "")
 	}
 
-	// Write file
-	outputFile := path.Join(outputPath,
-		fmt.Sprintf("%s-%s.graphqls", c.schema.Name, c.schema.Version))
-
-	if err := ioutil.WriteFile(outputFile, []byte(strings.Join(contents, "\n")), 0644); err != nil {
+	tmpl, err := util.NewTemplate("graphql", GraphqlTemplate, c.funcMap())
+	if err != nil {
 		return err
 	}
-	log.Printf("[WRITE] %s", outputFile)
 
-	return nil
+	return tmpl.Execute(wr, &TplData{
+		Relay:   c.option.Relay,
+		Scalars: scalarSet.Slice(),
+		Enums:   enums,
+		Classes: classes,
+	})
 }
@@ -0,0 +1,367 @@
+package graphql
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:  "group",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:    "name",
+					Type:    octopus.ColTypeVarchar,
+					Size:    40,
+					NotNull: true,
+				},
+				{
+					Name:    "owner_id",
+					Type:    octopus.ColTypeInt64,
+					NotNull: true,
+					Ref: &octopus.Reference{
+						Table:        "user",
+						Column:       "id",
+						Relationship: octopus.RefOneToOne,
+					},
+				},
+			},
+		},
+		{
+			Name:  "user",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:    "class",
+					Type:    octopus.ColTypeEnum,
+					Values:  []string{"A", "B"},
+					NotNull: true,
+				},
+				{
+					Name:         "point",
+					Type:         octopus.ColTypeDecimal,
+					Size:         10,
+					Scale:        2,
+					NotNull:      true,
+					DefaultValue: "0",
+				},
+				{
+					Name: "created_at",
+					Type: octopus.ColTypeDateTime,
+				},
+				{
+					Name: "group_id",
+					Type: octopus.ColTypeInt64,
+					Ref: &octopus.Reference{
+						Table:  "group",
+						Column: "id",
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	Convey("Generate", t, func() {
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper("common:C"),
+		}
+		expected := `schema {
+  query: Query
+  mutation: Mutation
+}
+
+scalar DateTime
+scalar Decimal
+
+type Query {
+  cGroups: [CGroup]
+  cGroup(id: ID!): CGroup
+  cUsers: [CUser]
+  cUser(id: ID!): CUser
+}
+
+type Mutation {
+  createCGroup(input: CreateCGroupInput!): CGroup!
+  updateCGroup(id: ID!, input: UpdateCGroupInput!): CGroup!
+  deleteCGroup(id: ID!): Boolean!
+  createCUser(input: CreateCUserInput!): CUser!
+  updateCUser(id: ID!, input: UpdateCUserInput!): CUser!
+  deleteCUser(id: ID!): Boolean!
+}
+
+enum CUserClass {
+  A
+  B
+}
+
+type CGroup {
+  id: ID!
+  name: String!
+  ownerId: Int!
+  owner: CUser!
+  cUsers: [CUser!]!
+}
+
+input CreateCGroupInput {
+  name: String!
+  ownerId: Int!
+}
+
+input UpdateCGroupInput {
+  name: String
+  ownerId: Int
+}
+
+type CUser {
+  id: ID!
+  class: CUserClass!
+  point: Decimal!
+  createdAt: DateTime
+  groupId: Int
+  cGroup: CGroup
+  group: CGroup
+}
+
+input CreateCUserInput {
+  class: CUserClass!
+  point: Decimal
+  createdAt: DateTime
+  groupId: Int
+}
+
+input UpdateCUserInput {
+  class: CUserClass
+  point: Decimal
+  createdAt: DateTime
+  groupId: Int
+}
+`
+
+		buf := new(bytes.Buffer)
+		err := NewGenerator(testSchema, option).Generate(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_Relay(t *testing.T) {
+	Convey("Relay", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "tag",
+					Columns: []*octopus.Column{
+						{
+							Name:    "name",
+							Type:    octopus.ColTypeVarchar,
+							NotNull: true,
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			Relay: true,
+		}
+		expected := `schema {
+  query: Query
+  mutation: Mutation
+}
+
+type Query {
+  tags(first: Int, after: String, last: Int, before: String): TagConnection!
+}
+
+type Mutation {
+  createTag(input: CreateTagInput!): Tag!
+}
+
+type Tag {
+  name: String!
+}
+
+input CreateTagInput {
+  name: String!
+}
+
+type TagConnection {
+  edges: [TagEdge!]!
+  pageInfo: PageInfo!
+  totalCount: Int!
+}
+
+type TagEdge {
+  cursor: String!
+  node: Tag!
+}
+
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+`
+
+		buf := new(bytes.Buffer)
+		err := NewGenerator(schema, option).Generate(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_EmptyInput(t *testing.T) {
+	Convey("EmptyInput", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "sequence",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							NotNull:         true,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+					},
+				},
+				{
+					Name: "user_group",
+					Columns: []*octopus.Column{
+						{
+							Name:       "user_id",
+							Type:       octopus.ColTypeInt64,
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:       "group_id",
+							Type:       octopus.ColTypeInt64,
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+					},
+				},
+			},
+		}
+		expected := `schema {
+  query: Query
+  mutation: Mutation
+}
+
+type Query {
+  sequences: [Sequence]
+  sequence(id: ID!): Sequence
+  userGroups: [UserGroup]
+  userGroup(userId: Int!, groupId: Int!): UserGroup
+}
+
+type Mutation {
+  deleteSequence(id: ID!): Boolean!
+  createUserGroup(input: CreateUserGroupInput!): UserGroup!
+  deleteUserGroup(userId: Int!, groupId: Int!): Boolean!
+}
+
+type Sequence {
+  id: ID!
+}
+
+type UserGroup {
+  userId: Int!
+  groupId: Int!
+}
+
+input CreateUserGroupInput {
+  userId: Int!
+  groupId: Int!
+}
+`
+
+		buf := new(bytes.Buffer)
+		err := NewGenerator(schema, &Option{}).Generate(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_UncountableName(t *testing.T) {
+	Convey("UncountableName", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "news",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, NotNull: true, PrimaryKey: true, AutoIncremental: true},
+					},
+				},
+			},
+		}
+		expected := `schema {
+  query: Query
+  mutation: Mutation
+}
+
+type Query {
+  newsList: [News]
+  news(id: ID!): News
+}
+
+type Mutation {
+  deleteNews(id: ID!): Boolean!
+}
+
+type News {
+  id: ID!
+}
+`
+
+		buf := new(bytes.Buffer)
+		err := NewGenerator(schema, &Option{}).Generate(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,100 @@
+package htmldoc
+
+import (
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/urfave/cli/v2"
+	"log"
+	"os/exec"
+)
+
+const (
+	FlagGroups   = "groups"
+	FlagInput    = "input"
+	FlagNoErd    = "noErd"
+	FlagOutput   = "output"
+	FlagPrevious = "previous"
+	FlagTitle    = "title"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := loadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	var previous []*octopus.Schema
+	for _, filename := range c.StringSlice(FlagPrevious) {
+		prevSchema, err := loadSchema(filename)
+		if err != nil {
+			return err
+		}
+		previous = append(previous, prevSchema)
+	}
+
+	var dotPath string
+	if !c.Bool(FlagNoErd) {
+		if dotPath, err = exec.LookPath("dot"); err != nil {
+			log.Printf("graphviz 'dot' command not found. ERD is not rendered.")
+		}
+	}
+
+	gen := NewGenerator(schema, &Option{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		Title:       c.String(FlagTitle),
+		Previous:    previous,
+		DotPath:     dotPath,
+	})
+	return gen.Generate(c.String(FlagOutput))
+}
+
+// loadSchema loads schema and uses filename as version if version is not set.
+func loadSchema(filename string) (*octopus.Schema, error) {
+	schema, err := octopus.LoadSchema(filename)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Version == "" {
+		schema.Version = filename
+	}
+	return schema, nil
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate HTML documents to `DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagNoErd,
+		Usage:   "do not render ERD with graphviz",
+		EnvVars: []string{"OCTOPUS_NO_ERD"},
+	},
+	&cli.StringSliceFlag{
+		Name:    FlagPrevious,
+		Aliases: []string{"p"},
+		Usage:   "previous octopus schema `FILE`s to show changes, oldest first. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_PREVIOUS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagTitle,
+		Aliases: []string{"t"},
+		Usage:   "site title",
+		EnvVars: []string{"OCTOPUS_TITLE"},
+	},
+}
@@ -0,0 +1,432 @@
+package htmldoc
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/diff"
+	"github.com/lechuckroh/octopus-db-tools/format/dot"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"html"
+	"html/template"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	IndexFilename   = "index.html"
+	ChangesFilename = "changes.html"
+	StyleFilename   = "style.css"
+	ErdDotFilename  = "erd.dot"
+	ErdSvgFilename  = "erd.svg"
+	TablesDir       = "tables"
+)
+
+type Option struct {
+	TableFilter octopus.TableFilterFn
+	// Title is the site title. Schema name is used if empty.
+	Title string
+	// Previous is the list of previous schema versions, oldest first.
+	Previous []*octopus.Schema
+	// DotPath is the path of graphviz 'dot' command to render ERD.
+	// ERD is not rendered if empty.
+	DotPath string
+}
+
+type docLink struct {
+	Table        string
+	Column       string
+	Relationship string
+	Href         string
+	// Target is the referenced column name.
+	Target string
+}
+
+type docColumn struct {
+	Column *octopus.Column
+	Keys   string
+	Ref    *docLink
+}
+
+type docTable struct {
+	Table        *octopus.Table
+	Filename     string
+	Columns      []*docColumn
+	ReferencedBy []*docLink
+	SearchText   string
+}
+
+type docGroup struct {
+	Name   string
+	Tables []*docTable
+}
+
+type docTableChanges struct {
+	Name  string
+	Href  string
+	Lines []template.HTML
+}
+
+type docChanges struct {
+	From   string
+	To     string
+	Tables []*docTableChanges
+}
+
+// page is the data used by page layout.
+type page struct {
+	Title      string
+	SiteTitle  string
+	Root       string
+	HasChanges bool
+}
+
+type indexPage struct {
+	page
+	Version string
+	Groups  []*docGroup
+	ErdSvg  bool
+}
+
+type tablePage struct {
+	page
+	Table *docTable
+}
+
+type changesPage struct {
+	page
+	Changes []*docChanges
+}
+
+type Generator struct {
+	schema      *octopus.Schema
+	option      *Option
+	tables      []*docTable
+	tableByName map[string]*docTable
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	gen := &Generator{
+		schema:      schema,
+		option:      option,
+		tableByName: make(map[string]*docTable),
+	}
+
+	for _, table := range schema.FilteredTables(option.TableFilter) {
+		docTable := &docTable{
+			Table:    table,
+			Filename: tableFilename(table.Name),
+		}
+		gen.tables = append(gen.tables, docTable)
+		gen.tableByName[table.Name] = docTable
+	}
+
+	for _, docTable := range gen.tables {
+		table := docTable.Table
+		words := []string{table.Name, table.Description}
+		for _, column := range table.Columns {
+			docColumn := &docColumn{
+				Column: column,
+				Keys:   strings.Join(column.Keys(), ", "),
+			}
+			if ref := column.Ref; ref != nil {
+				docColumn.Ref = &docLink{
+					Table:        ref.Table,
+					Column:       ref.Column,
+					Relationship: ref.Relationship,
+					Href:         gen.columnHref(ref.Table, ref.Column),
+				}
+				if referenced, ok := gen.tableByName[ref.Table]; ok {
+					referenced.ReferencedBy = append(referenced.ReferencedBy, &docLink{
+						Table:        table.Name,
+						Column:       column.Name,
+						Relationship: ref.Relationship,
+						Href:         gen.columnHref(table.Name, column.Name),
+						Target:       ref.Column,
+					})
+				}
+			}
+			docTable.Columns = append(docTable.Columns, docColumn)
+			words = append(words, column.Name, column.Description)
+		}
+		docTable.SearchText = searchText(words)
+	}
+	return gen
+}
+
+// searchText returns lower-cased text of non-empty words to search tables.
+func searchText(words []string) string {
+	var result []string
+	for _, word := range words {
+		if word != "" {
+			result = append(result, strings.ToLower(word))
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+// tableFilename returns filename of the table page.
+func tableFilename(tableName string) string {
+	return util.SafeFilename(tableName) + ".html"
+}
+
+// columnHref returns link to the column from table pages.
+// returns empty string if the table is not rendered.
+func (g *Generator) columnHref(tableName, columnName string) string {
+	docTable, ok := g.tableByName[tableName]
+	if !ok {
+		return ""
+	}
+	return docTable.Filename + "#col-" + columnName
+}
+
+func (g *Generator) siteTitle() string {
+	if g.option.Title != "" {
+		return g.option.Title
+	}
+	if g.schema.Name != "" {
+		return g.schema.Name
+	}
+	return "Database Schema"
+}
+
+func (g *Generator) newPage(title string, root string) page {
+	return page{
+		Title:      title,
+		SiteTitle:  g.siteTitle(),
+		Root:       root,
+		HasChanges: len(g.option.Previous) > 0,
+	}
+}
+
+// Groups returns tables grouped by table group.
+func (g *Generator) Groups() []*docGroup {
+	groupByName := make(map[string]*docGroup)
+	var groups []*docGroup
+	for _, docTable := range g.tables {
+		name := docTable.Table.Group
+		group, ok := groupByName[name]
+		if !ok {
+			group = &docGroup{Name: name}
+			groupByName[name] = group
+			groups = append(groups, group)
+		}
+		group.Tables = append(group.Tables, docTable)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	for _, group := range groups {
+		sort.Slice(group.Tables, func(i, j int) bool {
+			return group.Tables[i].Table.Name < group.Tables[j].Table.Name
+		})
+	}
+	return groups
+}
+
+// Generate writes the documentation site to outputPath directory.
+func (g *Generator) Generate(outputPath string) error {
+	if _, err := util.Mkdir(filepath.Join(outputPath, TablesDir)); err != nil {
+		return err
+	}
+
+	if err := util.WriteStringToFile(filepath.Join(outputPath, StyleFilename), Style); err != nil {
+		return err
+	}
+
+	// ERD
+	erdSvg, err := g.generateErd(outputPath)
+	if err != nil {
+		return err
+	}
+
+	// index
+	buf := new(bytes.Buffer)
+	if err := g.GenerateIndex(buf, erdSvg); err != nil {
+		return err
+	}
+	if err := util.WriteStringToFile(filepath.Join(outputPath, IndexFilename), buf.String()); err != nil {
+		return err
+	}
+
+	// tables
+	for _, docTable := range g.tables {
+		buf := new(bytes.Buffer)
+		if err := g.GenerateTable(buf, docTable.Table.Name); err != nil {
+			return err
+		}
+		filename := filepath.Join(outputPath, TablesDir, docTable.Filename)
+		if err := util.WriteStringToFile(filename, buf.String()); err != nil {
+			return err
+		}
+	}
+
+	// changes
+	if len(g.option.Previous) > 0 {
+		buf := new(bytes.Buffer)
+		if err := g.GenerateChanges(buf); err != nil {
+			return err
+		}
+		if err := util.WriteStringToFile(filepath.Join(outputPath, ChangesFilename), buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateErd writes ERD in DOT format, and renders SVG if graphviz is available.
+// returns true if SVG is rendered.
+func (g *Generator) generateErd(outputPath string) (bool, error) {
+	exporter := dot.NewExporter(g.schema, &dot.Option{
+		TableFilter: g.option.TableFilter,
+		RankDir:     "LR",
+		Columns:     dot.ColumnsKeys,
+	})
+	buf := new(bytes.Buffer)
+	if err := exporter.Export(buf); err != nil {
+		return false, err
+	}
+	if err := util.WriteStringToFile(filepath.Join(outputPath, ErdDotFilename), buf.String()); err != nil {
+		return false, err
+	}
+
+	if g.option.DotPath == "" {
+		return false, nil
+	}
+	svg, err := renderSvg(g.option.DotPath, buf.Bytes())
+	if err != nil {
+		return false, err
+	}
+	if err := util.WriteBytesToFile(filepath.Join(outputPath, ErdSvgFilename), svg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// renderSvg renders DOT source to SVG using graphviz.
+func renderSvg(dotPath string, source []byte) ([]byte, error) {
+	stderr := new(bytes.Buffer)
+	cmd := exec.Command(dotPath, "-Tsvg")
+	cmd.Stdin = bytes.NewReader(source)
+	cmd.Stderr = stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render ERD: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+func executePage(wr io.Writer, contentTemplate string, data interface{}) error {
+	tmpl, err := template.New("layout").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(LayoutTemplate)
+	if err != nil {
+		return err
+	}
+	if _, err = tmpl.Parse(contentTemplate); err != nil {
+		return err
+	}
+	return tmpl.ExecuteTemplate(wr, "layout", data)
+}
+
+// GenerateIndex writes the index page listing tables by group.
+func (g *Generator) GenerateIndex(wr io.Writer, erdSvg bool) error {
+	return executePage(wr, IndexTemplate, &indexPage{
+		page:    g.newPage(g.siteTitle(), ""),
+		Version: g.schema.Version,
+		Groups:  g.Groups(),
+		ErdSvg:  erdSvg,
+	})
+}
+
+// GenerateTable writes the table detail page.
+func (g *Generator) GenerateTable(wr io.Writer, tableName string) error {
+	docTable, ok := g.tableByName[tableName]
+	if !ok {
+		return fmt.Errorf("table not found: %s", tableName)
+	}
+	return executePage(wr, TableTemplate, &tablePage{
+		page:  g.newPage(tableName+" - "+g.siteTitle(), "../"),
+		Table: docTable,
+	})
+}
+
+// GenerateChanges writes changes between schema versions, latest first.
+func (g *Generator) GenerateChanges(wr io.Writer) error {
+	versions := append(append([]*octopus.Schema{}, g.option.Previous...), g.schema)
+
+	var changes []*docChanges
+	for i := len(versions) - 1; i > 0; i-- {
+		docChanges, err := g.diff(versions[i-1], versions[i])
+		if err != nil {
+			return err
+		}
+		changes = append(changes, docChanges)
+	}
+
+	return executePage(wr, ChangesTemplate, &changesPage{
+		page:    g.newPage("Changes - "+g.siteTitle(), ""),
+		Changes: changes,
+	})
+}
+
+func (g *Generator) diff(from, to *octopus.Schema) (*docChanges, error) {
+	result, err := diff.GetDiff(&diff.Option{
+		TableFilter: g.option.TableFilter,
+		DiffFrom:    from,
+		DiffTo:      to,
+		UseComments: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changesByTable := util.NewMultiMap()
+	for _, changeSet := range result.ChangeSets {
+		for _, change := range changeSet.Changes {
+			changesByTable.Put(change.DepTable().Name, change)
+		}
+	}
+
+	keys := changesByTable.Keys()
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Compare(keys[i].(string), keys[j].(string)) < 0
+	})
+
+	docChanges := &docChanges{From: from.Version, To: to.Version}
+	for _, key := range keys {
+		tableName := key.(string)
+		tableChanges := &docTableChanges{Name: tableName}
+		if docTable, ok := g.tableByName[tableName]; ok && to.TableByName(tableName) != nil {
+			tableChanges.Href = TablesDir + "/" + docTable.Filename
+		}
+
+		values, _ := changesByTable.Get(key)
+		for _, value := range values {
+			line, err := diff.DescribeChange(value.(diff.Change))
+			if err != nil {
+				return nil, err
+			}
+			tableChanges.Lines = append(tableChanges.Lines, markdownCodeToHTML(line))
+		}
+		docChanges.Tables = append(docChanges.Tables, tableChanges)
+	}
+	return docChanges, nil
+}
+
+// markdownCodeToHTML escapes s and converts markdown code spans to code elements.
+func markdownCodeToHTML(s string) template.HTML {
+	var sb strings.Builder
+	for i, part := range strings.Split(s, "`") {
+		if i%2 == 1 {
+			sb.WriteString("<code>" + html.EscapeString(part) + "</code>")
+		} else {
+			sb.WriteString(html.EscapeString(part))
+		}
+	}
+	return template.HTML(sb.String())
+}
@@ -0,0 +1,254 @@
+package htmldoc
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func newTestSchemas() (*octopus.Schema, *octopus.Schema) {
+	newGroupTable := func() *octopus.Table {
+		return &octopus.Table{
+			Name:        "group",
+			Group:       "common",
+			Description: "Group table",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:        "name",
+					Type:        octopus.ColTypeVarchar,
+					Size:        40,
+					NotNull:     true,
+					UniqueKey:   true,
+					Description: "group <name>",
+				},
+			},
+		}
+	}
+	prevSchema := &octopus.Schema{
+		Version: "1.0.0",
+		Tables: []*octopus.Table{
+			newGroupTable(),
+			{
+				Name: "user",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+				},
+			},
+		},
+	}
+	schema := &octopus.Schema{
+		Version: "1.1.0",
+		Tables: []*octopus.Table{
+			newGroupTable(),
+			{
+				Name:        "user",
+				Description: "User table",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:         "group_id",
+						Type:         octopus.ColTypeInt64,
+						DefaultValue: "1",
+						Ref: &octopus.Reference{
+							Table:        "group",
+							Column:       "id",
+							Relationship: octopus.RefManyToOne,
+						},
+					},
+				},
+				Indices: []*octopus.Index{
+					{Name: "idx_group", Columns: []string{"group_id", "id"}},
+				},
+			},
+		},
+	}
+	return prevSchema, schema
+}
+
+func TestGenerator_GenerateTable(t *testing.T) {
+	Convey("GenerateTable", t, func() {
+		prevSchema, schema := newTestSchemas()
+		gen := NewGenerator(schema, &Option{
+			Previous: []*octopus.Schema{prevSchema},
+		})
+
+		Convey("user", func() {
+			expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>user - Database Schema</title>
+<link rel="stylesheet" href="../style.css">
+</head>
+<body>
+<nav><a href="../index.html">Database Schema</a> | <a href="../changes.html">Changes</a></nav>
+<main>
+<h1>user</h1>
+<p>User table</p>
+<h2>Columns</h2>
+<table>
+<thead><tr><th>Name</th><th>Type</th><th>Keys</th><th>Not Null</th><th>Default</th><th>Reference</th><th>Description</th></tr></thead>
+<tbody>
+<tr id="col-id"><td>id</td><td>int64</td><td>PK</td><td>Y</td><td></td><td></td><td></td></tr>
+<tr id="col-group_id"><td>group_id</td><td>int64</td><td>FK</td><td></td><td>1</td><td><a href="group.html#col-id">group.id</a></td><td></td></tr>
+</tbody>
+</table>
+<h2>Indices</h2>
+<table>
+<thead><tr><th>Name</th><th>Columns</th></tr></thead>
+<tbody>
+<tr><td>idx_group</td><td>group_id, id</td></tr>
+</tbody>
+</table>
+</main>
+</body>
+</html>
+`
+			buf := new(bytes.Buffer)
+			So(gen.GenerateTable(buf, "user"), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("group", func() {
+			expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>group - Database Schema</title>
+<link rel="stylesheet" href="../style.css">
+</head>
+<body>
+<nav><a href="../index.html">Database Schema</a> | <a href="../changes.html">Changes</a></nav>
+<main>
+<h1>group</h1>
+<p class="group">Group: common</p>
+<p>Group table</p>
+<h2>Columns</h2>
+<table>
+<thead><tr><th>Name</th><th>Type</th><th>Keys</th><th>Not Null</th><th>Default</th><th>Reference</th><th>Description</th></tr></thead>
+<tbody>
+<tr id="col-id"><td>id</td><td>int64</td><td>PK, AI</td><td>Y</td><td></td><td></td><td></td></tr>
+<tr id="col-name"><td>name</td><td>varchar(40)</td><td>UK</td><td>Y</td><td></td><td></td><td>group &lt;name&gt;</td></tr>
+</tbody>
+</table>
+<h2>Referenced by</h2>
+<ul>
+<li><a href="user.html#col-group_id">user.group_id</a> &rarr; id (n:1)</li>
+</ul>
+</main>
+</body>
+</html>
+`
+			buf := new(bytes.Buffer)
+			So(gen.GenerateTable(buf, "group"), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("reference without relationship", func() {
+			schema.Tables[1].Columns[1].Ref.Relationship = ""
+			gen := NewGenerator(schema, &Option{})
+			buf := new(bytes.Buffer)
+			So(gen.GenerateTable(buf, "group"), ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, `<li><a href="user.html#col-group_id">user.group_id</a> &rarr; id</li>`)
+		})
+
+		Convey("filtered reference", func() {
+			gen := NewGenerator(schema, &Option{
+				TableFilter: func(table *octopus.Table) bool {
+					return table.Name == "user"
+				},
+			})
+			So(gen.tableByName["user"].Columns[1].Ref.Href, ShouldBeEmpty)
+			So(gen.GenerateTable(new(bytes.Buffer), "group"), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGenerator_GenerateChanges(t *testing.T) {
+	Convey("GenerateChanges", t, func() {
+		prevSchema, schema := newTestSchemas()
+		gen := NewGenerator(schema, &Option{
+			Title:    "Sample",
+			Previous: []*octopus.Schema{prevSchema},
+		})
+
+		expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Changes - Sample</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<nav><a href="index.html">Sample</a> | <a href="changes.html">Changes</a></nav>
+<main>
+<h1>Changes</h1>
+<section class="changes">
+<h2>1.0.0 &rarr; 1.1.0</h2>
+<h3><a href="tables/user.html">user</a></h3>
+<ul>
+<li>update table comment: <code>User table</code></li>
+<li>add column: <code>group_id</code></li>
+</ul>
+</section>
+</main>
+</body>
+</html>
+`
+		buf := new(bytes.Buffer)
+		So(gen.GenerateChanges(buf), ShouldBeNil)
+		actual := buf.String()
+		diff := cmp.Diff(expected, actual)
+		if diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_Groups(t *testing.T) {
+	Convey("Groups", t, func() {
+		_, schema := newTestSchemas()
+		groups := NewGenerator(schema, &Option{}).Groups()
+
+		So(groups, ShouldHaveLength, 2)
+		So(groups[0].Name, ShouldEqual, "")
+		So(groups[0].Tables[0].Table.Name, ShouldEqual, "user")
+		So(groups[0].Tables[0].SearchText, ShouldEqual, "user user table id group_id")
+		So(groups[1].Name, ShouldEqual, "common")
+		So(groups[1].Tables[0].Table.Name, ShouldEqual, "group")
+	})
+}
@@ -0,0 +1,177 @@
+package htmldoc
+
+const (
+	// LayoutTemplate is the common page layout.
+	// Each page defines "content" template.
+	LayoutTemplate = `{{define "layout"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="{{.Root}}` + StyleFilename + `">
+</head>
+<body>
+<nav><a href="{{.Root}}` + IndexFilename + `">{{.SiteTitle}}</a>{{if .HasChanges}} | <a href="{{.Root}}` + ChangesFilename + `">Changes</a>{{end}}</nav>
+<main>
+{{template "content" .}}
+</main>
+</body>
+</html>
+{{end}}
+{{- define "link"}}{{if .Href}}<a href="{{.Href}}">{{.Table}}.{{.Column}}</a>{{else}}{{.Table}}.{{.Column}}{{end}}{{end}}`
+
+	// IndexTemplate is the template of table list page.
+	IndexTemplate = `{{define "content"}}<h1>{{.SiteTitle}}</h1>
+{{- with .Version}}
+<p class="version">Version: {{.}}</p>
+{{- end}}
+<input id="search" type="search" placeholder="Search tables and columns" autofocus>
+{{- range .Groups}}
+<section class="group">
+<h2>{{if .Name}}{{.Name}}{{else}}(no group){{end}}</h2>
+<table>
+<thead><tr><th>Table</th><th>Columns</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Tables}}
+<tr data-search="{{.SearchText}}"><td><a href="` + TablesDir + `/{{.Filename}}">{{.Table.Name}}</a></td><td>{{len .Columns}}</td><td>{{.Table.Description}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</section>
+{{- end}}
+<h2>ERD</h2>
+{{- if .ErdSvg}}
+<a href="` + ErdSvgFilename + `"><img class="erd" src="` + ErdSvgFilename + `" alt="ERD"></a>
+{{- else}}
+<p>Graphviz is not available. ERD source: <a href="` + ErdDotFilename + `">` + ErdDotFilename + `</a></p>
+{{- end}}
+<script>
+document.getElementById("search").addEventListener("input", function (e) {
+  var terms = e.target.value.toLowerCase().split(/\s+/).filter(Boolean);
+  document.querySelectorAll("section.group").forEach(function (section) {
+    var visible = 0;
+    section.querySelectorAll("tr[data-search]").forEach(function (row) {
+      var text = row.getAttribute("data-search");
+      var matched = terms.every(function (term) { return text.indexOf(term) >= 0; });
+      row.style.display = matched ? "" : "none";
+      if (matched) {
+        visible++;
+      }
+    });
+    section.style.display = visible ? "" : "none";
+  });
+});
+</script>{{end}}`
+
+	// TableTemplate is the template of table detail page.
+	TableTemplate = `{{define "content"}}{{with .Table}}<h1>{{.Table.Name}}</h1>
+{{- with .Table.Group}}
+<p class="group">Group: {{.}}</p>
+{{- end}}
+{{- with .Table.Description}}
+<p>{{.}}</p>
+{{- end}}
+<h2>Columns</h2>
+<table>
+<thead><tr><th>Name</th><th>Type</th><th>Keys</th><th>Not Null</th><th>Default</th><th>Reference</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Columns}}
+<tr id="col-{{.Column.Name}}"><td>{{.Column.Name}}</td><td>{{.Column.Format}}</td><td>{{.Keys}}</td><td>{{if .Column.NotNull}}Y{{end}}</td><td>{{.Column.DefaultValue}}</td><td>{{with .Ref}}{{template "link" .}}{{end}}</td><td>{{.Column.Description}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- if .Table.Indices}}
+<h2>Indices</h2>
+<table>
+<thead><tr><th>Name</th><th>Columns</th></tr></thead>
+<tbody>
+{{- range .Table.Indices}}
+<tr><td>{{.Name}}</td><td>{{join .Columns ", "}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .ReferencedBy}}
+<h2>Referenced by</h2>
+<ul>
+{{- range .ReferencedBy}}
+<li>{{template "link" .}} &rarr; {{.Target}}{{if .Relationship}} ({{.Relationship}}){{end}}</li>
+{{- end}}
+</ul>
+{{- end}}{{end}}{{end}}`
+
+	// ChangesTemplate is the template of schema changes page.
+	ChangesTemplate = `{{define "content"}}<h1>Changes</h1>
+{{- range .Changes}}
+<section class="changes">
+<h2>{{.From}} &rarr; {{.To}}</h2>
+{{- range .Tables}}
+<h3>{{if .Href}}<a href="{{.Href}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</h3>
+<ul>
+{{- range .Lines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>No changes.</p>
+{{- end}}
+</section>
+{{- end}}{{end}}`
+
+	// Style is the stylesheet shared by all pages.
+	Style = `body {
+  margin: 0;
+  font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+  color: #24292e;
+}
+nav {
+  padding: 12px 24px;
+  background: #24292e;
+}
+nav a {
+  color: #fff;
+  font-weight: bold;
+}
+main {
+  padding: 0 24px 24px;
+}
+a {
+  color: #0366d6;
+  text-decoration: none;
+}
+a:hover {
+  text-decoration: underline;
+}
+table {
+  border-collapse: collapse;
+  margin-bottom: 16px;
+}
+th, td {
+  padding: 6px 12px;
+  border: 1px solid #dfe2e5;
+  text-align: left;
+  vertical-align: top;
+}
+th {
+  background: #f6f8fa;
+}
+tr:target {
+  background: #fffbdd;
+}
+code {
+  padding: 2px 4px;
+  background: #f6f8fa;
+  border-radius: 3px;
+}
+#search {
+  width: 100%;
+  max-width: 480px;
+  padding: 6px 8px;
+  font-size: 14px;
+}
+img.erd {
+  max-width: 100%;
+}
+`
+)
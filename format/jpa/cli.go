@@ -12,12 +12,15 @@ const (
 	FlagGroups           = "groups"
 	FlagIdEntity         = "idEntity"
 	FlagInput            = "input"
+	FlagLombok           = "lombok"
 	FlagOutput           = "output"
 	FlagPackage          = "package"
 	FlagPrefix           = "prefix"
 	FlagRelation         = "relation"
 	FlagRemovePrefix     = "removePrefix"
 	FlagReposPackage     = "reposPackage"
+	FlagReposTemplate    = "reposTemplate"
+	FlagTemplate         = "template"
 	FlagUniqueNameSuffix = "uniqueNameSuffix"
 	FlagUseUTC           = "useUTC"
 )
@@ -37,6 +40,8 @@ func KotlinAction(c *cli.Context) error {
 		Relation:         c.String(FlagRelation),
 		RemovePrefixes:   strings.Split(c.String(FlagRemovePrefix), ","),
 		ReposPackage:     c.String(FlagReposPackage),
+		ReposTemplate:    c.String(FlagReposTemplate),
+		Template:         c.String(FlagTemplate),
 		UniqueNameSuffix: c.String(FlagUniqueNameSuffix),
 	})
 	return gen.Generate(c.String(FlagOutput))
@@ -105,6 +110,135 @@ var KotlinCliFlags = []cli.Flag{
 		Usage:   "Repository class `PACKAGE` name. Generated if not empty.",
 		EnvVars: []string{"OCTOPUS_REPOS_PACKAGE"},
 	},
+	&cli.StringFlag{
+		Name:    FlagReposTemplate,
+		Usage:   "Custom repository template `FILE`.",
+		EnvVars: []string{"OCTOPUS_REPOS_TEMPLATE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagTemplate,
+		Aliases: []string{"t"},
+		Usage:   "Custom entity class template `FILE`.",
+		EnvVars: []string{"OCTOPUS_TEMPLATE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagUniqueNameSuffix,
+		Aliases: []string{"q"},
+		Usage:   "Unique constraint name `SUFFIX`.",
+		EnvVars: []string{"OCTOPUS_UNIQUE_NAME_SUFFIX"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagUseUTC,
+		Aliases: []string{"u"},
+		Usage:   "Set to use UTC for audit columns ('created_at', 'updated_at').",
+		EnvVars: []string{"OCTOPUS_USE_UTC"},
+	},
+}
+
+func JavaAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	gen := NewJavaGenerator(schema, &JavaOption{
+		AnnoMapper:       common.NewAnnotationMapper(c.String(FlagAnnotation)),
+		PrefixMapper:     common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:      octopus.GetTableFilterFn(c.String(FlagGroups)),
+		IdEntity:         c.String(FlagIdEntity),
+		Lombok:           c.Bool(FlagLombok),
+		Package:          c.String(FlagPackage),
+		Relation:         c.String(FlagRelation),
+		RemovePrefixes:   strings.Split(c.String(FlagRemovePrefix), ","),
+		ReposPackage:     c.String(FlagReposPackage),
+		ReposTemplate:    c.String(FlagReposTemplate),
+		Template:         c.String(FlagTemplate),
+		UniqueNameSuffix: c.String(FlagUniqueNameSuffix),
+		UseUTC:           c.Bool(FlagUseUTC),
+	})
+	return gen.Generate(c.String(FlagOutput))
+}
+
+var JavaCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate java files to `DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagAnnotation,
+		Aliases: []string{"a"},
+		Usage:   "Custom Entity class annotation. `FORMAT`: '{group1}:{annotations1}[,{group2}:{annotations2}]'",
+		EnvVars: []string{"OCTOPUS_ANNOTATION"},
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "Filter table groups to generate. `GROUPS` are separated by comma",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagIdEntity,
+		Aliases: []string{"e"},
+		Usage:   "Interface `NAME` with 'id' field",
+		EnvVars: []string{"OCTOPUS_ID_ENTITY"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagLombok,
+		Aliases: []string{"b"},
+		Usage:   "Set to use lombok annotations instead of getters and setters.",
+		EnvVars: []string{"OCTOPUS_LOMBOK"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPackage,
+		Aliases: []string{"p"},
+		Usage:   "Entity class `PACKAGE` name",
+		EnvVars: []string{"OCTOPUS_PACKAGE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"f"},
+		Usage:   "Class name prefix. `FORMAT`: '{group1}:{prefix1}[,{group2}:{prefix2}]'",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRelation,
+		Aliases: []string{"l"},
+		Usage:   "Relation `TYPE`. Available values: JPA, VRelation",
+		EnvVars: []string{"OCTOPUS_RELATION"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"d"},
+		Usage:   "Table `PREFIXES` to remove from class name. Multiple prefixes are separated by comma",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagReposPackage,
+		Aliases: []string{"r"},
+		Usage:   "Repository class `PACKAGE` name. Generated if not empty.",
+		EnvVars: []string{"OCTOPUS_REPOS_PACKAGE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagReposTemplate,
+		Usage:   "Custom repository template `FILE`.",
+		EnvVars: []string{"OCTOPUS_REPOS_TEMPLATE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagTemplate,
+		Aliases: []string{"t"},
+		Usage:   "Custom entity class template `FILE`.",
+		EnvVars: []string{"OCTOPUS_TEMPLATE"},
+	},
 	&cli.StringFlag{
 		Name:    FlagUniqueNameSuffix,
 		Aliases: []string{"q"},
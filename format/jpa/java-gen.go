@@ -0,0 +1,554 @@
+package jpa
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gertd/go-pluralize"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"text/template"
+)
+
+const (
+	// JavaEntityTemplate is the default template to generate java entity class.
+	// Template is executed with JavaTplData.
+	JavaEntityTemplate = `{{"" -}}
+{{if .Package}}package {{.Package}};
+{{end -}}
+{{if .Imports}}
+{{range .Imports}}import {{.}};
+{{end}}{{end}}
+{{- if .JavaImports}}
+{{range .JavaImports}}import {{.}};
+{{end}}{{end}}
+{{range .Annotations}}{{.}}
+{{end -}}
+{{if .Lombok}}@Getter
+@Setter
+@NoArgsConstructor
+{{end -}}
+@Entity
+{{- if .HasUniqueFields}}
+@Table(name = "{{.Table.Name}}", uniqueConstraints = {
+    @UniqueConstraint(name = "{{.UniqueConstraintName}}", columnNames = { {{- join .UniqueColumnNames ", " -}} })
+})
+{{- else}}
+@Table(name = "{{.Table.Name}}")
+{{- end}}
+{{- if ne .IdClassName ""}}
+@IdClass({{.Class.Name}}.{{.IdClassName}}.class)
+{{- end}}
+public class {{.Class.Name}}{{if ne .SuperClass ""}} implements {{.SuperClass}}{{end}} {
+{{- range $i, $field := .Class.Fields}}
+{{if $i}}
+{{end}}
+{{- range fieldAnnotations $field}}    {{.}}
+{{end}}    private {{$field.Type}} {{$field.Name}};
+{{- end}}
+{{- range .Class.Relations}}
+
+{{range .Annotations}}    {{.}}
+{{end}}    private {{.Type}} {{.Name}}{{if .DefaultValue}} = {{.DefaultValue}}{{end}};
+{{- end}}
+{{- if not .Lombok}}
+{{- range .Class.Fields}}
+
+    {{if eq . $.IdEntityField}}@Override
+    {{end}}public {{.Type}} get{{upperFirst .Name}}() {
+        return {{.Name}};
+    }
+
+    public void set{{upperFirst .Name}}({{.Type}} {{.Name}}) {
+        this.{{.Name}} = {{.Name}};
+    }
+{{- end}}
+{{- range .Class.Relations}}
+
+    public {{.Type}} get{{upperFirst .Name}}() {
+        return {{.Name}};
+    }
+
+    public void set{{upperFirst .Name}}({{.Type}} {{.Name}}) {
+        this.{{.Name}} = {{.Name}};
+    }
+{{- end}}
+{{- end}}
+{{- if ne .IdClassName ""}}
+
+{{if .Lombok}}    @Data
+{{end}}    public static class {{.IdClassName}} implements Serializable {
+{{- range .Class.PKFields}}
+        private {{.Type}} {{.Name}};
+{{- end}}
+{{- if not .Lombok}}
+{{- range .Class.PKFields}}
+
+        public {{.Type}} get{{upperFirst .Name}}() {
+            return {{.Name}};
+        }
+
+        public void set{{upperFirst .Name}}({{.Type}} {{.Name}}) {
+            this.{{.Name}} = {{.Name}};
+        }
+{{- end}}
+
+        @Override
+        public boolean equals(Object o) {
+            if (this == o) return true;
+            if (o == null || getClass() != o.getClass()) return false;
+            {{.IdClassName}} that = ({{.IdClassName}}) o;
+            return {{pkEquals .Class.PKFields}};
+        }
+
+        @Override
+        public int hashCode() {
+            return Objects.hash({{pkNames .Class.PKFields}});
+        }
+{{- end}}
+    }
+{{- end}}
+}
+`
+
+	// JavaRepositoryTemplate is the default template to generate java repository interface.
+	// Template is executed with JavaReposTplData.
+	JavaRepositoryTemplate = `{{"" -}}
+{{if .ReposPackage}}package {{.ReposPackage}};
+
+{{end -}}
+{{range .Imports}}import {{.}};
+{{end -}}
+import org.springframework.data.jpa.repository.JpaRepository;
+import org.springframework.stereotype.Repository;
+
+@Repository
+public interface {{.ClassName}}Repository extends JpaRepository<{{.ClassName}}, {{.IdClassName}}> {
+}
+`
+)
+
+type JavaOption struct {
+	AnnoMapper       *common.AnnotationMapper
+	PrefixMapper     *common.PrefixMapper
+	TableFilter      octopus.TableFilterFn
+	IdEntity         string
+	Lombok           bool
+	Package          string
+	Relation         string
+	RemovePrefixes   []string
+	ReposPackage     string
+	ReposTemplate    string
+	Template         string
+	UniqueNameSuffix string
+	UseUTC           bool
+}
+
+type JavaGenerator struct {
+	schema    *octopus.Schema
+	classes   []*JavaClass
+	option    *JavaOption
+	entityTpl *template.Template
+	reposTpl  *template.Template
+}
+
+func NewJavaGenerator(
+	schema *octopus.Schema,
+	option *JavaOption,
+) *JavaGenerator {
+	// populate JavaClass
+	var classes []*JavaClass
+	for _, table := range schema.Tables {
+		if option.TableFilter != nil && !option.TableFilter(table) {
+			continue
+		}
+		classes = append(classes, NewJavaClass(table, option))
+	}
+
+	gen := &JavaGenerator{
+		schema:  schema,
+		classes: classes,
+		option:  option,
+	}
+	if option.Relation == "JPA" {
+		gen.populateRelations()
+	}
+	return gen
+}
+
+// populateRelations adds association fields to classes.
+// references to tables not generated are skipped.
+func (c *JavaGenerator) populateRelations() {
+	client := pluralize.NewClient()
+
+	for _, class := range c.classes {
+		for _, field := range class.Fields {
+			column := field.Column
+			ref := column.Ref
+			if ref == nil {
+				continue
+			}
+			target := c.getClassByTableName(ref.Table)
+			if target == nil {
+				continue
+			}
+
+			switch ref.Relationship {
+			case octopus.RefOneToMany:
+				// foreign key column is in the target table
+				class.Relations = append(class.Relations, &JavaRelation{
+					Name: class.memberName(client.Plural(strcase.ToLowerCamel(target.Name))),
+					Type: fmt.Sprintf("List<%s>", target.Name),
+					Annotations: []string{
+						"@OneToMany",
+						joinColumnAnnotation(ref.Column, column.Name),
+					},
+					DefaultValue: "new ArrayList<>()",
+					Imports:      []string{"java.util.ArrayList", "java.util.List"},
+				})
+			case octopus.RefOneToOne:
+				name := class.memberName(ownerRelationName(field, target))
+				class.Relations = append(class.Relations, &JavaRelation{
+					Name: name,
+					Type: target.Name,
+					Annotations: []string{
+						"@OneToOne(fetch = FetchType.LAZY)",
+						joinColumnAnnotation(column.Name, ref.Column),
+					},
+				})
+				target.Relations = append(target.Relations, &JavaRelation{
+					Name:        target.memberName(strcase.ToLowerCamel(class.Name)),
+					Type:        class.Name,
+					Annotations: []string{fmt.Sprintf("@OneToOne(mappedBy = \"%s\")", name)},
+				})
+			default:
+				name := class.memberName(ownerRelationName(field, target))
+				class.Relations = append(class.Relations, &JavaRelation{
+					Name: name,
+					Type: target.Name,
+					Annotations: []string{
+						"@ManyToOne(fetch = FetchType.LAZY)",
+						joinColumnAnnotation(column.Name, ref.Column),
+					},
+				})
+				target.Relations = append(target.Relations, &JavaRelation{
+					Name:         target.memberName(client.Plural(strcase.ToLowerCamel(class.Name))),
+					Type:         fmt.Sprintf("List<%s>", class.Name),
+					Annotations:  []string{fmt.Sprintf("@OneToMany(mappedBy = \"%s\")", name)},
+					DefaultValue: "new ArrayList<>()",
+					Imports:      []string{"java.util.ArrayList", "java.util.List"},
+				})
+			}
+		}
+	}
+}
+
+// ownerRelationName returns association field name of the owning side.
+// 'user_id' column is mapped to 'user'.
+func ownerRelationName(field *JavaField, target *JavaClass) string {
+	name := strings.TrimSuffix(field.Column.Name, "_id")
+	if name == field.Column.Name {
+		return strcase.ToLowerCamel(target.Name)
+	}
+	return strcase.ToLowerCamel(name)
+}
+
+// joinColumnAnnotation returns read-only '@JoinColumn' annotation.
+// the foreign key column is already mapped as a basic field.
+func joinColumnAnnotation(name string, referencedColumnName string) string {
+	return fmt.Sprintf(
+		"@JoinColumn(name = \"%s\", referencedColumnName = \"%s\", insertable = false, updatable = false)",
+		name, referencedColumnName)
+}
+
+func (c *JavaGenerator) getFieldAnnotations(class *JavaClass, field *JavaField) []string {
+	column := field.Column
+
+	// @VRelation
+	var vRelationClass string
+	if c.option.Relation == "VRelation" {
+		if ref := column.Ref; ref != nil {
+			if target := c.getClassByTableName(ref.Table); target != nil {
+				vRelationClass = target.Name
+			} else {
+				log.Fatalf("Relation not found. %s::%s -> %s", class.Name, field.Name, ref.Table)
+			}
+		}
+	}
+
+	return jpaFieldAnnotations(column, field.Name, field.OverrideName, vRelationClass)
+}
+
+func (c *JavaGenerator) getClassByTableName(tableName string) *JavaClass {
+	for _, cls := range c.classes {
+		if cls.table.Name == tableName {
+			return cls
+		}
+	}
+	return nil
+}
+
+// JavaTplData is the data passed to the java entity class template.
+type JavaTplData struct {
+	Package              string
+	Class                *JavaClass
+	SuperClass           string
+	Annotations          []string
+	Table                *octopus.Table
+	IdEntityField        *JavaField
+	IdClassName          string
+	UniqueConstraintName string
+	UniqueColumnNames    []string
+	HasUniqueFields      bool
+	Lombok               bool
+	Imports              []string
+	JavaImports          []string
+}
+
+// GenerateEntityClass generates java entity class
+func (c *JavaGenerator) GenerateEntityClass(
+	wr io.Writer,
+	class *JavaClass,
+) error {
+	// custom functions
+	funcMap := template.FuncMap{
+		"join": strings.Join,
+		"fieldAnnotations": func(field *JavaField) []string {
+			return c.getFieldAnnotations(class, field)
+		},
+		"upperFirst": func(s string) string {
+			if s == "" {
+				return s
+			}
+			return strings.ToUpper(s[:1]) + s[1:]
+		},
+		"pkEquals": func(fields []*JavaField) string {
+			var exprs []string
+			for _, field := range fields {
+				exprs = append(exprs, fmt.Sprintf("Objects.equals(%s, that.%s)", field.Name, field.Name))
+			}
+			return strings.Join(exprs, " && ")
+		},
+		"pkNames": func(fields []*JavaField) string {
+			var names []string
+			for _, field := range fields {
+				names = append(names, field.Name)
+			}
+			return strings.Join(names, ", ")
+		},
+	}
+
+	tpl, err := loadTemplate(&c.entityTpl, "jpaJavaEntity", c.option.Template, JavaEntityTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+
+	// PK
+	var idClassName string
+	pkFieldCount := len(class.PKFields)
+	if pkFieldCount > 1 {
+		idClassName = class.Name + "PK"
+	}
+
+	// idEntity field
+	var idEntityField *JavaField
+	idEntityInterfaceName := c.option.IdEntity
+	if idEntityInterfaceName != "" && pkFieldCount == 1 && class.PKFields[0].Name == "id" {
+		idEntityField = class.PKFields[0]
+	}
+
+	// super class
+	superClass := ""
+	if idEntityField != nil {
+		superClass = fmt.Sprintf("%s<%s>", idEntityInterfaceName, idEntityField.Type)
+	}
+
+	// unique constraint
+	var uniqueColumnNames []string
+	for _, field := range class.UniqueFields {
+		uniqueColumnNames = append(uniqueColumnNames, util.Quote(field.Column.Name, "\""))
+	}
+
+	// imports
+	importSet := util.NewStringSet()
+	javaImportSet := util.NewStringSet()
+	javaImportSet.Add("javax.persistence.*")
+	if pkFieldCount > 1 {
+		javaImportSet.Add("java.io.Serializable")
+		if c.option.Lombok {
+			importSet.Add("lombok.Data")
+		} else {
+			javaImportSet.Add("java.util.Objects")
+		}
+	}
+	if c.option.Lombok {
+		importSet.Add("lombok.Getter")
+		importSet.Add("lombok.NoArgsConstructor")
+		importSet.Add("lombok.Setter")
+	}
+
+	addImport := func(imp string) {
+		if strings.HasPrefix(imp, "java.") {
+			javaImportSet.Add(imp)
+		} else {
+			importSet.Add(imp)
+		}
+	}
+	for _, field := range class.Fields {
+		column := field.Column
+		for _, imp := range field.Imports {
+			addImport(imp)
+		}
+
+		// @CreationTimestamp
+		if column.Type == octopus.ColTypeDateTime && field.Name == "createdAt" {
+			importSet.Add("org.hibernate.annotations.CreationTimestamp")
+		}
+		// @UpdateTimestamp
+		if column.Type == octopus.ColTypeDateTime && field.Name == "updatedAt" {
+			importSet.Add("org.hibernate.annotations.UpdateTimestamp")
+		}
+	}
+	for _, relation := range class.Relations {
+		for _, imp := range relation.Imports {
+			addImport(imp)
+		}
+	}
+
+	// annotations
+	var annotations []string
+	for _, annotation := range c.option.AnnoMapper.GetAnnotations(class.table.Group) {
+		if annotation != "" {
+			annotations = append(annotations, annotation)
+		}
+	}
+
+	// populate template data
+	data := JavaTplData{
+		Package:              c.option.Package,
+		Class:                class,
+		SuperClass:           superClass,
+		Annotations:          annotations,
+		Table:                class.table,
+		IdEntityField:        idEntityField,
+		IdClassName:          idClassName,
+		UniqueConstraintName: class.table.Name + c.option.UniqueNameSuffix,
+		UniqueColumnNames:    uniqueColumnNames,
+		HasUniqueFields:      len(uniqueColumnNames) > 0,
+		Lombok:               c.option.Lombok,
+		Imports:              importSet.Slice(),
+		JavaImports:          javaImportSet.Slice(),
+	}
+
+	return tpl.Execute(wr, &data)
+}
+
+// JavaReposTplData is the data passed to the java repository template.
+type JavaReposTplData struct {
+	EntityPackage string
+	ReposPackage  string
+	ClassName     string
+	IdClassName   string
+	Imports       []string
+}
+
+func (c *JavaGenerator) Generate(outputPath string) error {
+	if err := c.generateEntities(outputPath); err != nil {
+		return err
+	}
+
+	if c.option.ReposPackage != "" {
+		if err := c.generateRepositories(outputPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *JavaGenerator) generateEntities(outputPath string) error {
+	entityDir, err := util.MkdirPackage(outputPath, c.option.Package)
+	if err != nil {
+		return err
+	}
+
+	for _, class := range c.classes {
+		buf := new(bytes.Buffer)
+		if err := c.GenerateEntityClass(buf, class); err != nil {
+			return err
+		}
+		filename := path.Join(entityDir, fmt.Sprintf("%s.java", class.Name))
+		if err := util.WriteStringToFile(filename, buf.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *JavaGenerator) generateRepositories(outputPath string) error {
+	reposDir, err := util.MkdirPackage(outputPath, c.option.ReposPackage)
+	if err != nil {
+		return err
+	}
+
+	for _, class := range c.classes {
+		if len(class.PKFields) == 0 {
+			log.Printf("Repository skipped. %s has no primary key", class.Name)
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if err := c.GenerateRepository(buf, class); err != nil {
+			return err
+		}
+		reposFilename := path.Join(reposDir, fmt.Sprintf("%sRepository.java", class.Name))
+		if err := util.WriteStringToFile(reposFilename, buf.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateRepository generates spring data repository interface
+func (c *JavaGenerator) GenerateRepository(
+	wr io.Writer,
+	class *JavaClass,
+) error {
+	tmpl, err := loadTemplate(&c.reposTpl, "jpaJavaRepository", c.option.ReposTemplate, JavaRepositoryTemplate, template.FuncMap{})
+	if err != nil {
+		return err
+	}
+
+	importSet := util.NewStringSet()
+	// classes in the default package cannot be imported
+	if c.option.Package != "" {
+		importSet.Add(c.option.Package + "." + class.Name)
+	}
+
+	var idClassName string
+	if len(class.PKFields) > 1 {
+		idClassName = class.Name + "." + class.Name + "PK"
+	} else {
+		pkField := class.PKFields[0]
+		idClassName = pkField.Type
+		for _, imp := range pkField.Imports {
+			importSet.Add(imp)
+		}
+	}
+
+	data := JavaReposTplData{
+		EntityPackage: c.option.Package,
+		ReposPackage:  c.option.ReposPackage,
+		ClassName:     class.Name,
+		IdClassName:   idClassName,
+		Imports:       importSet.Slice(),
+	}
+	return tmpl.Execute(wr, &data)
+}
@@ -0,0 +1,446 @@
+package jpa
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+var jpaJavaTestSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name: "user",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+					NotNull:         true,
+				},
+				{
+					Name:      "name",
+					Type:      octopus.ColTypeVarchar,
+					Size:      100,
+					UniqueKey: true,
+					NotNull:   true,
+				},
+				{
+					Name:    "created_at",
+					Type:    octopus.ColTypeDateTime,
+					NotNull: true,
+				},
+			},
+			Group: "common",
+		},
+		{
+			Name: "post",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+					NotNull:         true,
+				},
+				{
+					Name:    "user_id",
+					Type:    octopus.ColTypeInt64,
+					NotNull: true,
+					Ref: &octopus.Reference{
+						Table:        "user",
+						Column:       "id",
+						Relationship: octopus.RefManyToOne,
+					},
+				},
+				{
+					Name: "title",
+					Type: octopus.ColTypeVarchar,
+					Size: 200,
+				},
+			},
+			Group: "common",
+		},
+		{
+			Name: "post_tag",
+			Columns: []*octopus.Column{
+				{
+					Name:       "post_id",
+					Type:       octopus.ColTypeInt64,
+					PrimaryKey: true,
+					NotNull:    true,
+				},
+				{
+					Name:       "tag",
+					Type:       octopus.ColTypeVarchar,
+					Size:       20,
+					PrimaryKey: true,
+					NotNull:    true,
+				},
+			},
+		},
+	},
+}
+
+// relations without lombok
+func TestJPAJava_GenerateEntityClass(t *testing.T) {
+	Convey("GenerateEntityClass", t, func() {
+		option := &JavaOption{
+			PrefixMapper:     common.NewPrefixMapper(""),
+			AnnoMapper:       common.NewAnnotationMapper("common:@Common"),
+			IdEntity:         "IdEntity",
+			Package:          "com.lechuck",
+			Relation:         "JPA",
+			UniqueNameSuffix: "_uq",
+			UseUTC:           true,
+		}
+		expected := `package com.lechuck;
+
+import org.hibernate.annotations.CreationTimestamp;
+
+import java.time.Instant;
+import java.util.ArrayList;
+import java.util.List;
+import javax.persistence.*;
+
+@Common
+@Entity
+@Table(name = "user", uniqueConstraints = {
+    @UniqueConstraint(name = "user_uq", columnNames = {"name"})
+})
+public class User implements IdEntity<Long> {
+    @Id
+    @GeneratedValue(strategy = GenerationType.AUTO)
+    @Column(nullable = false)
+    private Long id;
+
+    @Column(nullable = false, length = 100)
+    private String name;
+
+    @CreationTimestamp
+    @Column(nullable = false, updatable = false)
+    private Instant createdAt;
+
+    @OneToMany(mappedBy = "user")
+    private List<Post> posts = new ArrayList<>();
+
+    @Override
+    public Long getId() {
+        return id;
+    }
+
+    public void setId(Long id) {
+        this.id = id;
+    }
+
+    public String getName() {
+        return name;
+    }
+
+    public void setName(String name) {
+        this.name = name;
+    }
+
+    public Instant getCreatedAt() {
+        return createdAt;
+    }
+
+    public void setCreatedAt(Instant createdAt) {
+        this.createdAt = createdAt;
+    }
+
+    public List<Post> getPosts() {
+        return posts;
+    }
+
+    public void setPosts(List<Post> posts) {
+        this.posts = posts;
+    }
+}
+`
+
+		gen := NewJavaGenerator(jpaJavaTestSchema, option)
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateEntityClass(buf, gen.classes[0]); err != nil {
+			t.Error(err)
+		}
+		So(buf.String(), ShouldResemble, expected)
+	})
+}
+
+// lombok, composite primary key and repository
+func TestJPAJava_Lombok(t *testing.T) {
+	Convey("Lombok", t, func() {
+		option := &JavaOption{
+			PrefixMapper: common.NewPrefixMapper(""),
+			AnnoMapper:   common.NewAnnotationMapper(""),
+			Lombok:       true,
+			Package:      "com.lechuck",
+			Relation:     "JPA",
+			ReposPackage: "com.lechuck.repos",
+		}
+		expectedEntities := []string{
+			`package com.lechuck;
+
+import lombok.Getter;
+import lombok.NoArgsConstructor;
+import lombok.Setter;
+
+import javax.persistence.*;
+
+@Getter
+@Setter
+@NoArgsConstructor
+@Entity
+@Table(name = "post")
+public class Post {
+    @Id
+    @GeneratedValue(strategy = GenerationType.AUTO)
+    @Column(nullable = false)
+    private Long id;
+
+    @Column(nullable = false)
+    private Long userId;
+
+    @Column(length = 200)
+    private String title;
+
+    @ManyToOne(fetch = FetchType.LAZY)
+    @JoinColumn(name = "user_id", referencedColumnName = "id", insertable = false, updatable = false)
+    private User user;
+}
+`,
+			`package com.lechuck;
+
+import lombok.Data;
+import lombok.Getter;
+import lombok.NoArgsConstructor;
+import lombok.Setter;
+
+import java.io.Serializable;
+import javax.persistence.*;
+
+@Getter
+@Setter
+@NoArgsConstructor
+@Entity
+@Table(name = "post_tag")
+@IdClass(PostTag.PostTagPK.class)
+public class PostTag {
+    @Id
+    @Column(nullable = false)
+    private Long postId;
+
+    @Id
+    @Column(nullable = false, length = 20)
+    private String tag;
+
+    @Data
+    public static class PostTagPK implements Serializable {
+        private Long postId;
+        private String tag;
+    }
+}
+`,
+		}
+		expectedRepositories := []string{
+			`package com.lechuck.repos;
+
+import com.lechuck.Post;
+import org.springframework.data.jpa.repository.JpaRepository;
+import org.springframework.stereotype.Repository;
+
+@Repository
+public interface PostRepository extends JpaRepository<Post, Long> {
+}
+`,
+			`package com.lechuck.repos;
+
+import com.lechuck.PostTag;
+import org.springframework.data.jpa.repository.JpaRepository;
+import org.springframework.stereotype.Repository;
+
+@Repository
+public interface PostTagRepository extends JpaRepository<PostTag, PostTag.PostTagPK> {
+}
+`,
+		}
+
+		gen := NewJavaGenerator(jpaJavaTestSchema, option)
+
+		for i, class := range gen.classes[1:] {
+			buf := new(bytes.Buffer)
+			if err := gen.GenerateEntityClass(buf, class); err != nil {
+				t.Error(err)
+			}
+			So(buf.String(), ShouldResemble, expectedEntities[i])
+
+			buf = new(bytes.Buffer)
+			if err := gen.GenerateRepository(buf, class); err != nil {
+				t.Error(err)
+			}
+			So(buf.String(), ShouldResemble, expectedRepositories[i])
+		}
+	})
+}
+
+// reserved words, enum/set/json columns and default package
+func TestJPAJava_ReservedWords(t *testing.T) {
+	Convey("Reserved words", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "lesson",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+						{Name: "class", Type: octopus.ColTypeVarchar, Size: 10, NotNull: true},
+						{Name: "level", Type: octopus.ColTypeEnum, Values: []string{"low", "high"}},
+						{Name: "days", Type: octopus.ColTypeSet, Values: []string{"mon", "tue"}},
+						{Name: "extra", Type: octopus.ColTypeJSON},
+					},
+				},
+			},
+		}
+		option := &JavaOption{
+			PrefixMapper: common.NewPrefixMapper(""),
+			AnnoMapper:   common.NewAnnotationMapper(""),
+			Lombok:       true,
+		}
+		expectedEntity := `
+import lombok.Getter;
+import lombok.NoArgsConstructor;
+import lombok.Setter;
+
+import javax.persistence.*;
+
+@Getter
+@Setter
+@NoArgsConstructor
+@Entity
+@Table(name = "lesson")
+public class Lesson {
+    @Id
+    @Column(nullable = false)
+    private Long id;
+
+    @Column(name = "class", nullable = false, length = 10)
+    private String class_;
+
+    private String level;
+
+    private String days;
+
+    private String extra;
+}
+`
+		expectedRepository := `import org.springframework.data.jpa.repository.JpaRepository;
+import org.springframework.stereotype.Repository;
+
+@Repository
+public interface LessonRepository extends JpaRepository<Lesson, Long> {
+}
+`
+
+		gen := NewJavaGenerator(schema, option)
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateEntityClass(buf, gen.classes[0]); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expectedEntity, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldResemble, expectedEntity)
+
+		buf = new(bytes.Buffer)
+		if err := gen.GenerateRepository(buf, gen.classes[0]); err != nil {
+			t.Error(err)
+		}
+		So(buf.String(), ShouldResemble, expectedRepository)
+	})
+}
+
+// binary, bit, year and spatial columns
+func TestJPAJava_BinaryTypes(t *testing.T) {
+	Convey("Binary types", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "place",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+						{Name: "hash", Type: octopus.ColTypeBinary, Size: 16, NotNull: true},
+						{Name: "token", Type: octopus.ColTypeVarbinary, Size: 255},
+						{Name: "photo", Type: octopus.ColTypeBlob32},
+						{Name: "enabled", Type: octopus.ColTypeBit, Size: 1},
+						{Name: "flags", Type: octopus.ColTypeBit, Size: 8},
+						{Name: "built", Type: octopus.ColTypeYear},
+						{Name: "area", Type: octopus.ColTypeGeometry},
+						{Name: "location", Type: octopus.ColTypePoint},
+					},
+				},
+			},
+		}
+		option := &JavaOption{
+			PrefixMapper: common.NewPrefixMapper(""),
+			AnnoMapper:   common.NewAnnotationMapper(""),
+			Lombok:       true,
+		}
+		expectedEntity := `
+import lombok.Getter;
+import lombok.NoArgsConstructor;
+import lombok.Setter;
+import org.locationtech.jts.geom.Geometry;
+import org.locationtech.jts.geom.Point;
+
+import javax.persistence.*;
+
+@Getter
+@Setter
+@NoArgsConstructor
+@Entity
+@Table(name = "place")
+public class Place {
+    @Id
+    @Column(nullable = false)
+    private Long id;
+
+    @Column(nullable = false)
+    private byte[] hash;
+
+    private byte[] token;
+
+    @Lob
+    private byte[] photo;
+
+    private Boolean enabled;
+
+    private byte[] flags;
+
+    private Short built;
+
+    private Geometry area;
+
+    private Point location;
+}
+`
+
+		gen := NewJavaGenerator(schema, option)
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateEntityClass(buf, gen.classes[0]); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expectedEntity, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldResemble, expectedEntity)
+	})
+}
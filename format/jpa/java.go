@@ -0,0 +1,130 @@
+package jpa
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+)
+
+// JavaClass is an entity class generated from a table.
+type JavaClass struct {
+	table        *octopus.Table
+	Name         string
+	Fields       []*JavaField
+	PKFields     []*JavaField
+	UniqueFields []*JavaField
+	Relations    []*JavaRelation
+}
+
+// JavaField is an entity class field generated from a column.
+type JavaField struct {
+	Column       *octopus.Column
+	Name         string
+	OverrideName bool
+	Type         string
+	Imports      []string
+}
+
+// JavaRelation is an association field generated from a column reference.
+type JavaRelation struct {
+	Name         string
+	Type         string
+	Annotations  []string
+	DefaultValue string
+	Imports      []string
+}
+
+func NewJavaClass(
+	table *octopus.Table,
+	option *JavaOption,
+) *JavaClass {
+	className := entityClassName(table, option.RemovePrefixes, option.PrefixMapper)
+
+	var fields []*JavaField
+	var pkFields []*JavaField
+	var uniqueFields []*JavaField
+	for _, column := range table.Columns {
+		field := NewJavaField(column, option.UseUTC)
+		fields = append(fields, field)
+
+		if column.PrimaryKey {
+			pkFields = append(pkFields, field)
+		}
+		if column.UniqueKey {
+			uniqueFields = append(uniqueFields, field)
+		}
+	}
+
+	return &JavaClass{
+		table:        table,
+		Name:         className,
+		Fields:       fields,
+		PKFields:     pkFields,
+		UniqueFields: uniqueFields,
+	}
+}
+
+func NewJavaField(column *octopus.Column, useUTC bool) *JavaField {
+	var imports []string
+
+	jt := toJvmType(column, useUTC)
+	if jt.Import != "" {
+		imports = append(imports, jt.Import)
+	}
+
+	fieldName, ok := util.ToLowerCamel(column.Name)
+	if name := javaIdentifier(fieldName); name != fieldName {
+		// column name is set by '@Column(name = ...)'
+		fieldName, ok = name, false
+	}
+
+	return &JavaField{
+		Column:       column,
+		Name:         fieldName,
+		OverrideName: !ok,
+		Type:         jt.Java,
+		Imports:      imports,
+	}
+}
+
+// memberName returns name not used by any field or relation of the class.
+// number suffix is appended if name is already used.
+func (c *JavaClass) memberName(name string) string {
+	name = javaIdentifier(name)
+	result := name
+	for i := 2; c.hasMember(result); i++ {
+		result = fmt.Sprintf("%s%d", name, i)
+	}
+	return result
+}
+
+func (c *JavaClass) hasMember(name string) bool {
+	for _, field := range c.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	for _, relation := range c.Relations {
+		if relation.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var javaReservedWords = util.NewStringSet(
+	"abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class", "const",
+	"continue", "default", "do", "double", "else", "enum", "extends", "false", "final", "finally",
+	"float", "for", "goto", "if", "implements", "import", "instanceof", "int", "interface", "long",
+	"native", "new", "null", "package", "private", "protected", "public", "return", "short", "static",
+	"strictfp", "super", "switch", "synchronized", "this", "throw", "throws", "transient", "true", "try",
+	"void", "volatile", "while", "_",
+)
+
+// javaIdentifier returns name with '_' suffix if name is a java reserved word.
+func javaIdentifier(name string) string {
+	if javaReservedWords.Contains(name) {
+		return name + "_"
+	}
+	return name
+}
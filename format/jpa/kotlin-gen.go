@@ -3,7 +3,6 @@ package jpa
 import (
 	"bytes"
 	"fmt"
-	"github.com/iancoleman/strcase"
 	"github.com/lechuckroh/octopus-db-tools/format/common"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
@@ -14,6 +13,73 @@ import (
 	"text/template"
 )
 
+const (
+	// KotlinEntityTemplate is the default template to generate entity class.
+	// Template is executed with KotlinTplData.
+	KotlinEntityTemplate = `{{"" -}}
+package {{.Package}}
+
+{{range .Imports}}import {{.}}
+{{end}}
+{{range .JavaImports}}import {{.}}
+{{end}}
+{{range .Annotations}}{{.}}{{end}}
+@Entity
+{{- if .HasUniqueFields}}
+@Table(name="{{.Table.Name}}", uniqueConstraints = [
+    UniqueConstraint(name = "{{.UniqueConstraintName}}", columnNames = [{{join .UniqueFieldNames ", "}}])
+])
+{{- else}}
+@Table(name = "{{.Table.Name}}")
+{{- end}}
+{{- if ne .IdClassName ""}}
+@IdClass({{.IdClassName}}::class)
+{{- end}}
+data class {{.Class.Name}}(
+{{- range .Class.Fields}}
+    {{- $annotations := fieldAnnotations .}}
+    {{- range $annotations}}
+        {{. -}}
+    {{end}}
+    {{- if .Column.NotNull}}
+		{{- if eq . $.IdEntityField}}
+        override var {{.Name}}: {{.Type}} = {{.DefaultValue}},
+		{{- else}}
+        var {{.Name}}: {{.Type}} = {{.DefaultValue}}{{if hasNext . $.Class.Fields}},{{end}}
+		{{- end}}
+    {{- else}}
+        var {{.Name}}: {{.Type}}{{if hasNext . $.Class.Fields}},{{end}}
+    {{- end}}
+{{end}}
+{{- if eq .SuperClass ""}}
+)
+{{- else}}
+): {{.SuperClass}}
+{{- end}}
+
+{{- if ne .IdClassName ""}}
+data class {{.IdClassName}}(
+{{- range .Class.PKFields}}
+        var {{.Name}}: {{.Type}} = {{.DefaultValue}}{{if hasNext . $.Class.PKFields}},{{end}}
+{{- end}}
+): Serializable
+{{- end}}
+`
+
+	// KotlinRepositoryTemplate is the default template to generate repository interface.
+	// Template is executed with KotlinReposTplData.
+	KotlinRepositoryTemplate = `{{"" -}}
+package {{.ReposPackage}}
+
+import {{.EntityPackage}}.*
+import org.springframework.data.jpa.repository.JpaRepository
+import org.springframework.stereotype.Repository
+
+@Repository
+interface {{.ClassName}}Repository : JpaRepository<{{.ClassName}}, {{.IdClassName}}>
+`
+)
+
 type KtOption struct {
 	AnnoMapper       *common.AnnotationMapper
 	PrefixMapper     *common.PrefixMapper
@@ -23,13 +89,17 @@ type KtOption struct {
 	Relation         string
 	RemovePrefixes   []string
 	ReposPackage     string
+	ReposTemplate    string
+	Template         string
 	UniqueNameSuffix string
 }
 
 type KtGenerator struct {
-	schema  *octopus.Schema
-	classes []*KotlinClass
-	option  *KtOption
+	schema    *octopus.Schema
+	classes   []*KotlinClass
+	option    *KtOption
+	entityTpl *template.Template
+	reposTpl  *template.Template
 }
 
 func NewKtGenerator(
@@ -54,67 +124,23 @@ func NewKtGenerator(
 }
 
 func (c *KtGenerator) getFieldAnnotations(class *KotlinClass, field *KotlinField) []string {
-	var annotations []string
-
 	column := field.Column
-	var colAttrs []string
-	if column.PrimaryKey {
-		annotations = append(annotations, "@Id")
-	}
-	if column.AutoIncremental {
-		annotations = append(annotations, "@GeneratedValue(strategy = GenerationType.AUTO)")
-	}
-	if octopus.IsColTypeClob(column.Type) {
-		annotations = append(annotations, "@Lob")
-	}
 
 	// @VRelation
+	var vRelationClass string
 	if c.option.Relation == "VRelation" {
 		if ref := column.Ref; ref != nil {
-			targetClassName := c.getClassNameByTableName(ref.Table)
-			if len(targetClassName) == 0 {
+			vRelationClass = c.getClassNameByTableName(ref.Table)
+			if len(vRelationClass) == 0 {
 				log.Fatalf("Relation not found. %s::%s -> %s", class.Name, field.Name, ref.Table)
 			}
-
-			annotations = append(annotations,
-				fmt.Sprintf("@VRelation(cls = \"%s\", field = \"%s\")",
-					targetClassName,
-					strcase.ToLowerCamel(ref.Column)))
 		}
 	}
 
-	if field.OverrideName {
-		colAttrs = append(colAttrs, fmt.Sprintf("name = \"%s\"", column.Name))
-	}
-	if column.NotNull {
-		colAttrs = append(colAttrs, "nullable = false")
-	}
-	if octopus.IsColTypeString(column.Type) && column.Size > 0 {
-		colAttrs = append(colAttrs, fmt.Sprintf("length = %d", column.Size))
-	}
-	if octopus.IsColTypeDecimal(column.Type) {
-		if column.Size > 0 {
-			colAttrs = append(colAttrs, fmt.Sprintf("precision = %d", column.Size))
-		}
-		if column.Scale > 0 {
-			colAttrs = append(colAttrs, fmt.Sprintf("scale = %d", column.Scale))
-		}
-	}
-	// @CreationTimestamp
-	if column.Type == octopus.ColTypeDateTime && field.Name == "createdAt" {
-		annotations = append(annotations, "@CreationTimestamp")
-		colAttrs = append(colAttrs, "updatable = false")
-	}
-	// @UpdateTimestamp
-	if column.Type == octopus.ColTypeDateTime && field.Name == "updatedAt" {
-		annotations = append(annotations, "@UpdateTimestamp")
-	}
-	if len(colAttrs) > 0 {
-		annotations = append(annotations, fmt.Sprintf("@Column(%s)", strings.Join(colAttrs, ", ")))
-	}
-	return annotations
+	return jpaFieldAnnotations(column, field.Name, field.OverrideName, vRelationClass)
 }
 
+// KotlinTplData is the data passed to the entity class template.
 type KotlinTplData struct {
 	Package              string
 	Class                *KotlinClass
@@ -147,59 +173,7 @@ func (c *KtGenerator) GenerateEntityClass(
 		},
 	}
 
-	// template
-	tplText := `{{"" -}}
-package {{.Package}}
-
-{{range .Imports}}import {{.}}
-{{end}}
-{{range .JavaImports}}import {{.}}
-{{end}}
-{{range .Annotations}}{{.}}{{end}}
-@Entity
-{{- if .HasUniqueFields}}
-@Table(name="{{.Table.Name}}", uniqueConstraints = [
-    UniqueConstraint(name = "{{.UniqueConstraintName}}", columnNames = [{{join .UniqueFieldNames ", "}}])
-])
-{{- else}}
-@Table(name = "{{.Table.Name}}")
-{{- end}}
-{{- if ne .IdClassName ""}}
-@IdClass({{.IdClassName}}::class)
-{{- end}}
-data class {{.Class.Name}}(
-{{- range .Class.Fields}}
-    {{- $annotations := fieldAnnotations .}}
-    {{- range $annotations}}
-        {{. -}}
-    {{end}}
-    {{- if .Column.NotNull}}
-		{{- if eq . $.IdEntityField}}
-        override var {{.Name}}: {{.Type}} = {{.DefaultValue}},
-		{{- else}}
-        var {{.Name}}: {{.Type}} = {{.DefaultValue}}{{if hasNext . $.Class.Fields}},{{end}}
-		{{- end}}
-    {{- else}}
-        var {{.Name}}: {{.Type}}{{if hasNext . $.Class.Fields}},{{end}}
-    {{- end}}
-{{end}}
-{{- if eq .SuperClass ""}}
-)
-{{- else}}
-): {{.SuperClass}}
-{{- end}}
-
-{{- if ne .IdClassName ""}}
-data class {{.IdClassName}}(
-{{- range .Class.PKFields}}
-        var {{.Name}}: {{.Type}} = {{.DefaultValue}}{{if hasNext . $.Class.PKFields}},{{end}}
-{{- end}}
-): Serializable
-{{- end}}
-`
-
-	// parse template
-	tpl, err := util.NewTemplate("jpaKotlinEntity", tplText, funcMap)
+	tpl, err := loadTemplate(&c.entityTpl, "jpaKotlinEntity", c.option.Template, KotlinEntityTemplate, funcMap)
 	if err != nil {
 		return err
 	}
@@ -280,6 +254,14 @@ data class {{.IdClassName}}(
 	return tpl.Execute(wr, &data)
 }
 
+// KotlinReposTplData is the data passed to the repository template.
+type KotlinReposTplData struct {
+	EntityPackage string
+	ReposPackage  string
+	ClassName     string
+	IdClassName   string
+}
+
 func (c *KtGenerator) getClassNameByTableName(tableName string) string {
 	for _, cls := range c.classes {
 		if cls.table.Name == tableName {
@@ -371,19 +353,7 @@ func (c *KtGenerator) generateRepository(
 		},
 	}
 
-	tplString := `{{"" -}}
-package {{.ReposPackage}}
-
-import {{.EntityPackage}}.*
-import org.springframework.data.jpa.repository.JpaRepository
-import org.springframework.stereotype.Repository
-
-@Repository
-interface {{.ClassName}}Repository : JpaRepository<{{.ClassName}}, {{.IdClassName}}>
-`
-
-	// parse template
-	tmpl, err := template.New("jpaKotlinEntity").Funcs(funcMap).Parse(tplString)
+	tmpl, err := loadTemplate(&c.reposTpl, "jpaKotlinRepository", c.option.ReposTemplate, KotlinRepositoryTemplate, funcMap)
 	if err != nil {
 		return err
 	}
@@ -396,12 +366,7 @@ interface {{.ClassName}}Repository : JpaRepository<{{.ClassName}}, {{.IdClassNam
 		idClassName = class.PKFields[0].Type
 	}
 
-	data := struct {
-		EntityPackage string
-		ReposPackage  string
-		ClassName     string
-		IdClassName   string
-	}{
+	data := KotlinReposTplData{
 		EntityPackage: entityPackageName,
 		ReposPackage:  reposPackageName,
 		ClassName:     class.Name,
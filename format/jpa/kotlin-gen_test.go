@@ -2,9 +2,13 @@ package jpa
 
 import (
 	"bytes"
+	"github.com/google/go-cmp/cmp"
 	"github.com/lechuckroh/octopus-db-tools/format/common"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"log"
+	"os"
 	"testing"
 )
 
@@ -111,3 +115,101 @@ data class CUser(
 		}
 	})
 }
+
+func TestJPAKotlin_Template(t *testing.T) {
+	Convey("Custom template", t, func() {
+		tplFile, err := ioutil.TempFile("", "kotlin-*.tpl")
+		So(err, ShouldBeNil)
+		defer os.Remove(tplFile.Name())
+		reposTplFile, err := ioutil.TempFile("", "kotlin-repos-*.tpl")
+		So(err, ShouldBeNil)
+		defer os.Remove(reposTplFile.Name())
+
+		tplText := `{{"" -}}
+package {{.Package}}
+
+class {{.Class.Name}}(
+{{- range .Class.Fields}}
+    {{join (fieldAnnotations .) " "}} var {{.Name}}: {{.Type}}{{if hasNext . $.Class.Fields}},{{end}}
+{{- end}}
+)
+`
+		reposTplText := `{{"" -}}
+package {{.ReposPackage}}
+
+interface {{.ClassName}}Repository : Repository<{{.EntityPackage}}.{{.ClassName}}, {{.IdClassName}}>
+`
+		_, err = tplFile.WriteString(tplText)
+		So(err, ShouldBeNil)
+		So(tplFile.Close(), ShouldBeNil)
+		_, err = reposTplFile.WriteString(reposTplText)
+		So(err, ShouldBeNil)
+		So(reposTplFile.Close(), ShouldBeNil)
+
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+						{Name: "name", Type: octopus.ColTypeVarchar, Size: 100},
+					},
+				},
+				{
+					Name: "tag",
+					Columns: []*octopus.Column{
+						{Name: "code", Type: octopus.ColTypeVarchar, Size: 10, PrimaryKey: true, NotNull: true},
+					},
+				},
+			},
+		}
+		option := &KtOption{
+			PrefixMapper:  common.NewPrefixMapper(""),
+			AnnoMapper:    common.NewAnnotationMapper(""),
+			Package:       "com.lechuck",
+			ReposPackage:  "com.lechuck.repos",
+			ReposTemplate: reposTplFile.Name(),
+			Template:      tplFile.Name(),
+		}
+		expected := []string{
+			`package com.lechuck
+
+class User(
+    @Id @Column(nullable = false) var id: Long,
+    @Column(length = 100) var name: String?
+)
+`,
+			`package com.lechuck
+
+class Tag(
+    @Id @Column(nullable = false, length = 10) var code: String
+)
+`,
+		}
+		expectedRepos := []string{
+			`package com.lechuck.repos
+
+interface UserRepository : Repository<com.lechuck.User, Long>
+`,
+			`package com.lechuck.repos
+
+interface TagRepository : Repository<com.lechuck.Tag, String>
+`,
+		}
+
+		gen := NewKtGenerator(schema, option)
+		for i, class := range gen.classes {
+			buf := new(bytes.Buffer)
+			So(gen.GenerateEntityClass(buf, class), ShouldBeNil)
+			actual := buf.String()
+			if diff := cmp.Diff(expected[i], actual); diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected[i])
+
+			buf = new(bytes.Buffer)
+			So(gen.generateRepository(buf, class, option.Package, option.ReposPackage), ShouldBeNil)
+			So(buf.String(), ShouldEqual, expectedRepos[i])
+		}
+	})
+}
@@ -1,12 +1,11 @@
 package jpa
 
 import (
-	"github.com/iancoleman/strcase"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
-	"strings"
 )
 
+// KotlinClass is an entity class generated from a table.
 type KotlinClass struct {
 	table        *octopus.Table
 	Name         string
@@ -16,6 +15,7 @@ type KotlinClass struct {
 	UniqueFields []*KotlinField
 }
 
+// KotlinField is an entity class field generated from a column.
 type KotlinField struct {
 	Column       *octopus.Column
 	Name         string
@@ -29,18 +29,7 @@ func NewKotlinClass(
 	table *octopus.Table,
 	option *KtOption,
 ) *KotlinClass {
-	className := table.ClassName
-	if className == "" {
-		tableName := table.Name
-		for _, prefix := range option.RemovePrefixes {
-			tableName = strings.TrimPrefix(tableName, prefix)
-		}
-		className = strcase.ToCamel(tableName)
-
-		if prefix := option.PrefixMapper.GetPrefix(table.Group); prefix != "" {
-			className = prefix + className
-		}
-	}
+	className := entityClassName(table, option.RemovePrefixes, option.PrefixMapper)
 
 	var fields []*KotlinField
 	var pkFields []*KotlinField
@@ -68,95 +57,19 @@ func NewKotlinClass(
 }
 
 func NewKotlinField(column *octopus.Column) *KotlinField {
-	var fieldType string
 	var defaultValue string
 	notNull := column.NotNull
 
 	importSet := util.NewStringSet()
 
-	columnType := strings.ToLower(column.Type)
-	switch columnType {
-	case octopus.ColTypeChar:
-		fallthrough
-	case octopus.ColTypeVarchar:
-		fallthrough
-	case octopus.ColTypeText8:
-		fallthrough
-	case octopus.ColTypeText16:
-		fallthrough
-	case octopus.ColTypeText24:
-		fallthrough
-	case octopus.ColTypeText32:
-		fieldType = "String"
-		if notNull {
-			defaultValue = "\"\""
-		}
-	case octopus.ColTypeBoolean:
-		fieldType = "Boolean"
-		if notNull {
-			defaultValue = "false"
-		}
-	case octopus.ColTypeInt8:
-		fallthrough
-	case octopus.ColTypeInt16:
-		fallthrough
-	case octopus.ColTypeInt24:
-		fallthrough
-	case octopus.ColTypeInt32:
-		fieldType = "Int"
-		if notNull {
-			defaultValue = "0"
-		}
-	case octopus.ColTypeInt64:
-		fieldType = "Long"
-		if notNull {
-			defaultValue = "0L"
-		}
-	case octopus.ColTypeDecimal:
-		fieldType = "BigDecimal"
-		importSet.Add("java.math.BigDecimal")
-		if notNull {
-			defaultValue = "BigDecimal.ZERO"
-		}
-	case octopus.ColTypeFloat:
-		fieldType = "Float"
-		if notNull {
-			defaultValue = "0.0F"
-		}
-	case octopus.ColTypeDouble:
-		fieldType = "Double"
-		if notNull {
-			defaultValue = "0.0"
-		}
-	case octopus.ColTypeDateTime:
-		fieldType = "Timestamp"
-		importSet.Add("java.sql.Timestamp")
-		if notNull {
-			defaultValue = "Timestamp(System.currentTimeMillis())"
-		}
-	case octopus.ColTypeDate:
-		fieldType = "LocalDate"
-		importSet.Add("java.time.LocalDate")
-		if notNull {
-			defaultValue = "LocalDate.now()"
-		}
-	case octopus.ColTypeTime:
-		fieldType = "LocalTime"
-		importSet.Add("java.time.LocalTime")
-		if notNull {
-			defaultValue = "LocalTime.now()"
-		}
-	case octopus.ColTypeBlob8:
-		fallthrough
-	case octopus.ColTypeBlob16:
-		fallthrough
-	case octopus.ColTypeBlob24:
-		fallthrough
-	case octopus.ColTypeBlob32:
-		fieldType = "Blob"
-		importSet.Add("java.sql.Blob")
-	default:
-		fieldType = "Any"
+	// '--useUTC' is not supported yet
+	jt := toJvmType(column, false)
+	fieldType := jt.Kotlin
+	if jt.Import != "" {
+		importSet.Add(jt.Import)
+	}
+	if notNull {
+		defaultValue = kotlinDefaultValue(fieldType)
 	}
 	if !notNull {
 		fieldType = fieldType + "?"
@@ -173,3 +86,37 @@ func NewKotlinField(column *octopus.Column) *KotlinField {
 		Imports:      importSet.Slice(),
 	}
 }
+
+// kotlinDefaultValue returns default value of not null field.
+func kotlinDefaultValue(fieldType string) string {
+	switch fieldType {
+	case "String":
+		return "\"\""
+	case "Boolean":
+		return "false"
+	case "Int":
+		return "0"
+	case "Short":
+		return "0"
+	case "Long":
+		return "0L"
+	case "BigDecimal":
+		return "BigDecimal.ZERO"
+	case "Float":
+		return "0.0F"
+	case "Double":
+		return "0.0"
+	case "ByteArray":
+		return "ByteArray(0)"
+	case "Timestamp":
+		return "Timestamp(System.currentTimeMillis())"
+	case "Instant":
+		return "Instant.now()"
+	case "LocalDate":
+		return "LocalDate.now()"
+	case "LocalTime":
+		return "LocalTime.now()"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,215 @@
+package jpa
+
+import (
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"strings"
+	"text/template"
+)
+
+// loadTemplate reads and parses template file only once, and returns a copy of the parsed template.
+// funcMap is set to the copy, so that functions can be bound to each class.
+func loadTemplate(
+	parsed **template.Template,
+	name string,
+	filename string,
+	defaultText string,
+	funcMap template.FuncMap,
+) (*template.Template, error) {
+	if *parsed == nil {
+		tplText, err := util.ReadTemplateText(filename, defaultText)
+		if err != nil {
+			return nil, err
+		}
+		tpl, err := util.NewTemplate(name, tplText, funcMap)
+		if err != nil {
+			return nil, err
+		}
+		*parsed = tpl
+	}
+
+	tpl, err := (*parsed).Clone()
+	if err != nil {
+		return nil, err
+	}
+	return tpl.Funcs(funcMap), nil
+}
+
+// entityClassName returns entity class name from table name.
+func entityClassName(
+	table *octopus.Table,
+	removePrefixes []string,
+	prefixMapper *common.PrefixMapper,
+) string {
+	className := table.ClassName
+	if className == "" {
+		tableName := table.Name
+		for _, prefix := range removePrefixes {
+			tableName = strings.TrimPrefix(tableName, prefix)
+		}
+		className = strcase.ToCamel(tableName)
+
+		if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+			className = prefix + className
+		}
+	}
+	return className
+}
+
+// jvmType is a JVM type mapped from column type.
+// It is shared by kotlin and java generators.
+type jvmType struct {
+	Kotlin string
+	Java   string
+	Import string
+}
+
+// toJvmType returns jvmType of column.
+// audit columns('created_at', 'updated_at') are mapped to Instant if useUTC is true.
+func toJvmType(column *octopus.Column, useUTC bool) *jvmType {
+	columnType := strings.ToLower(column.Type)
+	switch columnType {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		fallthrough
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		return &jvmType{Kotlin: "String", Java: "String"}
+	case octopus.ColTypeBoolean:
+		return &jvmType{Kotlin: "Boolean", Java: "Boolean"}
+	case octopus.ColTypeInt8:
+		fallthrough
+	case octopus.ColTypeInt16:
+		fallthrough
+	case octopus.ColTypeInt24:
+		fallthrough
+	case octopus.ColTypeInt32:
+		return &jvmType{Kotlin: "Int", Java: "Integer"}
+	case octopus.ColTypeInt64:
+		return &jvmType{Kotlin: "Long", Java: "Long"}
+	case octopus.ColTypeDecimal:
+		return &jvmType{Kotlin: "BigDecimal", Java: "BigDecimal", Import: "java.math.BigDecimal"}
+	case octopus.ColTypeFloat:
+		return &jvmType{Kotlin: "Float", Java: "Float"}
+	case octopus.ColTypeDouble:
+		return &jvmType{Kotlin: "Double", Java: "Double"}
+	case octopus.ColTypeDateTime:
+		if useUTC && isAuditColumn(column) {
+			return &jvmType{Kotlin: "Instant", Java: "Instant", Import: "java.time.Instant"}
+		}
+		return &jvmType{Kotlin: "Timestamp", Java: "Timestamp", Import: "java.sql.Timestamp"}
+	case octopus.ColTypeDate:
+		return &jvmType{Kotlin: "LocalDate", Java: "LocalDate", Import: "java.time.LocalDate"}
+	case octopus.ColTypeTime:
+		return &jvmType{Kotlin: "LocalTime", Java: "LocalTime", Import: "java.time.LocalTime"}
+	case octopus.ColTypeYear:
+		return &jvmType{Kotlin: "Short", Java: "Short"}
+	case octopus.ColTypeBit:
+		// bit(1) is a flag. wider bit columns are read as byte array.
+		if column.Size <= 1 {
+			return &jvmType{Kotlin: "Boolean", Java: "Boolean"}
+		}
+		return &jvmType{Kotlin: "ByteArray", Java: "byte[]"}
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		return &jvmType{Kotlin: "ByteArray", Java: "byte[]"}
+	case octopus.ColTypeGeometry:
+		// requires hibernate-spatial
+		return &jvmType{Kotlin: "Geometry", Java: "Geometry", Import: "org.locationtech.jts.geom.Geometry"}
+	case octopus.ColTypePoint:
+		return &jvmType{Kotlin: "Point", Java: "Point", Import: "org.locationtech.jts.geom.Point"}
+	case octopus.ColTypeEnum:
+		fallthrough
+	case octopus.ColTypeSet:
+		fallthrough
+	case octopus.ColTypeJSON:
+		// JPA cannot map Object. enum name, comma separated set values and json document are mapped to String.
+		return &jvmType{Kotlin: "Any", Java: "String"}
+	default:
+		return &jvmType{Kotlin: "Any", Java: "Object"}
+	}
+}
+
+func isAuditColumn(column *octopus.Column) bool {
+	return column.Name == "created_at" || column.Name == "updated_at"
+}
+
+// jpaFieldAnnotations returns JPA annotations of entity field.
+// It is shared by kotlin and java generators.
+// vRelationClass is the target class name of '@VRelation'. skipped if empty.
+func jpaFieldAnnotations(
+	column *octopus.Column,
+	fieldName string,
+	overrideName bool,
+	vRelationClass string,
+) []string {
+	var annotations []string
+
+	var colAttrs []string
+	if column.PrimaryKey {
+		annotations = append(annotations, "@Id")
+	}
+	if column.AutoIncremental {
+		annotations = append(annotations, "@GeneratedValue(strategy = GenerationType.AUTO)")
+	}
+	if octopus.IsColTypeClob(column.Type) || octopus.IsColTypeBlob(column.Type) {
+		annotations = append(annotations, "@Lob")
+	}
+
+	// @VRelation
+	if ref := column.Ref; ref != nil && vRelationClass != "" {
+		annotations = append(annotations,
+			fmt.Sprintf("@VRelation(cls = \"%s\", field = \"%s\")",
+				vRelationClass,
+				strcase.ToLowerCamel(ref.Column)))
+	}
+
+	if overrideName {
+		colAttrs = append(colAttrs, fmt.Sprintf("name = \"%s\"", column.Name))
+	}
+	if column.NotNull {
+		colAttrs = append(colAttrs, "nullable = false")
+	}
+	if octopus.IsColTypeString(column.Type) && column.Size > 0 {
+		colAttrs = append(colAttrs, fmt.Sprintf("length = %d", column.Size))
+	}
+	if octopus.IsColTypeDecimal(column.Type) {
+		if column.Size > 0 {
+			colAttrs = append(colAttrs, fmt.Sprintf("precision = %d", column.Size))
+		}
+		if column.Scale > 0 {
+			colAttrs = append(colAttrs, fmt.Sprintf("scale = %d", column.Scale))
+		}
+	}
+	// @CreationTimestamp
+	if column.Type == octopus.ColTypeDateTime && fieldName == "createdAt" {
+		annotations = append(annotations, "@CreationTimestamp")
+		colAttrs = append(colAttrs, "updatable = false")
+	}
+	// @UpdateTimestamp
+	if column.Type == octopus.ColTypeDateTime && fieldName == "updatedAt" {
+		annotations = append(annotations, "@UpdateTimestamp")
+	}
+	if len(colAttrs) > 0 {
+		annotations = append(annotations, fmt.Sprintf("@Column(%s)", strings.Join(colAttrs, ", ")))
+	}
+	return annotations
+}
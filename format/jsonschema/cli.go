@@ -0,0 +1,93 @@
+package jsonschema
+
+import (
+	"bytes"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagBundle = "bundle"
+	FlagGroups = "groups"
+	FlagInput  = "input"
+	FlagOutput = "output"
+)
+
+func ExportAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	exporter := Exporter{
+		schema: schema,
+		option: &Option{
+			TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		},
+	}
+
+	outputPath := c.String(FlagOutput)
+	if c.Bool(FlagBundle) {
+		filename := outputPath
+		if ext := strings.ToLower(filepath.Ext(outputPath)); ext != ".json" {
+			// ensure directory is created
+			if _, err := util.Mkdir(outputPath); err != nil {
+				return err
+			}
+			filename = filepath.Join(outputPath, "schema.json")
+		}
+
+		buf := new(bytes.Buffer)
+		if err = exporter.ExportBundle(buf); err != nil {
+			return err
+		}
+		return util.WriteStringToFile(filename, buf.String())
+	}
+
+	// ensure directory is created
+	if _, err := util.Mkdir(outputPath); err != nil {
+		return err
+	}
+	for _, table := range exporter.Tables() {
+		buf := new(bytes.Buffer)
+		if err = exporter.ExportTable(buf, table); err != nil {
+			return err
+		}
+		if err = util.WriteStringToFile(filepath.Join(outputPath, Filename(table)), buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var ExportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "export JSON schemas to `DIR`, or bundled JSON schema to `FILE`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.BoolFlag{
+		Name:    FlagBundle,
+		Aliases: []string{"b"},
+		Usage:   "export a single JSON schema with table schemas in '$defs'",
+		EnvVars: []string{"OCTOPUS_BUNDLE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to export. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+}
@@ -0,0 +1,199 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"math"
+	"strings"
+)
+
+type Option struct {
+	TableFilter octopus.TableFilterFn
+}
+
+type Exporter struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+// Tables returns tables to export.
+func (c *Exporter) Tables() []*octopus.Table {
+	return c.schema.FilteredTables(c.option.TableFilter)
+}
+
+// Filename returns JSON schema filename of the table.
+func Filename(table *octopus.Table) string {
+	return table.Name + ".schema.json"
+}
+
+// ExportTable writes JSON schema of the table.
+func (c *Exporter) ExportTable(wr io.Writer, table *octopus.Table) error {
+	schema := TableSchema(table)
+	schema.Schema = draft202012
+	schema.ID = Filename(table)
+	return writeJSON(wr, schema)
+}
+
+// ExportBundle writes a JSON schema which has schemas of all tables in '$defs'.
+func (c *Exporter) ExportBundle(wr io.Writer) error {
+	defs := util.NewOrderedMap()
+	for _, table := range c.Tables() {
+		defs.Set(table.Name, TableSchema(table))
+	}
+
+	return writeJSON(wr, &Schema{
+		Schema: draft202012,
+		Title:  c.schema.Name,
+		Defs:   defs,
+	})
+}
+
+func writeJSON(wr io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(wr)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// TableSchema returns object schema of the table.
+func TableSchema(table *octopus.Table) *Schema {
+	var required []string
+	properties := util.NewOrderedMap()
+	for _, column := range table.Columns {
+		properties.Set(column.Name, ColumnSchema(column))
+		if column.NotNull {
+			required = append(required, column.Name)
+		}
+	}
+
+	return &Schema{
+		Title:       table.Name,
+		Description: table.Description,
+		Type:        "object",
+		Properties:  properties,
+		Required:    required,
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// intRange returns signed integer schema of bits.
+func intRange(schema *Schema, bits int) {
+	schema.Type = "integer"
+	schema.Minimum = int64Ptr(-1 << (bits - 1))
+	schema.Maximum = int64Ptr(1<<(bits-1) - 1)
+}
+
+// ColumnSchema returns property schema of the column.
+// nullable column has 'null' type.
+func ColumnSchema(column *octopus.Column) *Schema {
+	schema := &Schema{
+		Description: column.Description,
+	}
+
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		schema.Type = "string"
+		schema.MaxLength = column.Size
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		schema.Type = "string"
+	case octopus.ColTypeBoolean:
+		schema.Type = "boolean"
+	case octopus.ColTypeBit:
+		if column.Size == 1 {
+			schema.Type = "boolean"
+		} else {
+			schema.Type = "integer"
+		}
+	case octopus.ColTypeInt8:
+		intRange(schema, 8)
+	case octopus.ColTypeInt16:
+		intRange(schema, 16)
+	case octopus.ColTypeInt24:
+		intRange(schema, 24)
+	case octopus.ColTypeInt32:
+		intRange(schema, 32)
+	case octopus.ColTypeInt64:
+		fallthrough
+	case octopus.ColTypeYear:
+		schema.Type = "integer"
+	case octopus.ColTypeDecimal:
+		schema.Type = "number"
+		if column.Size > column.Scale {
+			limit := math.Pow10(int(column.Size - column.Scale))
+			schema.ExclusiveMinimum = float64Ptr(-limit)
+			schema.ExclusiveMaximum = float64Ptr(limit)
+		}
+		if column.Scale > 0 {
+			schema.MultipleOf = math.Pow10(-int(column.Scale))
+		}
+	case octopus.ColTypeFloat:
+		fallthrough
+	case octopus.ColTypeDouble:
+		schema.Type = "number"
+	case octopus.ColTypeDateTime:
+		schema.Type = "string"
+		schema.Format = "date-time"
+	case octopus.ColTypeDate:
+		schema.Type = "string"
+		schema.Format = "date"
+	case octopus.ColTypeTime:
+		schema.Type = "string"
+		schema.Format = "time"
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		schema.Type = "string"
+		schema.ContentEncoding = "base64"
+	case octopus.ColTypeEnum:
+		schema.Type = "string"
+		for _, value := range column.Values {
+			schema.Enum = append(schema.Enum, value)
+		}
+	case octopus.ColTypeSet:
+		schema.Type = "array"
+		schema.UniqueItems = true
+		items := &Schema{Type: "string"}
+		for _, value := range column.Values {
+			items.Enum = append(items.Enum, value)
+		}
+		schema.Items = items
+	case octopus.ColTypeJSON:
+		// any type. 'null' is already allowed.
+		return schema
+	default:
+		schema.Type = "string"
+	}
+
+	if !column.NotNull {
+		schema.Type = []string{schema.Type.(string), "null"}
+		if schema.Enum != nil {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+	return schema
+}
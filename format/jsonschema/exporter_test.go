@@ -0,0 +1,205 @@
+package jsonschema
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Name: "sample",
+	Tables: []*octopus.Table{
+		{
+			Name:        "user",
+			Description: "User table",
+			Group:       "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:        "name",
+					Type:        octopus.ColTypeVarchar,
+					Size:        40,
+					NotNull:     true,
+					Description: "login name",
+				},
+				{
+					Name:   "class",
+					Type:   octopus.ColTypeEnum,
+					Values: []string{"A", "B"},
+				},
+				{
+					Name:    "roles",
+					Type:    octopus.ColTypeSet,
+					Values:  []string{"admin", "user"},
+					NotNull: true,
+				},
+				{
+					Name:    "age",
+					Type:    octopus.ColTypeInt8,
+					NotNull: true,
+				},
+				{
+					Name:  "point",
+					Type:  octopus.ColTypeDecimal,
+					Size:  10,
+					Scale: 2,
+				},
+				{
+					Name: "props",
+					Type: octopus.ColTypeJSON,
+				},
+				{
+					Name:    "birthday",
+					Type:    octopus.ColTypeDate,
+					NotNull: true,
+				},
+			},
+		},
+		{
+			Name:  "log",
+			Group: "log",
+			Columns: []*octopus.Column{
+				{
+					Name:    "created_at",
+					Type:    octopus.ColTypeDateTime,
+					NotNull: true,
+				},
+			},
+		},
+	},
+}
+
+func TestExporter_ExportTable(t *testing.T) {
+	Convey("ExportTable", t, func() {
+		expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "user.schema.json",
+  "title": "user",
+  "description": "User table",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer"
+    },
+    "name": {
+      "description": "login name",
+      "type": "string",
+      "maxLength": 40
+    },
+    "class": {
+      "type": [
+        "string",
+        "null"
+      ],
+      "enum": [
+        "A",
+        "B",
+        null
+      ]
+    },
+    "roles": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "enum": [
+          "admin",
+          "user"
+        ]
+      },
+      "uniqueItems": true
+    },
+    "age": {
+      "type": "integer",
+      "minimum": -128,
+      "maximum": 127
+    },
+    "point": {
+      "type": [
+        "number",
+        "null"
+      ],
+      "exclusiveMinimum": -100000000,
+      "exclusiveMaximum": 100000000,
+      "multipleOf": 0.01
+    },
+    "props": {},
+    "birthday": {
+      "type": "string",
+      "format": "date"
+    }
+  },
+  "required": [
+    "id",
+    "name",
+    "roles",
+    "age",
+    "birthday"
+  ]
+}
+`
+
+		exporter := &Exporter{
+			schema: testSchema,
+			option: &Option{},
+		}
+		buf := new(bytes.Buffer)
+		err := exporter.ExportTable(buf, testSchema.Tables[0])
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestExporter_ExportBundle(t *testing.T) {
+	Convey("ExportBundle", t, func() {
+		expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "sample",
+  "$defs": {
+    "log": {
+      "title": "log",
+      "type": "object",
+      "properties": {
+        "created_at": {
+          "type": "string",
+          "format": "date-time"
+        }
+      },
+      "required": [
+        "created_at"
+      ]
+    }
+  }
+}
+`
+
+		exporter := &Exporter{
+			schema: testSchema,
+			option: &Option{
+				TableFilter: octopus.GetTableFilterFn("log"),
+			},
+		}
+		buf := new(bytes.Buffer)
+		err := exporter.ExportBundle(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,28 @@
+package jsonschema
+
+import "github.com/lechuckroh/octopus-db-tools/util"
+
+const draft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema object.
+type Schema struct {
+	Schema           string           `json:"$schema,omitempty"`
+	ID               string           `json:"$id,omitempty"`
+	Title            string           `json:"title,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	Type             interface{}      `json:"type,omitempty"`
+	Format           string           `json:"format,omitempty"`
+	ContentEncoding  string           `json:"contentEncoding,omitempty"`
+	Enum             []interface{}    `json:"enum,omitempty"`
+	Items            *Schema          `json:"items,omitempty"`
+	UniqueItems      bool             `json:"uniqueItems,omitempty"`
+	MaxLength        uint16           `json:"maxLength,omitempty"`
+	Minimum          *int64           `json:"minimum,omitempty"`
+	Maximum          *int64           `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64         `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64         `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       float64          `json:"multipleOf,omitempty"`
+	Properties       *util.OrderedMap `json:"properties,omitempty"`
+	Required         []string         `json:"required,omitempty"`
+	Defs             *util.OrderedMap `json:"$defs,omitempty"`
+}
@@ -0,0 +1,66 @@
+package liquibase
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func TestDiff_AddColumn(t *testing.T) {
+	Convey("Diff with added column", t, func() {
+		newUserTable := func(columns ...*octopus.Column) *octopus.Table {
+			return &octopus.Table{
+				Name: "user",
+				Columns: append([]*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+				}, columns...),
+			}
+		}
+		diff := NewDiff(&DiffOption{
+			Author: "author",
+			DiffFrom: &octopus.Schema{
+				Version: "1.0.0",
+				Tables:  []*octopus.Table{newUserTable()},
+			},
+			DiffTo: &octopus.Schema{
+				Version: "1.0.1",
+				Tables: []*octopus.Table{newUserTable(&octopus.Column{
+					Name:    "point",
+					Type:    octopus.ColTypeInt32,
+					NotNull: true,
+				})},
+			},
+		})
+		expected := `databaseChangeLog:
+- objectQuotingStrategy: QUOTE_ALL_OBJECTS
+- changeSet:
+    id: 1-1
+    author: author
+    changes:
+    - addColumn:
+        tableName: user
+        columns:
+        - column:
+            name: point
+            type: int
+            constraints:
+              nullable: false
+            afterColumn: id
+`
+
+		data, err := diff.generate()
+		So(err, ShouldBeNil)
+		actual := string(data)
+		if d := cmp.Diff(expected, actual); d != "" {
+			log.Println(d)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
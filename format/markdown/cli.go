@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"bytes"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagGroups      = "groups"
+	FlagInput       = "input"
+	FlagOutput      = "output"
+	FlagSplitGroups = "splitGroups"
+	FlagTitle       = "title"
+)
+
+// IndexFilename is the filename of group index in split groups mode.
+const IndexFilename = "README.md"
+
+func ExportAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	splitGroups := c.Bool(FlagSplitGroups)
+	exporter := NewExporter(schema, &Option{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		Title:       c.String(FlagTitle),
+		SplitGroups: splitGroups,
+	})
+
+	outputPath := c.String(FlagOutput)
+	if splitGroups {
+		return exportGroups(exporter, outputPath)
+	}
+
+	var filename string
+	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".md" {
+		filename = outputPath
+	} else {
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, "output.md")
+	}
+
+	buf := new(bytes.Buffer)
+	if err = exporter.Export(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+// exportGroups writes index and a file per group to outputPath directory.
+func exportGroups(exporter *Exporter, outputPath string) error {
+	if _, err := util.Mkdir(outputPath); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := exporter.ExportIndex(buf); err != nil {
+		return err
+	}
+	if err := util.WriteStringToFile(filepath.Join(outputPath, IndexFilename), buf.String()); err != nil {
+		return err
+	}
+
+	for _, group := range exporter.Groups() {
+		buf := new(bytes.Buffer)
+		if err := exporter.ExportGroup(buf, group); err != nil {
+			return err
+		}
+		filename := filepath.Join(outputPath, exporter.GroupFilename(group))
+		if err := util.WriteStringToFile(filename, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var ExportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "export markdown data dictionary to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to export. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagSplitGroups,
+		Aliases: []string{"s"},
+		Usage:   "export a file per table group to output directory",
+		EnvVars: []string{"OCTOPUS_SPLIT_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagTitle,
+		Aliases: []string{"t"},
+		Usage:   "document title",
+		EnvVars: []string{"OCTOPUS_TITLE"},
+	},
+}
@@ -0,0 +1,287 @@
+package markdown
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/diff"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultGroupName is the name of tables without group.
+const DefaultGroupName = "default"
+
+var anchorRemoveRegex = regexp.MustCompile(`[^a-z0-9 _-]`)
+
+type Option struct {
+	TableFilter octopus.TableFilterFn
+	// Title is the document title. Schema name is used if empty.
+	Title string
+	// SplitGroups links tables of other groups to Exporter.GroupFilename.
+	SplitGroups bool
+}
+
+type Exporter struct {
+	schema *octopus.Schema
+	option *Option
+	// groupByTable is the group of filtered tables.
+	groupByTable map[string]string
+	// groupFilenames is the filename without extension of each group.
+	groupFilenames map[string]string
+}
+
+// reference is a reference between columns.
+type reference struct {
+	table  *octopus.Table
+	column *octopus.Column
+	ref    *octopus.Reference
+}
+
+func NewExporter(schema *octopus.Schema, option *Option) *Exporter {
+	exporter := &Exporter{
+		schema:       schema,
+		option:       option,
+		groupByTable: make(map[string]string),
+	}
+	for _, table := range schema.FilteredTables(option.TableFilter) {
+		exporter.groupByTable[table.Name] = table.Group
+	}
+	indexName := strings.TrimSuffix(IndexFilename, filepath.Ext(IndexFilename))
+	exporter.groupFilenames = util.GroupFilenames(exporter.Groups(), DefaultGroupName, indexName)
+	return exporter
+}
+
+// GroupFilename returns the filename of the group in split groups mode.
+// Group name is converted to safe filename, and duplicated filename gets numeric suffix.
+func (c *Exporter) GroupFilename(group string) string {
+	return c.groupFilenames[group] + ".md"
+}
+
+// groupTables returns filtered tables of the group.
+func (c *Exporter) groupTables(group string) []*octopus.Table {
+	var tables []*octopus.Table
+	for _, table := range c.schema.FilteredTables(c.option.TableFilter) {
+		if table.Group == group {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// Groups returns group names of filtered tables in schema order.
+func (c *Exporter) Groups() []string {
+	var groups []string
+	groupSet := util.NewStringSet()
+	for _, table := range c.schema.FilteredTables(c.option.TableFilter) {
+		if !groupSet.Contains(table.Group) {
+			groupSet.Add(table.Group)
+			groups = append(groups, table.Group)
+		}
+	}
+	return groups
+}
+
+func (c *Exporter) title() string {
+	if c.option.Title != "" {
+		return c.option.Title
+	}
+	if c.schema.Name != "" {
+		return c.schema.Name
+	}
+	return "Data Dictionary"
+}
+
+func groupTitle(group string) string {
+	if group == "" {
+		return "(no group)"
+	}
+	return group
+}
+
+// anchor returns GitHub style heading anchor.
+func anchor(heading string) string {
+	s := anchorRemoveRegex.ReplaceAllString(strings.ToLower(heading), "")
+	return "#" + strings.ReplaceAll(s, " ", "-")
+}
+
+// tableLink returns markdown link to the table section from the document of currentGroup.
+// returns text only if the table is not exported.
+func (c *Exporter) tableLink(text string, tableName string, currentGroup string) string {
+	group, ok := c.groupByTable[tableName]
+	if !ok {
+		return text
+	}
+	href := anchor(tableName)
+	if c.option.SplitGroups && group != currentGroup {
+		href = c.GroupFilename(group) + href
+	}
+	return fmt.Sprintf("[%s](%s)", text, href)
+}
+
+// Export writes data dictionary of all filtered tables.
+func (c *Exporter) Export(wr io.Writer) error {
+	w := diff.NewMarkdownWriter(wr)
+	w.WriteH1(c.title())
+	if version := c.schema.Version; version != "" {
+		w.WriteLine("")
+		w.WriteLine(fmt.Sprintf("* version: `%s`", version))
+	}
+
+	w.WriteLine("")
+	w.WriteH2("Table of Contents")
+	w.WriteLine("")
+	for _, group := range c.Groups() {
+		w.WriteLine("* " + groupTitle(group))
+		for _, table := range c.groupTables(group) {
+			w.WriteLine("    " + tocLine(table, c.tableLink(table.Name, table.Name, group)))
+		}
+	}
+
+	for _, table := range c.schema.FilteredTables(c.option.TableFilter) {
+		c.writeTable(w, table, table.Group)
+	}
+	return nil
+}
+
+// ExportGroup writes data dictionary of filtered tables of the group.
+func (c *Exporter) ExportGroup(wr io.Writer, group string) error {
+	w := diff.NewMarkdownWriter(wr)
+	w.WriteH1(groupTitle(group))
+
+	tables := c.groupTables(group)
+	w.WriteLine("")
+	w.WriteH2("Table of Contents")
+	w.WriteLine("")
+	for _, table := range tables {
+		w.WriteLine(tocLine(table, c.tableLink(table.Name, table.Name, group)))
+	}
+
+	for _, table := range tables {
+		c.writeTable(w, table, group)
+	}
+	return nil
+}
+
+// ExportIndex writes links to group documents.
+func (c *Exporter) ExportIndex(wr io.Writer) error {
+	w := diff.NewMarkdownWriter(wr)
+	w.WriteH1(c.title())
+	if version := c.schema.Version; version != "" {
+		w.WriteLine("")
+		w.WriteLine(fmt.Sprintf("* version: `%s`", version))
+	}
+
+	w.WriteLine("")
+	w.WriteH2("Groups")
+	w.WriteLine("")
+	for _, group := range c.Groups() {
+		w.WriteLine(fmt.Sprintf("* [%s](%s)", groupTitle(group), c.GroupFilename(group)))
+		for _, table := range c.groupTables(group) {
+			link := fmt.Sprintf("[%s](%s%s)", table.Name, c.GroupFilename(group), anchor(table.Name))
+			w.WriteLine("    " + tocLine(table, link))
+		}
+	}
+	return nil
+}
+
+// tocLine returns table of contents item of the table.
+func tocLine(table *octopus.Table, link string) string {
+	line := "* " + link
+	if table.Description != "" {
+		line += ": " + diff.EscapeMarkdown(table.Description)
+	}
+	return line
+}
+
+func (c *Exporter) writeTable(w *diff.MarkdownWriter, table *octopus.Table, currentGroup string) {
+	w.WriteLine("")
+	w.WriteH2(table.Name)
+	if table.Description != "" {
+		w.WriteLine("")
+		w.WriteLine(diff.EscapeMarkdown(table.Description))
+	}
+
+	// columns
+	var rows [][]string
+	for _, column := range table.Columns {
+		rows = append(rows, []string{
+			column.Name,
+			column.Format(),
+			util.IfThenElseString(column.NotNull, "NO", "YES"),
+			strings.Join(column.Keys(), ", "),
+			formatDefaultValue(column.DefaultValue),
+			column.Description,
+		})
+	}
+	w.WriteLine("")
+	w.WriteTable([]string{"Name", "Type", "Null", "Key", "Default", "Description"}, rows)
+
+	// indices
+	if len(table.Indices) > 0 {
+		rows = nil
+		for _, index := range table.Indices {
+			rows = append(rows, []string{index.Name, strings.Join(index.Columns, ", ")})
+		}
+		w.WriteLine("")
+		w.WriteH3("Indices")
+		w.WriteLine("")
+		w.WriteTable([]string{"Name", "Columns"}, rows)
+	}
+
+	// references
+	rows = nil
+	for _, column := range table.Columns {
+		if ref := column.Ref; ref != nil {
+			rows = append(rows, []string{
+				column.Name,
+				c.tableLink(ref.Table+"."+ref.Column, ref.Table, currentGroup),
+				ref.Relationship,
+			})
+		}
+	}
+	if len(rows) > 0 {
+		w.WriteLine("")
+		w.WriteH3("References")
+		w.WriteLine("")
+		w.WriteTable([]string{"Column", "References", "Relationship"}, rows)
+	}
+
+	// referenced by
+	rows = nil
+	for _, r := range c.referencedBy(table) {
+		rows = append(rows, []string{
+			r.ref.Column,
+			c.tableLink(r.table.Name+"."+r.column.Name, r.table.Name, currentGroup),
+			r.ref.Relationship,
+		})
+	}
+	if len(rows) > 0 {
+		w.WriteLine("")
+		w.WriteH3("Referenced by")
+		w.WriteLine("")
+		w.WriteTable([]string{"Column", "Referenced by", "Relationship"}, rows)
+	}
+}
+
+// referencedBy returns columns of filtered tables referencing the table.
+func (c *Exporter) referencedBy(table *octopus.Table) []*reference {
+	var result []*reference
+	for _, t := range c.schema.FilteredTables(c.option.TableFilter) {
+		for _, column := range t.Columns {
+			if ref := column.Ref; ref != nil && ref.Table == table.Name {
+				result = append(result, &reference{table: t, column: column, ref: ref})
+			}
+		}
+	}
+	return result
+}
+
+func formatDefaultValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + value + "`"
+}
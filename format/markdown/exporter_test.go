@@ -0,0 +1,234 @@
+package markdown
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func newTestSchema() *octopus.Schema {
+	return &octopus.Schema{
+		Name:    "sample",
+		Version: "1.0.0",
+		Tables: []*octopus.Table{
+			{
+				Name:        "group",
+				Group:       "common",
+				Description: "Group table",
+				Columns: []*octopus.Column{
+					{
+						Name:            "id",
+						Type:            octopus.ColTypeInt64,
+						NotNull:         true,
+						PrimaryKey:      true,
+						AutoIncremental: true,
+					},
+					{
+						Name:        "name",
+						Type:        octopus.ColTypeVarchar,
+						Size:        40,
+						NotNull:     true,
+						UniqueKey:   true,
+						Description: "group name | <unique>",
+					},
+				},
+			},
+			{
+				Name: "user",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:         "group_id",
+						Type:         octopus.ColTypeInt64,
+						DefaultValue: "1",
+						Description:  "group\nID",
+						Ref: &octopus.Reference{
+							Table:        "group",
+							Column:       "id",
+							Relationship: octopus.RefManyToOne,
+						},
+					},
+				},
+				Indices: []*octopus.Index{
+					{Name: "idx_group", Columns: []string{"group_id", "id"}},
+				},
+			},
+		},
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		expected := "# sample\n" +
+			"\n" +
+			"* version: `1.0.0`\n" +
+			"\n" +
+			"## Table of Contents\n" +
+			"\n" +
+			"* common\n" +
+			"    * [group](#group): Group table\n" +
+			"* (no group)\n" +
+			"    * [user](#user)\n" +
+			"\n" +
+			"## group\n" +
+			"\n" +
+			"Group table\n" +
+			"\n" +
+			"| Name | Type | Null | Key | Default | Description |\n" +
+			"| ---- | ---- | ---- | --- | ------- | ----------- |\n" +
+			"| id | int64 | NO | PK, AI |  |  |\n" +
+			"| name | varchar(40) | NO | UK |  | group name \\| &lt;unique&gt; |\n" +
+			"\n" +
+			"### Referenced by\n" +
+			"\n" +
+			"| Column | Referenced by | Relationship |\n" +
+			"| ------ | ------------- | ------------ |\n" +
+			"| id | [user.group_id](#user) | n:1 |\n" +
+			"\n" +
+			"## user\n" +
+			"\n" +
+			"| Name | Type | Null | Key | Default | Description |\n" +
+			"| ---- | ---- | ---- | --- | ------- | ----------- |\n" +
+			"| id | int64 | NO | PK |  |  |\n" +
+			"| group_id | int64 | YES | FK | `1` | group<br />ID |\n" +
+			"\n" +
+			"### Indices\n" +
+			"\n" +
+			"| Name | Columns |\n" +
+			"| ---- | ------- |\n" +
+			"| idx_group | group_id, id |\n" +
+			"\n" +
+			"### References\n" +
+			"\n" +
+			"| Column | References | Relationship |\n" +
+			"| ------ | ---------- | ------------ |\n" +
+			"| group_id | [group.id](#group) | n:1 |\n"
+
+		buf := new(bytes.Buffer)
+		So(NewExporter(newTestSchema(), &Option{}).Export(buf), ShouldBeNil)
+		actual := buf.String()
+		diff := cmp.Diff(expected, actual)
+		if diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestExporter_ExportGroup(t *testing.T) {
+	Convey("ExportGroup", t, func() {
+		exporter := NewExporter(newTestSchema(), &Option{
+			Title:       "Dictionary",
+			SplitGroups: true,
+		})
+		So(exporter.Groups(), ShouldResemble, []string{"common", ""})
+
+		Convey("index", func() {
+			expected := "# Dictionary\n" +
+				"\n" +
+				"* version: `1.0.0`\n" +
+				"\n" +
+				"## Groups\n" +
+				"\n" +
+				"* [common](common.md)\n" +
+				"    * [group](common.md#group): Group table\n" +
+				"* [(no group)](default.md)\n" +
+				"    * [user](default.md#user)\n"
+
+			buf := new(bytes.Buffer)
+			So(exporter.ExportIndex(buf), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("group", func() {
+			expected := "# common\n" +
+				"\n" +
+				"## Table of Contents\n" +
+				"\n" +
+				"* [group](#group): Group table\n" +
+				"\n" +
+				"## group\n" +
+				"\n" +
+				"Group table\n" +
+				"\n" +
+				"| Name | Type | Null | Key | Default | Description |\n" +
+				"| ---- | ---- | ---- | --- | ------- | ----------- |\n" +
+				"| id | int64 | NO | PK, AI |  |  |\n" +
+				"| name | varchar(40) | NO | UK |  | group name \\| &lt;unique&gt; |\n" +
+				"\n" +
+				"### Referenced by\n" +
+				"\n" +
+				"| Column | Referenced by | Relationship |\n" +
+				"| ------ | ------------- | ------------ |\n" +
+				"| id | [user.group_id](default.md#user) | n:1 |\n"
+
+			buf := new(bytes.Buffer)
+			So(exporter.ExportGroup(buf, "common"), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("unsafe group names and description", func() {
+			schema := &octopus.Schema{
+				Tables: []*octopus.Table{
+					{Name: "log", Description: "logs | <raw>\nline"},
+					{Name: "user", Group: "default"},
+					{Name: "order", Group: "../evil"},
+					{Name: "readme", Group: "readme"},
+				},
+			}
+			exporter := NewExporter(schema, &Option{SplitGroups: true})
+			So(exporter.GroupFilename(""), ShouldEqual, "default.md")
+			So(exporter.GroupFilename("default"), ShouldEqual, "default_2.md")
+			So(exporter.GroupFilename("../evil"), ShouldEqual, ".._evil.md")
+			So(exporter.GroupFilename("readme"), ShouldEqual, "readme_2.md")
+
+			expected := "# (no group)\n" +
+				"\n" +
+				"## Table of Contents\n" +
+				"\n" +
+				"* [log](#log): logs \\| &lt;raw&gt;<br />line\n" +
+				"\n" +
+				"## log\n" +
+				"\n" +
+				"logs \\| &lt;raw&gt;<br />line\n" +
+				"\n" +
+				"| Name | Type | Null | Key | Default | Description |\n" +
+				"| ---- | ---- | ---- | --- | ------- | ----------- |\n"
+
+			buf := new(bytes.Buffer)
+			So(exporter.ExportGroup(buf, ""), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("filtered reference", func() {
+			exporter := NewExporter(newTestSchema(), &Option{
+				TableFilter: octopus.GetTableFilterFn("common"),
+			})
+			So(exporter.tableLink("user.group_id", "user", "common"), ShouldEqual, "user.group_id")
+			So(exporter.referencedBy(exporter.schema.TableByName("group")), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package mermaid
+
+import (
+	"bytes"
+	"errors"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagGroups      = "groups"
+	FlagInput       = "input"
+	FlagOutput      = "output"
+	FlagSplitGroups = "splitGroups"
+)
+
+func ExportAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	outputPath := c.String(FlagOutput)
+	extSet := util.NewStringSet(".md", ".mmd", ".mermaid")
+	var filename string
+	ext := strings.ToLower(filepath.Ext(outputPath))
+	if extSet.Contains(ext) {
+		filename = outputPath
+	} else {
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, "output.mmd")
+		ext = ".mmd"
+	}
+
+	markdown := ext == ".md"
+	splitGroups := c.Bool(FlagSplitGroups)
+	if splitGroups && !markdown {
+		return errors.New("splitGroups requires markdown(*.md) output")
+	}
+
+	exporter := NewExporter(schema, &Option{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		Markdown:    markdown,
+		SplitGroups: splitGroups,
+	})
+	buf := new(bytes.Buffer)
+	if err = exporter.Export(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+var ExportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "export mermaid ER diagram to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagSplitGroups,
+		Aliases: []string{"s"},
+		Usage:   "write a diagram per table group. requires markdown(*.md) output.",
+		EnvVars: []string{"OCTOPUS_SPLIT_GROUPS"},
+	},
+}
@@ -0,0 +1,156 @@
+package mermaid
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"io"
+	"strings"
+)
+
+const defaultGroupName = "Default"
+
+type Option struct {
+	TableFilter octopus.TableFilterFn
+	Markdown    bool
+	SplitGroups bool
+}
+
+type Exporter struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewExporter(schema *octopus.Schema, option *Option) *Exporter {
+	return &Exporter{schema: schema, option: option}
+}
+
+// Export writes mermaid ER diagram.
+// If Markdown is set, diagrams are written in mermaid code blocks. If SplitGroups is also set, a diagram is written per table group.
+func (c *Exporter) Export(wr io.Writer) error {
+	tables := c.schema.FilteredTables(c.option.TableFilter)
+
+	if !c.option.Markdown {
+		_, err := io.WriteString(wr, strings.Join(c.diagramLines(tables, tables), "\n")+"\n")
+		return err
+	}
+
+	var result []string
+	if c.option.SplitGroups {
+		var groupNames []string
+		tablesByGroup := make(map[string][]*octopus.Table)
+		for _, table := range tables {
+			group := table.Group
+			if group == "" {
+				group = defaultGroupName
+			}
+			if _, ok := tablesByGroup[group]; !ok {
+				groupNames = append(groupNames, group)
+			}
+			tablesByGroup[group] = append(tablesByGroup[group], table)
+		}
+
+		for i, group := range groupNames {
+			if i > 0 {
+				result = append(result, "")
+			}
+			result = append(result, "## "+group, "")
+			result = append(result, codeBlock(c.diagramLines(tablesByGroup[group], tables))...)
+		}
+	} else {
+		result = codeBlock(c.diagramLines(tables, tables))
+	}
+
+	_, err := io.WriteString(wr, strings.Join(result, "\n")+"\n")
+	return err
+}
+
+func codeBlock(lines []string) []string {
+	result := []string{"```mermaid"}
+	result = append(result, lines...)
+	return append(result, "```")
+}
+
+// diagramLines returns erDiagram lines of tables.
+// relationships to tables which are not in allTables are skipped.
+func (c *Exporter) diagramLines(tables []*octopus.Table, allTables []*octopus.Table) []string {
+	tableNames := make(map[string]bool)
+	for _, table := range allTables {
+		tableNames[table.Name] = true
+	}
+
+	result := []string{"erDiagram"}
+	var relationships []string
+	for _, table := range tables {
+		result = append(result, fmt.Sprintf("    %s {", table.Name))
+		for _, column := range table.Columns {
+			result = append(result, "        "+getAttributeDef(column))
+
+			ref := column.Ref
+			if ref == nil || !tableNames[ref.Table] {
+				continue
+			}
+			if ref.Relationship == octopus.RefOneToMany {
+				// foreign key is defined in referenced table
+				_, refColumn := c.schema.FindReference(*ref)
+				notNull := refColumn != nil && refColumn.NotNull
+				relationships = append(relationships, fmt.Sprintf("    %s %s %s : %q",
+					table.Name, getOneToManyCardinality(notNull), ref.Table, ref.Column))
+			} else {
+				relationships = append(relationships, fmt.Sprintf("    %s %s %s : %q",
+					ref.Table, getCardinality(ref.Relationship, column.NotNull), table.Name, column.Name))
+			}
+		}
+		result = append(result, "    }")
+	}
+	return append(result, relationships...)
+}
+
+// getAttributeDef returns attribute definition. ex: 'varchar(40) name UK "user name"'
+func getAttributeDef(column *octopus.Column) string {
+	columnType := column.Type
+	if column.Size > 0 && column.Scale == 0 {
+		columnType = fmt.Sprintf("%s(%d)", column.Type, column.Size)
+	}
+	params := []string{columnType, column.Name}
+
+	var keys []string
+	if column.PrimaryKey {
+		keys = append(keys, "PK")
+	}
+	if column.Ref != nil && column.Ref.Relationship != octopus.RefOneToMany {
+		keys = append(keys, "FK")
+	}
+	if column.UniqueKey {
+		keys = append(keys, "UK")
+	}
+	if len(keys) > 0 {
+		params = append(params, strings.Join(keys, ", "))
+	}
+
+	if column.Description != "" {
+		params = append(params, `"`+strings.ReplaceAll(column.Description, `"`, `'`)+`"`)
+	}
+	return strings.Join(params, " ")
+}
+
+// getCardinality returns cardinality from referenced table to foreign key table.
+func getCardinality(relationship string, notNull bool) string {
+	parent := "o|"
+	if notNull {
+		parent = "||"
+	}
+	switch relationship {
+	case octopus.RefOneToOne:
+		return parent + "--o|"
+	default:
+		return parent + "--o{"
+	}
+}
+
+// getOneToManyCardinality returns cardinality of '1:n' reference.
+func getOneToManyCardinality(notNull bool) string {
+	if notNull {
+		return "||--o{"
+	}
+	return "o|--o{"
+}
@@ -0,0 +1,161 @@
+package mermaid
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"strings"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:  "user",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true, Description: "unique id"},
+				{Name: "name", Type: "varchar", Size: 40, UniqueKey: true, NotNull: true, Description: `"login" name`},
+				{
+					Name:    "group_id",
+					Type:    "int64",
+					NotNull: true,
+					Ref:     &octopus.Reference{Table: "group", Column: "id", Relationship: octopus.RefManyToOne},
+				},
+			},
+		},
+		{
+			Name:  "group",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{
+					Name: "owner_id",
+					Type: "int64",
+					Ref:  &octopus.Reference{Table: "user", Column: "id", Relationship: octopus.RefOneToOne},
+				},
+			},
+		},
+		{
+			Name:  "order",
+			Group: "shop",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{Name: "amount", Type: "decimal", Size: 10, Scale: 2},
+				{
+					Name:    "user_id",
+					Type:    "int64",
+					NotNull: true,
+					Ref:     &octopus.Reference{Table: "user", Column: "id"},
+				},
+				{
+					Name: "id2",
+					Type: "int64",
+					Ref:  &octopus.Reference{Table: "order_item", Column: "order_id", Relationship: octopus.RefOneToMany},
+				},
+			},
+		},
+		{
+			Name:  "order_item",
+			Group: "shop",
+			Columns: []*octopus.Column{
+				{Name: "order_id", Type: "int64", PrimaryKey: true, NotNull: true},
+			},
+		},
+	},
+}
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		expected := strings.Join([]string{
+			"erDiagram",
+			"    user {",
+			`        int64 id PK "unique id"`,
+			`        varchar(40) name UK "'login' name"`,
+			"        int64 group_id FK",
+			"    }",
+			"    group {",
+			"        int64 id PK",
+			"        int64 owner_id FK",
+			"    }",
+			"    order {",
+			"        int64 id PK",
+			"        decimal amount",
+			"        int64 user_id FK",
+			"        int64 id2",
+			"    }",
+			"    order_item {",
+			"        int64 order_id PK",
+			"    }",
+			`    group ||--o{ user : "group_id"`,
+			`    user o|--o| group : "owner_id"`,
+			`    user ||--o{ order : "user_id"`,
+			`    order ||--o{ order_item : "order_id"`,
+			"",
+		}, "\n")
+
+		exporter := NewExporter(testSchema, &Option{})
+		buf := new(bytes.Buffer)
+		So(exporter.Export(buf), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("Export markdown by groups", t, func() {
+		expected := strings.Join([]string{
+			"## common",
+			"",
+			"```mermaid",
+			"erDiagram",
+			"    user {",
+			`        int64 id PK "unique id"`,
+			`        varchar(40) name UK "'login' name"`,
+			"        int64 group_id FK",
+			"    }",
+			"    group {",
+			"        int64 id PK",
+			"        int64 owner_id FK",
+			"    }",
+			`    group ||--o{ user : "group_id"`,
+			`    user o|--o| group : "owner_id"`,
+			"```",
+			"",
+			"## shop",
+			"",
+			"```mermaid",
+			"erDiagram",
+			"    order {",
+			"        int64 id PK",
+			"        decimal amount",
+			"        int64 user_id FK",
+			"        int64 id2",
+			"    }",
+			"    order_item {",
+			"        int64 order_id PK",
+			"    }",
+			`    user ||--o{ order : "user_id"`,
+			`    order ||--o{ order_item : "order_id"`,
+			"```",
+			"",
+		}, "\n")
+
+		exporter := NewExporter(testSchema, &Option{
+			Markdown:    true,
+			SplitGroups: true,
+		})
+		buf := new(bytes.Buffer)
+		So(exporter.Export(buf), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
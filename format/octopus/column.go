@@ -180,6 +180,25 @@ func (c *Column) getValue(value string) (string, bool) {
 	return value, false
 }
 
+// Keys returns key abbreviations of the column: PK, UK, FK, AI.
+// FK is omitted in '1:n' reference, because the foreign key is the referenced column.
+func (c *Column) Keys() []string {
+	var keys []string
+	if c.PrimaryKey {
+		keys = append(keys, "PK")
+	}
+	if c.UniqueKey {
+		keys = append(keys, "UK")
+	}
+	if c.Ref != nil && c.Ref.Relationship != RefOneToMany {
+		keys = append(keys, "FK")
+	}
+	if c.AutoIncremental {
+		keys = append(keys, "AI")
+	}
+	return keys
+}
+
 func (c *Column) Format() string {
 	if c.Size == 0 {
 		return c.Type
@@ -0,0 +1,33 @@
+package octopus
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestColumn_Keys(t *testing.T) {
+	Convey("Keys", t, func() {
+		Convey("no keys", func() {
+			So((&Column{Name: "name"}).Keys(), ShouldBeEmpty)
+		})
+
+		Convey("all keys", func() {
+			column := &Column{
+				Name:            "id",
+				PrimaryKey:      true,
+				UniqueKey:       true,
+				AutoIncremental: true,
+				Ref:             &Reference{Table: "group", Column: "id"},
+			}
+			So(column.Keys(), ShouldResemble, []string{"PK", "UK", "FK", "AI"})
+		})
+
+		Convey("1:n reference is not a foreign key", func() {
+			column := &Column{
+				Name: "id",
+				Ref:  &Reference{Table: "profile", Column: "user_id", Relationship: RefOneToMany},
+			}
+			So(column.Keys(), ShouldBeEmpty)
+		})
+	})
+}
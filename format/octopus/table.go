@@ -127,6 +127,17 @@ func (s *Schema) TableByName(name string) *Table {
 	return nil
 }
 
+// FilteredTables returns tables accepted by filter. returns all tables if filter is nil.
+func (s *Schema) FilteredTables(filter TableFilterFn) []*Table {
+	var tables []*Table
+	for _, table := range s.Tables {
+		if filter == nil || filter(table) {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
 func (s *Schema) FindReference(ref Reference) (*Table, *Column) {
 	if table := s.TableByName(ref.Table); table != nil {
 		if column := table.ColumnByName(ref.Column); column != nil {
@@ -0,0 +1,37 @@
+package octopus
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSchema_FilteredTables(t *testing.T) {
+	Convey("FilteredTables", t, func() {
+		schema := &Schema{
+			Tables: []*Table{
+				{Name: "user", Group: "common"},
+				{Name: "order", Group: "shop"},
+				{Name: "log"},
+			},
+		}
+		tableNames := func(tables []*Table) []string {
+			var names []string
+			for _, table := range tables {
+				names = append(names, table.Name)
+			}
+			return names
+		}
+
+		Convey("nil filter", func() {
+			So(tableNames(schema.FilteredTables(nil)), ShouldResemble, []string{"user", "order", "log"})
+		})
+
+		Convey("group filter", func() {
+			So(tableNames(schema.FilteredTables(GetTableFilterFn("common,shop"))), ShouldResemble, []string{"user", "order"})
+		})
+
+		Convey("no table matched", func() {
+			So(schema.FilteredTables(GetTableFilterFn("none")), ShouldBeEmpty)
+		})
+	})
+}
@@ -114,6 +114,17 @@ func IsColTypeAutoIncremental(colType string) bool {
 		})
 }
 
+// IsColTypeBlob checks if blob column type.
+func IsColTypeBlob(colType string) bool {
+	return containsColType(colType,
+		[]string{
+			ColTypeBlob8,
+			ColTypeBlob16,
+			ColTypeBlob24,
+			ColTypeBlob32,
+		})
+}
+
 // IsColTypeClob checks if clob column type.
 func IsColTypeClob(colType string) bool {
 	return containsColType(colType,
@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"bytes"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagGroups       = "groups"
+	FlagInput        = "input"
+	FlagOutput       = "output"
+	FlagPaths        = "paths"
+	FlagPrefix       = "prefix"
+	FlagRemovePrefix = "removePrefix"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	outputPath := c.String(FlagOutput)
+	var filename string
+	format := FormatYaml
+	switch strings.ToLower(filepath.Ext(outputPath)) {
+	case ".yaml", ".yml":
+		filename = outputPath
+	case ".json":
+		filename = outputPath
+		format = FormatJson
+	default:
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, "openapi.yaml")
+	}
+
+	gen := NewGenerator(schema, &Option{
+		PrefixMapper:   common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:    octopus.GetTableFilterFn(c.String(FlagGroups)),
+		RemovePrefixes: strings.Split(c.String(FlagRemovePrefix), ","),
+		Paths:          c.Bool(FlagPaths),
+		Format:         format,
+	})
+
+	buf := new(bytes.Buffer)
+	if err = gen.Generate(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate OpenAPI document to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.BoolFlag{
+		Name:    FlagPaths,
+		Usage:   "generate CRUD path stubs of each table",
+		EnvVars: []string{"OCTOPUS_PATHS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"p"},
+		Usage:   "set schema name prefix",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"r"},
+		Usage:   "set prefixes to remove from schema name. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
+}
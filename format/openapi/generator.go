@@ -0,0 +1,379 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gertd/go-pluralize"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"gopkg.in/yaml.v2"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	FormatYaml = "yaml"
+	FormatJson = "json"
+
+	schemaRefPrefix = "#/components/schemas/"
+	mediaTypeJson   = "application/json"
+)
+
+type Option struct {
+	PrefixMapper   *common.PrefixMapper
+	TableFilter    octopus.TableFilterFn
+	RemovePrefixes []string
+	Paths          bool
+	Format         string
+}
+
+type Generator struct {
+	schema *octopus.Schema
+	option *Option
+	tables []*octopus.Table
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	tables := schema.FilteredTables(option.TableFilter)
+
+	return &Generator{
+		schema: schema,
+		option: option,
+		tables: tables,
+	}
+}
+
+// trimmedTableName returns table name without prefixes to remove.
+func (g *Generator) trimmedTableName(table *octopus.Table) string {
+	tableName := table.Name
+	for _, prefix := range g.option.RemovePrefixes {
+		tableName = strings.TrimPrefix(tableName, prefix)
+	}
+	return tableName
+}
+
+// SchemaName returns component schema name from table name.
+func (g *Generator) SchemaName(table *octopus.Table) string {
+	name := table.ClassName
+	if name == "" {
+		name = strcase.ToCamel(g.trimmedTableName(table))
+
+		if prefixMapper := g.option.PrefixMapper; prefixMapper != nil {
+			if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+				name = prefix + name
+			}
+		}
+	}
+	return name
+}
+
+func (g *Generator) tableByName(tableName string) *octopus.Table {
+	for _, table := range g.tables {
+		if table.Name == tableName {
+			return table
+		}
+	}
+	return nil
+}
+
+func schemaRef(name string) *Schema {
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+// Document returns OpenAPI document of the schema.
+func (g *Generator) Document() *Document {
+	title := g.schema.Name
+	if title == "" {
+		title = "octopus"
+	}
+	version := g.schema.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	schemas := util.NewOrderedMap()
+	for _, table := range g.tables {
+		schemas.Set(g.SchemaName(table), g.tableSchema(table))
+	}
+
+	doc := &Document{
+		OpenAPI:    "3.0.3",
+		Info:       &Info{Title: title, Version: version},
+		Components: &Components{Schemas: schemas},
+	}
+	if g.option.Paths {
+		doc.Paths = g.paths()
+	}
+	return doc
+}
+
+// tableSchema returns object schema of the table.
+// references to other tables are added as '$ref' properties.
+func (g *Generator) tableSchema(table *octopus.Table) *Schema {
+	client := pluralize.NewClient()
+
+	var required []string
+	properties := util.NewOrderedMap()
+	for _, column := range table.Columns {
+		properties.Set(column.Name, ColumnSchema(column))
+		if column.NotNull {
+			required = append(required, column.Name)
+		}
+	}
+
+	for _, column := range table.Columns {
+		ref := column.Ref
+		if ref == nil {
+			continue
+		}
+		refTable := g.tableByName(ref.Table)
+		if refTable == nil {
+			continue
+		}
+		refSchemaName := g.SchemaName(refTable)
+
+		var name string
+		var property *Schema
+		if ref.Relationship == octopus.RefOneToMany {
+			name = client.Plural(strcase.ToSnake(g.trimmedTableName(refTable)))
+			property = &Schema{Type: "array", Items: schemaRef(refSchemaName)}
+		} else {
+			name = strings.TrimSuffix(column.Name, "_id")
+			if name == column.Name {
+				name = strcase.ToSnake(g.trimmedTableName(refTable))
+			}
+			property = schemaRef(refSchemaName)
+		}
+		if properties.Contains(name) {
+			name = name + "_ref"
+		}
+		properties.Set(name, property)
+	}
+
+	return &Schema{
+		Type:        "object",
+		Description: table.Description,
+		Required:    required,
+		Properties:  properties,
+	}
+}
+
+// ColumnSchema returns property schema of the column.
+func ColumnSchema(column *octopus.Column) *Schema {
+	schema := &Schema{
+		Description: column.Description,
+		Nullable:    !column.NotNull,
+		ReadOnly:    column.AutoIncremental,
+	}
+
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		schema.Type = "string"
+		schema.MaxLength = column.Size
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		schema.Type = "string"
+	case octopus.ColTypeBoolean:
+		schema.Type = "boolean"
+	case octopus.ColTypeBit:
+		if column.Size == 1 {
+			schema.Type = "boolean"
+		} else {
+			schema.Type = "integer"
+		}
+	case octopus.ColTypeInt8:
+		fallthrough
+	case octopus.ColTypeInt16:
+		fallthrough
+	case octopus.ColTypeInt24:
+		fallthrough
+	case octopus.ColTypeInt32:
+		fallthrough
+	case octopus.ColTypeYear:
+		schema.Type = "integer"
+		schema.Format = "int32"
+	case octopus.ColTypeInt64:
+		schema.Type = "integer"
+		schema.Format = "int64"
+	case octopus.ColTypeDecimal:
+		schema.Type = "number"
+		schema.Format = "decimal"
+		if column.Scale > 0 {
+			schema.MultipleOf = math.Pow10(-int(column.Scale))
+		}
+	case octopus.ColTypeFloat:
+		schema.Type = "number"
+		schema.Format = "float"
+	case octopus.ColTypeDouble:
+		schema.Type = "number"
+		schema.Format = "double"
+	case octopus.ColTypeDateTime:
+		schema.Type = "string"
+		schema.Format = "date-time"
+	case octopus.ColTypeDate:
+		schema.Type = "string"
+		schema.Format = "date"
+	case octopus.ColTypeTime:
+		schema.Type = "string"
+		schema.Format = "time"
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		schema.Type = "string"
+		schema.Format = "byte"
+	case octopus.ColTypeEnum:
+		schema.Type = "string"
+		schema.Enum = column.Values
+	case octopus.ColTypeSet:
+		schema.Type = "array"
+		schema.Items = &Schema{Type: "string", Enum: column.Values}
+	case octopus.ColTypeJSON:
+		// any type
+	default:
+		schema.Type = "string"
+	}
+	return schema
+}
+
+// paths returns CRUD path stubs of the tables.
+// item paths are generated for tables with a single primary key only.
+func (g *Generator) paths() *util.OrderedMap {
+	client := pluralize.NewClient()
+	paths := util.NewOrderedMap()
+
+	for _, table := range g.tables {
+		name := g.SchemaName(table)
+		ref := schemaRef(name)
+		tags := []string{name}
+		collectionPath := "/" + client.Plural(strcase.ToKebab(g.trimmedTableName(table)))
+		pluralName := client.Plural(name)
+
+		paths.Set(collectionPath, &PathItem{
+			Get: &Operation{
+				Tags:        tags,
+				Summary:     "List " + pluralName,
+				OperationID: "list" + pluralName,
+				Responses:   newResponses("200", newResponse("OK", &Schema{Type: "array", Items: ref}), false),
+			},
+			Post: &Operation{
+				Tags:        tags,
+				Summary:     "Create " + name,
+				OperationID: "create" + name,
+				RequestBody: newRequestBody(ref),
+				Responses:   newResponses("201", newResponse("Created", ref), false),
+			},
+		})
+
+		var pkColumns []*octopus.Column
+		for _, column := range table.Columns {
+			if column.PrimaryKey {
+				pkColumns = append(pkColumns, column)
+			}
+		}
+		if len(pkColumns) != 1 {
+			continue
+		}
+		pk := pkColumns[0]
+		pkSchema := ColumnSchema(pk)
+		parameters := []*Parameter{
+			{
+				Name:     pk.Name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: pkSchema.Type, Format: pkSchema.Format},
+			},
+		}
+
+		paths.Set(fmt.Sprintf("%s/{%s}", collectionPath, pk.Name), &PathItem{
+			Get: &Operation{
+				Tags:        tags,
+				Summary:     "Get " + name,
+				OperationID: "get" + name,
+				Parameters:  parameters,
+				Responses:   newResponses("200", newResponse("OK", ref), true),
+			},
+			Put: &Operation{
+				Tags:        tags,
+				Summary:     "Update " + name,
+				OperationID: "update" + name,
+				Parameters:  parameters,
+				RequestBody: newRequestBody(ref),
+				Responses:   newResponses("200", newResponse("OK", ref), true),
+			},
+			Delete: &Operation{
+				Tags:        tags,
+				Summary:     "Delete " + name,
+				OperationID: "delete" + name,
+				Parameters:  parameters,
+				Responses:   newResponses("204", newResponse("No Content", nil), true),
+			},
+		})
+	}
+	return paths
+}
+
+func newRequestBody(schema *Schema) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]*MediaType{mediaTypeJson: {Schema: schema}},
+	}
+}
+
+func newResponse(description string, schema *Schema) *Response {
+	response := &Response{Description: description}
+	if schema != nil {
+		response.Content = map[string]*MediaType{mediaTypeJson: {Schema: schema}}
+	}
+	return response
+}
+
+// newResponses returns responses of the success status code.
+// item responses have additional 'Not Found' response.
+func newResponses(code string, response *Response, item bool) *util.OrderedMap {
+	responses := util.NewOrderedMap()
+	responses.Set(code, response)
+	if item {
+		responses.Set("404", newResponse("Not Found", nil))
+	}
+	return responses
+}
+
+// Generate writes OpenAPI document in YAML or JSON format.
+func (g *Generator) Generate(wr io.Writer) error {
+	doc := g.Document()
+
+	switch g.option.Format {
+	case "", FormatYaml:
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		_, err = wr.Write(data)
+		return err
+	case FormatJson:
+		encoder := json.NewEncoder(wr)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	default:
+		return fmt.Errorf("unsupported format: %s", g.option.Format)
+	}
+}
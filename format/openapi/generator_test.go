@@ -0,0 +1,381 @@
+package openapi
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Name:    "sample",
+	Version: "1.0",
+	Tables: []*octopus.Table{
+		{
+			Name:  "group",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:    "name",
+					Type:    octopus.ColTypeVarchar,
+					Size:    40,
+					NotNull: true,
+				},
+			},
+		},
+		{
+			Name:        "user",
+			Description: "User table",
+			Group:       "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:        "name",
+					Type:        octopus.ColTypeVarchar,
+					Size:        40,
+					NotNull:     true,
+					Description: "login name",
+				},
+				{
+					Name:    "class",
+					Type:    octopus.ColTypeEnum,
+					Values:  []string{"A", "B"},
+					NotNull: true,
+				},
+				{
+					Name:   "roles",
+					Type:   octopus.ColTypeSet,
+					Values: []string{"admin", "user"},
+				},
+				{
+					Name:  "point",
+					Type:  octopus.ColTypeDecimal,
+					Size:  10,
+					Scale: 2,
+				},
+				{
+					Name:    "created_at",
+					Type:    octopus.ColTypeDateTime,
+					NotNull: true,
+				},
+				{
+					Name: "group_id",
+					Type: octopus.ColTypeInt64,
+					Ref: &octopus.Reference{
+						Table:  "group",
+						Column: "id",
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestGenerator_Schemas(t *testing.T) {
+	Convey("Schemas", t, func() {
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper("common:C"),
+		}
+		expected := `openapi: 3.0.3
+info:
+  title: sample
+  version: "1.0"
+components:
+  schemas:
+    CGroup:
+      type: object
+      required:
+      - id
+      - name
+      properties:
+        id:
+          type: integer
+          format: int64
+          readOnly: true
+        name:
+          type: string
+          maxLength: 40
+    CUser:
+      type: object
+      description: User table
+      required:
+      - id
+      - name
+      - class
+      - created_at
+      properties:
+        id:
+          type: integer
+          format: int64
+          readOnly: true
+        name:
+          type: string
+          description: login name
+          maxLength: 40
+        class:
+          type: string
+          enum:
+          - A
+          - B
+        roles:
+          type: array
+          items:
+            type: string
+            enum:
+            - admin
+            - user
+          nullable: true
+        point:
+          type: number
+          format: decimal
+          multipleOf: 0.01
+          nullable: true
+        created_at:
+          type: string
+          format: date-time
+        group_id:
+          type: integer
+          format: int64
+          nullable: true
+        group:
+          $ref: '#/components/schemas/CGroup'
+`
+
+		buf := new(bytes.Buffer)
+		err := NewGenerator(testSchema, option).Generate(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_Paths(t *testing.T) {
+	Convey("Paths", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{testSchema.Tables[0]},
+		}
+		option := &Option{
+			Paths:  true,
+			Format: FormatJson,
+		}
+		expected := `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "octopus",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/groups": {
+      "get": {
+        "tags": [
+          "Group"
+        ],
+        "summary": "List Groups",
+        "operationId": "listGroups",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/components/schemas/Group"
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "Group"
+        ],
+        "summary": "Create Group",
+        "operationId": "createGroup",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Group"
+              }
+            }
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "Created",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Group"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/groups/{id}": {
+      "get": {
+        "tags": [
+          "Group"
+        ],
+        "summary": "Get Group",
+        "operationId": "getGroup",
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "format": "int64"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Group"
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found"
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "Group"
+        ],
+        "summary": "Update Group",
+        "operationId": "updateGroup",
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "format": "int64"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "$ref": "#/components/schemas/Group"
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Group"
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found"
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "Group"
+        ],
+        "summary": "Delete Group",
+        "operationId": "deleteGroup",
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "format": "int64"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "No Content"
+          },
+          "404": {
+            "description": "Not Found"
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Group": {
+        "type": "object",
+        "required": [
+          "id",
+          "name"
+        ],
+        "properties": {
+          "id": {
+            "type": "integer",
+            "format": "int64",
+            "readOnly": true
+          },
+          "name": {
+            "type": "string",
+            "maxLength": 40
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+		buf := new(bytes.Buffer)
+		err := NewGenerator(schema, option).Generate(buf)
+		So(err, ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,74 @@
+package openapi
+
+import "github.com/lechuckroh/octopus-db-tools/util"
+
+// Document is an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string           `yaml:"openapi" json:"openapi"`
+	Info       *Info            `yaml:"info" json:"info"`
+	Paths      *util.OrderedMap `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Components *Components      `yaml:"components" json:"components"`
+}
+
+type Info struct {
+	Title   string `yaml:"title" json:"title"`
+	Version string `yaml:"version" json:"version"`
+}
+
+type Components struct {
+	Schemas *util.OrderedMap `yaml:"schemas" json:"schemas"`
+}
+
+// Schema is an OpenAPI schema object.
+type Schema struct {
+	Ref         string           `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Type        string           `yaml:"type,omitempty" json:"type,omitempty"`
+	Format      string           `yaml:"format,omitempty" json:"format,omitempty"`
+	Description string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Enum        []string         `yaml:"enum,omitempty" json:"enum,omitempty"`
+	Items       *Schema          `yaml:"items,omitempty" json:"items,omitempty"`
+	MaxLength   uint16           `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	MultipleOf  float64          `yaml:"multipleOf,omitempty" json:"multipleOf,omitempty"`
+	Nullable    bool             `yaml:"nullable,omitempty" json:"nullable,omitempty"`
+	ReadOnly    bool             `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+	Required    []string         `yaml:"required,omitempty" json:"required,omitempty"`
+	Properties  *util.OrderedMap `yaml:"properties,omitempty" json:"properties,omitempty"`
+}
+
+// PathItem is an OpenAPI path item object.
+type PathItem struct {
+	Get    *Operation `yaml:"get,omitempty" json:"get,omitempty"`
+	Put    *Operation `yaml:"put,omitempty" json:"put,omitempty"`
+	Post   *Operation `yaml:"post,omitempty" json:"post,omitempty"`
+	Delete *Operation `yaml:"delete,omitempty" json:"delete,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string         `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Summary     string           `yaml:"summary,omitempty" json:"summary,omitempty"`
+	OperationID string           `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Parameters  []*Parameter     `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody *RequestBody     `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Responses   *util.OrderedMap `yaml:"responses" json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `yaml:"name" json:"name"`
+	In       string  `yaml:"in" json:"in"`
+	Required bool    `yaml:"required" json:"required"`
+	Schema   *Schema `yaml:"schema" json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `yaml:"required" json:"required"`
+	Content  map[string]*MediaType `yaml:"content" json:"content"`
+}
+
+type Response struct {
+	Description string                `yaml:"description" json:"description"`
+	Content     map[string]*MediaType `yaml:"content,omitempty" json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema" json:"schema"`
+}
@@ -10,10 +10,15 @@ import (
 )
 
 const (
-	FlagInput  = "input"
-	FlagOutput = "output"
+	FlagGroups      = "groups"
+	FlagInput       = "input"
+	FlagOutput      = "output"
+	FlagSplitGroups = "splitGroups"
 )
 
+// defaultGroupFilename is the filename of tables without group in split groups mode.
+const defaultGroupFilename = "default"
+
 func Action(c *cli.Context) error {
 	schema, err := octopus.LoadSchema(c.String(FlagInput))
 	if err != nil {
@@ -22,10 +27,16 @@ func Action(c *cli.Context) error {
 
 	gen := Generator{
 		schema: schema,
-		option: &Option{},
+		option: &Option{
+			TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		},
 	}
 
 	outputPath := c.String(FlagOutput)
+	if c.Bool(FlagSplitGroups) {
+		return generateGroups(&gen, outputPath)
+	}
+
 	extSet := util.NewStringSet(".wsd", ".pu", ".puml", ".plantuml", ".iuml")
 	var filename string
 	if ext := strings.ToLower(filepath.Ext(outputPath)); extSet.Contains(ext) {
@@ -47,6 +58,29 @@ func Action(c *cli.Context) error {
 	return util.WriteStringToFile(filename, buf.String())
 }
 
+// generateGroups writes a file per group to outputPath directory.
+// Group names are converted to safe filenames, and duplicated filenames get numeric suffix.
+func generateGroups(gen *Generator, outputPath string) error {
+	if _, err := util.Mkdir(outputPath); err != nil {
+		return err
+	}
+
+	groups := gen.Groups()
+	filenames := util.GroupFilenames(groups, defaultGroupFilename)
+	for _, group := range groups {
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateGroup(buf, group); err != nil {
+			return err
+		}
+
+		filename := filepath.Join(outputPath, filenames[group]+".plantuml")
+		if err := util.WriteStringToFile(filename, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var CliFlags = []cli.Flag{
 	&cli.StringFlag{
 		Name:     FlagInput,
@@ -62,4 +96,16 @@ var CliFlags = []cli.Flag{
 		EnvVars:  []string{"OCTOPUS_OUTPUT"},
 		Required: true,
 	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagSplitGroups,
+		Aliases: []string{"s"},
+		Usage:   "generate a file per table group to output directory",
+		EnvVars: []string{"OCTOPUS_SPLIT_GROUPS"},
+	},
 }
@@ -3,11 +3,13 @@ package plantuml
 import (
 	"fmt"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
 	"io"
 	"strings"
 )
 
 type Option struct {
+	TableFilter octopus.TableFilterFn
 }
 
 type Generator struct {
@@ -15,29 +17,84 @@ type Generator struct {
 	option *Option
 }
 
+// Groups returns group names of filtered tables in schema order.
+func (c *Generator) Groups() []string {
+	var groups []string
+	groupSet := util.NewStringSet()
+	for _, table := range c.schema.FilteredTables(c.option.TableFilter) {
+		if !groupSet.Contains(table.Group) {
+			groupSet.Add(table.Group)
+			groups = append(groups, table.Group)
+		}
+	}
+	return groups
+}
+
+// Generate writes all filtered tables.
 func (c *Generator) Generate(wr io.Writer) error {
+	return c.generate(wr, c.schema.FilteredTables(c.option.TableFilter))
+}
+
+// GenerateGroup writes filtered tables of the group.
+// Tables of other groups referenced by the group are written as stubs.
+func (c *Generator) GenerateGroup(wr io.Writer, group string) error {
+	var tables []*octopus.Table
+	for _, table := range c.schema.FilteredTables(c.option.TableFilter) {
+		if table.Group == group {
+			tables = append(tables, table)
+		}
+	}
+	return c.generate(wr, tables)
+}
+
+func (c *Generator) generate(wr io.Writer, tables []*octopus.Table) error {
 	var result []string
 	var refs []string
 
 	result = append(result, "@startuml")
-	for _, table := range c.schema.Tables {
-		result = append(result, fmt.Sprintf("entity %s {", table.Name))
 
-		separatorAdded := false
-		for _, column := range table.Columns {
-			if !column.PrimaryKey && !separatorAdded {
-				separatorAdded = true
-				result = append(result, "    --")
+	tableNames := util.NewStringSet()
+	var groups []string
+	tablesByGroup := make(map[string][]*octopus.Table)
+	for _, table := range tables {
+		tableNames.Add(table.Name)
+		if _, ok := tablesByGroup[table.Group]; !ok {
+			groups = append(groups, table.Group)
+		}
+		tablesByGroup[table.Group] = append(tablesByGroup[table.Group], table)
+	}
+
+	for _, group := range groups {
+		indent := ""
+		if group != "" {
+			result = append(result, fmt.Sprintf("package %q {", group))
+			indent = "    "
+		}
+		for _, table := range tablesByGroup[group] {
+			for _, line := range getTableLines(table) {
+				result = append(result, indent+line)
 			}
-			result = append(result, fmt.Sprintf("    %s", getColumnDef(column)))
+		}
+		if group != "" {
+			result = append(result, "}")
+		}
+	}
 
-			// Reference
-			if ref := column.Ref; ref != nil {
-				relationship := getRelationshipType(ref)
-				refs = append(refs, fmt.Sprintf("%s %s %s", table.Name, relationship, ref.Table))
+	// references. referenced tables which are not written are added as stubs.
+	stubSet := util.NewStringSet()
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			ref := column.Ref
+			if ref == nil {
+				continue
 			}
+			if !tableNames.Contains(ref.Table) && !stubSet.Contains(ref.Table) {
+				stubSet.Add(ref.Table)
+				result = append(result, fmt.Sprintf("entity %s <<external>>", ref.Table))
+			}
+			relationship := getRelationshipType(ref)
+			refs = append(refs, fmt.Sprintf("%s %s %s", table.Name, relationship, ref.Table))
 		}
-		result = append(result, "}")
 	}
 
 	result = append(result, refs...)
@@ -47,12 +104,51 @@ func (c *Generator) Generate(wr io.Writer) error {
 	return err
 }
 
-func getTableDef(table *octopus.Table) string {
+// getTableLines returns entity lines of the table, followed by a note of descriptions.
+func getTableLines(table *octopus.Table) []string {
+	result := []string{fmt.Sprintf("entity %s {", table.Name)}
+
+	separatorAdded := false
+	for _, column := range table.Columns {
+		if !column.PrimaryKey && !separatorAdded {
+			separatorAdded = true
+			result = append(result, "    --")
+		}
+		result = append(result, fmt.Sprintf("    %s", getColumnDef(column)))
+	}
+
+	if len(table.Indices) > 0 {
+		result = append(result, "    ..")
+		for _, index := range table.Indices {
+			result = append(result, fmt.Sprintf("    %s: (%s) <<index>>", index.Name, strings.Join(index.Columns, ", ")))
+		}
+	}
+	result = append(result, "}")
+
+	// descriptions
+	var noteLines []string
 	if table.Description != "" {
-		return fmt.Sprintf("%s # %s", table.Name, table.Description)
-	} else {
-		return table.Name
+		noteLines = append(noteLines, table.Description)
+	}
+	var columnNoteLines []string
+	for _, column := range table.Columns {
+		if column.Description != "" {
+			columnNoteLines = append(columnNoteLines, fmt.Sprintf("%s: %s", column.Name, column.Description))
+		}
+	}
+	if len(noteLines) > 0 && len(columnNoteLines) > 0 {
+		noteLines = append(noteLines, "--")
+	}
+	noteLines = append(noteLines, columnNoteLines...)
+
+	if len(noteLines) > 0 {
+		result = append(result, fmt.Sprintf("note right of %s", table.Name))
+		for _, line := range noteLines {
+			result = append(result, "    "+line)
+		}
+		result = append(result, "end note")
 	}
+	return result
 }
 
 func getColumnDef(col *octopus.Column) string {
@@ -68,7 +164,7 @@ func getColumnDef(col *octopus.Column) string {
 		line += " <<PK>>"
 	}
 	if col.UniqueKey {
-		line += " <<UQ>>"
+		line += " <<UK>>"
 	}
 	if col.Ref != nil {
 		line += " <<FK>>"
@@ -0,0 +1,168 @@
+package plantuml
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:        "user",
+			Group:       "common",
+			Description: "User table",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{Name: "name", Type: "varchar", UniqueKey: true, NotNull: true, Description: "login name"},
+				{Name: "group_id", Type: "int64", Ref: &octopus.Reference{Table: "group", Column: "id"}},
+			},
+			Indices: []*octopus.Index{
+				{Name: "idx_group", Columns: []string{"group_id", "name"}},
+			},
+		},
+		{
+			Name:  "group",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+			},
+		},
+		{
+			Name:  "order",
+			Group: "shop",
+			Columns: []*octopus.Column{
+				{Name: "id", Type: "int64", PrimaryKey: true, NotNull: true},
+				{
+					Name: "user_id",
+					Type: "int64",
+					Ref:  &octopus.Reference{Table: "user", Column: "id", Relationship: octopus.RefOneToOne},
+				},
+			},
+		},
+		{
+			Name: "log",
+			Columns: []*octopus.Column{
+				{Name: "message", Type: "text"},
+			},
+		},
+	},
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	Convey("Generate", t, func() {
+		expected := strings.Join([]string{
+			"@startuml",
+			`package "common" {`,
+			"    entity user {",
+			"        * id: int64 <<PK>>",
+			"        --",
+			"        * name: varchar <<UK>>",
+			"        group_id: int64 <<FK>>",
+			"        ..",
+			"        idx_group: (group_id, name) <<index>>",
+			"    }",
+			"    note right of user",
+			"        User table",
+			"        --",
+			"        name: login name",
+			"    end note",
+			"    entity group {",
+			"        * id: int64 <<PK>>",
+			"    }",
+			"}",
+			`package "shop" {`,
+			"    entity order {",
+			"        * id: int64 <<PK>>",
+			"        --",
+			"        user_id: int64 <<FK>>",
+			"    }",
+			"}",
+			"entity log {",
+			"    --",
+			"    message: text",
+			"}",
+			"user }o-|| group",
+			"order ||-|| user",
+			"@enduml",
+		}, "\n")
+
+		gen := Generator{schema: testSchema, option: &Option{}}
+		buf := new(bytes.Buffer)
+		So(gen.Generate(buf), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("GenerateGroup", t, func() {
+		expected := strings.Join([]string{
+			"@startuml",
+			`package "shop" {`,
+			"    entity order {",
+			"        * id: int64 <<PK>>",
+			"        --",
+			"        user_id: int64 <<FK>>",
+			"    }",
+			"}",
+			"entity user <<external>>",
+			"order ||-|| user",
+			"@enduml",
+		}, "\n")
+
+		gen := Generator{
+			schema: testSchema,
+			option: &Option{TableFilter: octopus.GetTableFilterFn("common,shop")},
+		}
+		So(gen.Groups(), ShouldResemble, []string{"common", "shop"})
+
+		buf := new(bytes.Buffer)
+		So(gen.GenerateGroup(buf, "shop"), ShouldBeNil)
+
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_GenerateGroups(t *testing.T) {
+	Convey("Generate groups with unsafe and duplicated names", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{Name: "user", Group: "default", Columns: []*octopus.Column{{Name: "id", Type: "int64"}}},
+				{Name: "order", Group: "../evil", Columns: []*octopus.Column{{Name: "id", Type: "int64"}}},
+				{Name: "log", Columns: []*octopus.Column{{Name: "id", Type: "int64"}}},
+			},
+		}
+
+		dir, err := ioutil.TempDir("", "plantuml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		gen := Generator{schema: schema, option: &Option{}}
+		So(generateGroups(&gen, dir), ShouldBeNil)
+
+		files, err := ioutil.ReadDir(dir)
+		So(err, ShouldBeNil)
+		var filenames []string
+		for _, file := range files {
+			filenames = append(filenames, file.Name())
+		}
+		sort.Strings(filenames)
+		So(filenames, ShouldResemble, []string{".._evil.plantuml", "default.plantuml", "default_2.plantuml"})
+	})
+}
@@ -6,17 +6,26 @@ import (
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
 	"github.com/urfave/cli/v2"
+	"log"
+	"path/filepath"
 	"strings"
 )
 
 const (
+	FlagCheck            = "check"
+	FlagDecimal          = "decimal"
 	FlagGoPackage        = "goPackage"
 	FlagGroups           = "groups"
+	FlagGrpc             = "grpc"
 	FlagInput            = "input"
+	FlagLock             = "lock"
+	FlagLockFile         = "lockFile"
+	FlagNullable         = "nullable"
 	FlagOutput           = "output"
 	FlagPackage          = "package"
 	FlagPrefix           = "prefix"
 	FlagRemovePrefix     = "removePrefix"
+	FlagTemplate         = "template"
 	FlogRelationTagDecr  = "relationTagDecr"
 	FlogRelationTagStart = "relationTagStart"
 )
@@ -27,6 +36,21 @@ func Action(c *cli.Context) error {
 		return err
 	}
 
+	outputPath := c.String(FlagOutput)
+
+	// tag lock is used only if requested, so that existing proto files are not renumbered on upgrade.
+	check := c.Bool(FlagCheck)
+	var lock *Lock
+	lockFile := c.String(FlagLockFile)
+	if lockFile == "" {
+		lockFile = filepath.Join(filepath.Dir(outputPath), DefaultLockFilename)
+	}
+	if c.Bool(FlagLock) || c.IsSet(FlagLockFile) || check {
+		if lock, err = LoadLock(lockFile); err != nil {
+			return err
+		}
+	}
+
 	gen := newGenerator(
 		schema,
 		&Option{
@@ -35,9 +59,15 @@ func Action(c *cli.Context) error {
 			RemovePrefixes:   strings.Split(c.String(FlagRemovePrefix), ","),
 			Package:          c.String(FlagPackage),
 			GoPackage:        c.String(FlagGoPackage),
-			FilePath:         c.String(FlagOutput),
+			FilePath:         outputPath,
 			RelationTagStart: -1,
 			RelationTagDecr:  false,
+			Template:         c.String(FlagTemplate),
+			Lock:             lock,
+			Check:            check,
+			Nullable:         c.String(FlagNullable),
+			Decimal:          c.String(FlagDecimal),
+			Grpc:             c.Bool(FlagGrpc),
 		},
 	)
 	buf := new(bytes.Buffer)
@@ -45,8 +75,19 @@ func Action(c *cli.Context) error {
 		return err
 	}
 
+	if check {
+		log.Printf("No wire-breaking changes found")
+		return nil
+	}
+
 	// write to file
-	return util.WriteStringToFile(c.String(FlagOutput), buf.String())
+	if err = util.WriteStringToFile(outputPath, buf.String()); err != nil {
+		return err
+	}
+	if lock != nil {
+		return lock.Save(lockFile)
+	}
+	return nil
 }
 
 var CliFlags = []cli.Flag{
@@ -64,6 +105,17 @@ var CliFlags = []cli.Flag{
 		EnvVars:  []string{"OCTOPUS_OUTPUT"},
 		Required: true,
 	},
+	&cli.BoolFlag{
+		Name:    FlagCheck,
+		Usage:   "check wire-breaking changes against tag lock file without writing files",
+		EnvVars: []string{"OCTOPUS_CHECK"},
+	},
+	&cli.StringFlag{
+		Name:    FlagDecimal,
+		Usage:   "set decimal field type. 'double', 'string' or message type name. ex: google.type.Decimal",
+		Value:   DecimalDouble,
+		EnvVars: []string{"OCTOPUS_DECIMAL"},
+	},
 	&cli.StringFlag{
 		Name:    FlagGoPackage,
 		Usage:   "set go package name",
@@ -75,6 +127,27 @@ var CliFlags = []cli.Flag{
 		Usage:   "filter table groups to generate. set multiple values with comma separated.",
 		EnvVars: []string{"OCTOPUS_GROUPS"},
 	},
+	&cli.BoolFlag{
+		Name:    FlagGrpc,
+		Usage:   "generate gRPC CRUD service of each message",
+		EnvVars: []string{"OCTOPUS_GRPC"},
+	},
+	&cli.BoolFlag{
+		Name:    FlagLock,
+		Usage:   "pin field tags with tag lock file",
+		EnvVars: []string{"OCTOPUS_LOCK"},
+	},
+	&cli.StringFlag{
+		Name:    FlagLockFile,
+		Aliases: []string{"l"},
+		Usage:   "read/write tag lock from `FILE`. implies --lock. default: '.octopus-pb.lock' in the output directory",
+		EnvVars: []string{"OCTOPUS_LOCK_FILE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagNullable,
+		Usage:   "set nullable scalar field mapping. 'wrapper' or 'optional'",
+		EnvVars: []string{"OCTOPUS_NULLABLE"},
+	},
 	&cli.StringFlag{
 		Name:    FlagPackage,
 		Aliases: []string{"p"},
@@ -93,6 +166,12 @@ var CliFlags = []cli.Flag{
 		Usage:   "set prefixes to remove from message name. set multiple values with comma separated.",
 		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
 	},
+	&cli.StringFlag{
+		Name:    FlagTemplate,
+		Aliases: []string{"t"},
+		Usage:   "read custom proto template from `FILE`",
+		EnvVars: []string{"OCTOPUS_TEMPLATE"},
+	},
 	&cli.BoolFlag{
 		Name:    FlogRelationTagDecr,
 		Usage:   "set relation tags decremental from `relationTagStart`",
@@ -8,25 +8,128 @@ import (
 	"github.com/lechuckroh/octopus-db-tools/util"
 	"io"
 	"log"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
 type ProtoFieldRule string
 
+// Nullable column mapping modes
+const (
+	NullableWrapper  = "wrapper"
+	NullableOptional = "optional"
+)
+
+// Decimal column mapping types. other values are used as message type name.
+const (
+	DecimalDouble = "double"
+	DecimalString = "string"
+)
+
+// wrapperTypes maps scalar types to well-known wrapper types.
+var wrapperTypes = map[string]string{
+	"string": "google.protobuf.StringValue",
+	"bool":   "google.protobuf.BoolValue",
+	"int32":  "google.protobuf.Int32Value",
+	"int64":  "google.protobuf.Int64Value",
+	"float":  "google.protobuf.FloatValue",
+	"double": "google.protobuf.DoubleValue",
+	"bytes":  "google.protobuf.BytesValue",
+}
+
+// wellKnownImports maps well-known message types to import files.
+var wellKnownImports = map[string]string{
+	"google.type.Decimal": "google/type/decimal.proto",
+}
+
 const (
 	Required ProtoFieldRule = "required"
 	Optional ProtoFieldRule = "optional"
 	Repeated ProtoFieldRule = "repeated"
 )
 
+const (
+	// ProtoTemplate is the default template to generate proto file.
+	// Template is executed with TplData.
+	ProtoTemplate = `{{"" -}}
+syntax = "proto3";
+
+{{if .Package}}package {{.Package}};{{end}}
+{{range .Options}}
+option {{.}};
+{{end}}
+{{- range .Imports}}
+import "{{.}}";
+{{end}}
+
+{{- range .Enums}}
+enum {{.Name}} {
+  {{- if .Reserved}}
+  reserved {{joinTags .Reserved}};
+  {{- end}}
+  {{- if .ReservedNames}}
+  reserved {{quoteJoin .ReservedNames}};
+  {{- end}}
+  {{- range .Values}}
+  {{.Name}} = {{.Number}};
+  {{- end}}
+}
+{{end}}
+{{- range .Messages}}
+message {{.Name}} {
+  {{- if .Reserved}}
+  reserved {{joinTags .Reserved}};
+  {{- end}}
+  {{- if .ReservedNames}}
+  reserved {{quoteJoin .ReservedNames}};
+  {{- end}}
+  {{- range .Fields}}
+  {{with .Rule}}{{.}} {{end}}{{.Type}} {{.Name}} = {{.Tag}};
+  {{- end}}
+  {{- range .Relations}}
+  {{if .Repeated}}repeated {{end}}{{.Type}} {{.Name}} = {{.Tag}};
+  {{- end}}
+}
+{{end}}
+{{- range .Services}}
+service {{.Name}} {
+  {{- range .Methods}}
+  rpc {{.Name}}({{.Request}}) returns ({{.Response}});
+  {{- end}}
+}
+{{range .Messages}}
+message {{.Name}} {
+  {{- range .Fields}}
+  {{with .Rule}}{{.}} {{end}}{{.Type}} {{.Name}} = {{.Tag}};
+  {{- end}}
+}
+{{end}}
+{{- end}}`
+)
+
+// TplData is the data passed to the proto template.
+type TplData struct {
+	Package  string
+	Options  []string
+	Imports  []string
+	Enums    []*PbEnum
+	Messages []*PbMessage
+	Services []*PbService
+}
+
+// PbMessage is a proto message generated from a table.
 type PbMessage struct {
-	Name      string
-	Fields    []*PbField
-	Relations []*PbRelation
-	Imports   []string
+	Name          string
+	Fields        []*PbField
+	Relations     []*PbRelation
+	Imports       []string
+	Reserved      []int
+	ReservedNames []string
+	Enums         []*PbEnum
 }
 
+// PbField is a message field generated from a column.
 type PbField struct {
 	Type    string
 	Name    string
@@ -37,6 +140,20 @@ type PbField struct {
 	Import  string
 }
 
+// PbEnum is a proto enum generated from values of enum/set column.
+type PbEnum struct {
+	Name          string
+	Values        []*PbEnumValue
+	Reserved      []int
+	ReservedNames []string
+}
+
+type PbEnumValue struct {
+	Name   string
+	Number int
+}
+
+// PbRelation is a message field generated from a column reference.
 type PbRelation struct {
 	Type     string
 	Name     string
@@ -53,6 +170,12 @@ type Option struct {
 	FilePath         string
 	RelationTagStart int
 	RelationTagDecr  bool
+	Template         string
+	Lock             *Lock
+	Check            bool
+	Nullable         string
+	Decimal          string
+	Grpc             bool
 }
 
 type Generator struct {
@@ -80,46 +203,59 @@ func (t *Generator) Generate(wr io.Writer) error {
 	goPkg := option.GoPackage
 
 	var pbMessages []*PbMessage
+	var pbServices []*PbService
+	var breakingChanges []string
 	for _, table := range t.schema.Tables {
 		if option.TableFilter != nil && !option.TableFilter(table) {
 			continue
 		}
-		pbMessages = append(pbMessages, t.newPbMessage(table))
+		message := t.newPbMessage(table)
+		if option.Lock != nil {
+			breakingChanges = append(breakingChanges, option.Lock.Apply(message)...)
+		}
+		pbMessages = append(pbMessages, message)
+		if option.Grpc {
+			pbServices = append(pbServices, t.newPbService(message, table))
+		}
 	}
 
-	return t.generateProto(wr, pbMessages, pkg, goPkg)
+	if len(breakingChanges) > 0 {
+		if option.Check {
+			return fmt.Errorf("wire-breaking changes found:\n  %s", strings.Join(breakingChanges, "\n  "))
+		}
+		for _, change := range breakingChanges {
+			log.Printf("[WARN] wire-breaking change: %s", change)
+		}
+	}
+
+	return t.generateProto(wr, pbMessages, pbServices, pkg, goPkg)
 }
 
 func (t *Generator) generateProto(
 	wr io.Writer,
 	messages []*PbMessage,
+	services []*PbService,
 	pkg string,
 	goPkg string,
 ) error {
 	// custom functions
-	funcMap := template.FuncMap{}
-
-	tplText := `{{"" -}}
-syntax = "proto3";
-
-{{if .Package}}package {{.Package}};{{end}}
-{{range .Options}}
-option {{.}};
-{{end}}
-{{- range .Imports}}
-import "{{.}}";
-{{end}}
+	funcMap := template.FuncMap{
+		"joinTags": func(tags []int) string {
+			var values []string
+			for _, tag := range tags {
+				values = append(values, strconv.Itoa(tag))
+			}
+			return strings.Join(values, ", ")
+		},
+		"quoteJoin": func(names []string) string {
+			return util.QuoteAndJoin(names, "\"", ", ")
+		},
+	}
 
-{{- range .Messages}}
-message {{.Name}} {
-  {{- range .Fields}}
-  {{.Type}} {{.Name}} = {{.Tag}};
-  {{- end}}
-  {{- range .Relations}}
-  {{.Type}} {{.Name}} = {{.Tag}};
-  {{- end}}
-}
-{{end}}`
+	tplText, err := util.ReadTemplateText(t.option.Template, ProtoTemplate)
+	if err != nil {
+		return err
+	}
 
 	// parse template
 	tmpl, err := util.NewTemplate("protobuf", tplText, funcMap)
@@ -129,8 +265,13 @@ message {{.Name}} {
 
 	// import
 	imports := util.NewStringSet()
+	var enums []*PbEnum
 	for _, message := range messages {
 		imports.AddAll(message.Imports)
+		enums = append(enums, message.Enums...)
+	}
+	for _, service := range services {
+		imports.AddAll(service.Imports)
 	}
 
 	// options
@@ -139,16 +280,13 @@ message {{.Name}} {
 		options = append(options, fmt.Sprintf("go_package = \"%s\"", goPkg))
 	}
 
-	data := struct {
-		Package  string
-		Options  []string
-		Imports  []string
-		Messages []*PbMessage
-	}{
+	data := TplData{
 		Package:  pkg,
 		Options:  options,
 		Imports:  imports.Slice(),
+		Enums:    enums,
 		Messages: messages,
+		Services: services,
 	}
 	return tmpl.Execute(wr, &data)
 }
@@ -171,19 +309,25 @@ func (t *Generator) getMessageName(table *octopus.Table) string {
 }
 
 func (t *Generator) newPbMessage(table *octopus.Table) *PbMessage {
+	messageName := t.getMessageName(table)
+
 	// field list
 	imports := util.NewStringSet()
 	var fields []*PbField
+	var enums []*PbEnum
 	var relations []*PbRelation
 	maxTag := 0
 	for i, column := range table.Columns {
 		tag := i + 1
 		maxTag = tag
-		field := newPbField(tag, column)
+		field, enum := t.newPbField(tag, column, messageName)
 		fields = append(fields, field)
 		if imp := field.Import; imp != "" {
 			imports.Add(imp)
 		}
+		if enum != nil {
+			enums = append(enums, enum)
+		}
 
 		// reference
 		if ref := column.Ref; ref != nil {
@@ -222,16 +366,19 @@ func (t *Generator) newPbMessage(table *octopus.Table) *PbMessage {
 	}
 
 	return &PbMessage{
-		Name:      t.getMessageName(table),
+		Name:      messageName,
 		Fields:    fields,
 		Relations: relations,
 		Imports:   imports.Slice(),
+		Enums:     enums,
 	}
 }
 
-func newPbField(tag int, column *octopus.Column) *PbField {
+func (t *Generator) newPbField(tag int, column *octopus.Column, messageName string) (*PbField, *PbEnum) {
 	var fieldType string
 	var imp string
+	var rule ProtoFieldRule
+	var enum *PbEnum
 
 	columnType := strings.ToLower(column.Type)
 	switch columnType {
@@ -246,9 +393,19 @@ func newPbField(tag int, column *octopus.Column) *PbField {
 	case octopus.ColTypeText24:
 		fallthrough
 	case octopus.ColTypeText32:
+		fallthrough
+	case octopus.ColTypeTime:
+		fallthrough
+	case octopus.ColTypeJSON:
 		fieldType = "string"
 	case octopus.ColTypeBoolean:
 		fieldType = "bool"
+	case octopus.ColTypeBit:
+		if column.Size == 1 {
+			fieldType = "bool"
+		} else {
+			fieldType = "int64"
+		}
 	case octopus.ColTypeInt64:
 		fieldType = "int64"
 	case octopus.ColTypeInt8:
@@ -258,30 +415,105 @@ func newPbField(tag int, column *octopus.Column) *PbField {
 	case octopus.ColTypeInt24:
 		fallthrough
 	case octopus.ColTypeInt32:
+		fallthrough
+	case octopus.ColTypeYear:
 		fieldType = "int32"
 	case octopus.ColTypeDecimal:
-		fieldType = "double"
+		switch decimal := t.option.Decimal; decimal {
+		case "", DecimalDouble:
+			fieldType = "double"
+		case DecimalString:
+			fieldType = "string"
+		default:
+			fieldType = decimal
+			imp = wellKnownImports[decimal]
+		}
 	case octopus.ColTypeFloat:
 		fieldType = "float"
 	case octopus.ColTypeDouble:
 		fieldType = "double"
 	case octopus.ColTypeDateTime:
+		fallthrough
+	case octopus.ColTypeDate:
 		fieldType = "google.protobuf.Timestamp"
 		imp = "google/protobuf/timestamp.proto"
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		fieldType = "bytes"
+	case octopus.ColTypeEnum:
+		fallthrough
+	case octopus.ColTypeSet:
+		fieldType = "string"
+		if len(column.Values) > 0 {
+			enum = newPbEnum(messageName+strcase.ToCamel(column.Name), column.Values)
+			fieldType = enum.Name
+		}
+		if columnType == octopus.ColTypeSet {
+			rule = Repeated
+		}
 	default:
 		fieldType = column.Type
 		log.Printf("unsupported columnType: %s", columnType)
 	}
 
+	// nullable
+	if !column.NotNull && !column.PrimaryKey && rule == "" {
+		switch t.option.Nullable {
+		case NullableWrapper:
+			if wrapper, ok := wrapperTypes[fieldType]; ok {
+				fieldType = wrapper
+				imp = "google/protobuf/wrappers.proto"
+			}
+		case NullableOptional:
+			if !strings.HasPrefix(fieldType, "google.protobuf.") {
+				rule = Optional
+			}
+		}
+	}
+
 	fieldName, _ := util.ToLowerCamel(column.Name)
 
 	return &PbField{
 		Type:    fieldType,
 		Name:    fieldName,
 		Tag:     tag,
-		Rule:    Optional,
+		Rule:    rule,
 		Comment: column.Description,
 		Default: column.DefaultValue,
 		Import:  imp,
+	}, enum
+}
+
+// newPbEnum returns proto3 enum of values.
+// the first value is 'UNSPECIFIED' with number 0, and every value is prefixed with enum name.
+// values colliding after conversion get numeric suffix. e.g. 'in-active', 'in_active' -> IN_ACTIVE, IN_ACTIVE_2
+func newPbEnum(name string, values []string) *PbEnum {
+	prefix := strcase.ToScreamingSnake(name) + "_"
+	unspecified := prefix + "UNSPECIFIED"
+	enumValues := []*PbEnumValue{{Name: unspecified, Number: 0}}
+	valueNameSet := util.NewStringSet(unspecified)
+	for i, value := range values {
+		valueName := prefix + strcase.ToScreamingSnake(value)
+		for suffix := 2; valueNameSet.Contains(valueName); suffix++ {
+			valueName = fmt.Sprintf("%s%s_%d", prefix, strcase.ToScreamingSnake(value), suffix)
+		}
+		valueNameSet.Add(valueName)
+		enumValues = append(enumValues, &PbEnumValue{
+			Name:   valueName,
+			Number: i + 1,
+		})
+	}
+	return &PbEnum{
+		Name:   name,
+		Values: enumValues,
 	}
 }
@@ -6,7 +6,9 @@ import (
 	"github.com/lechuckroh/octopus-db-tools/format/common"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
 	"log"
+	"os"
 	"testing"
 )
 
@@ -272,3 +274,207 @@ message Group {
 		So(actual, ShouldEqual, expected)
 	})
 }
+
+func TestProtobufTpl_Grpc(t *testing.T) {
+	Convey("gRPC", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:       "id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+							NotNull:    true,
+						},
+						{
+							Name: "name",
+							Type: octopus.ColTypeVarchar,
+						},
+						{
+							Name:    "class",
+							Type:    octopus.ColTypeEnum,
+							Values:  []string{"a", "in-use"},
+							NotNull: true,
+						},
+						{
+							Name:   "roles",
+							Type:   octopus.ColTypeSet,
+							Values: []string{"admin", "user"},
+						},
+						{
+							Name: "point",
+							Type: octopus.ColTypeDecimal,
+						},
+						{
+							Name: "birthday",
+							Type: octopus.ColTypeDate,
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			RelationTagStart: -1,
+			Nullable:         NullableWrapper,
+			Decimal:          DecimalString,
+			Grpc:             true,
+		}
+		expected := `syntax = "proto3";
+
+
+
+import "google/protobuf/empty.proto";
+
+import "google/protobuf/field_mask.proto";
+
+import "google/protobuf/timestamp.proto";
+
+import "google/protobuf/wrappers.proto";
+
+enum UserClass {
+  USER_CLASS_UNSPECIFIED = 0;
+  USER_CLASS_A = 1;
+  USER_CLASS_IN_USE = 2;
+}
+
+enum UserRoles {
+  USER_ROLES_UNSPECIFIED = 0;
+  USER_ROLES_ADMIN = 1;
+  USER_ROLES_USER = 2;
+}
+
+message User {
+  int64 id = 1;
+  google.protobuf.StringValue name = 2;
+  UserClass class = 3;
+  repeated UserRoles roles = 4;
+  google.protobuf.StringValue point = 5;
+  google.protobuf.Timestamp birthday = 6;
+}
+
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User);
+  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse);
+  rpc CreateUser(CreateUserRequest) returns (User);
+  rpc UpdateUser(UpdateUserRequest) returns (User);
+  rpc DeleteUser(DeleteUserRequest) returns (google.protobuf.Empty);
+}
+
+message GetUserRequest {
+  int64 id = 1;
+}
+
+message ListUsersRequest {
+  int32 pageSize = 1;
+  string pageToken = 2;
+}
+
+message ListUsersResponse {
+  repeated User users = 1;
+  string nextPageToken = 2;
+}
+
+message CreateUserRequest {
+  User user = 1;
+}
+
+message UpdateUserRequest {
+  User user = 1;
+  google.protobuf.FieldMask updateMask = 2;
+}
+
+message DeleteUserRequest {
+  int64 id = 1;
+}
+`
+
+		buf := new(bytes.Buffer)
+		gen := newGenerator(schema, option)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestProtobufTpl_Template(t *testing.T) {
+	Convey("Custom template", t, func() {
+		tplFile, err := ioutil.TempFile("", "protobuf-*.tpl")
+		So(err, ShouldBeNil)
+		defer os.Remove(tplFile.Name())
+
+		tplText := `{{"" -}}
+package {{.Package}};
+{{range .Messages}}
+// {{.Name}}
+{{- range .Fields}}
+{{.Name}}: {{.Type}} = {{.Tag}}
+{{- end}}
+{{end -}}
+`
+		_, err = tplFile.WriteString(tplText)
+		So(err, ShouldBeNil)
+		So(tplFile.Close(), ShouldBeNil)
+
+		option := &Option{
+			PrefixMapper:     common.NewPrefixMapper(""),
+			Package:          "octopus",
+			RelationTagStart: 100,
+			Template:         tplFile.Name(),
+			TableFilter: func(table *octopus.Table) bool {
+				return table.Group == "group"
+			},
+		}
+		expected := `package octopus;
+
+// Group
+id: int64 = 1
+name: string = 2
+createdAt: google.protobuf.Timestamp = 3
+updatedAt: google.protobuf.Timestamp = 4
+`
+
+		buf := new(bytes.Buffer)
+		gen := newGenerator(protobufTplTestSchema, option)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("Template file not found", t, func() {
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper(""),
+			Template:     "not-found.tpl",
+		}
+		gen := newGenerator(protobufTplTestSchema, option)
+		So(gen.Generate(new(bytes.Buffer)), ShouldNotBeNil)
+	})
+}
+
+func TestProtobufTpl_EnumValueCollision(t *testing.T) {
+	Convey("newPbEnum", t, func() {
+		enum := newPbEnum("UserStatus", []string{"in-active", "in_active", "unspecified"})
+
+		var names []string
+		for _, value := range enum.Values {
+			names = append(names, value.Name)
+		}
+		So(names, ShouldResemble, []string{
+			"USER_STATUS_UNSPECIFIED",
+			"USER_STATUS_IN_ACTIVE",
+			"USER_STATUS_IN_ACTIVE_2",
+			"USER_STATUS_UNSPECIFIED_2",
+		})
+	})
+}
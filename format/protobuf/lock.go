@@ -0,0 +1,294 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// DefaultLockFilename is the filename of tag lock file.
+const DefaultLockFilename = ".octopus-pb.lock"
+
+// compatibleTypeGroups are groups of scalar types which are wire-compatible with each other.
+var compatibleTypeGroups = [][]string{
+	{"int32", "uint32", "int64", "uint64", "bool"},
+	{"sint32", "sint64"},
+	{"string", "bytes"},
+	{"fixed32", "sfixed32"},
+	{"fixed64", "sfixed64"},
+}
+
+// Lock pins field tags of each message and value numbers of each enum,
+// so that they are not changed when columns or enum values are added or removed.
+type Lock struct {
+	Messages map[string]*LockMessage `json:"messages"`
+	Enums    map[string]*LockEnum    `json:"enums,omitempty"`
+}
+
+type LockMessage struct {
+	Fields        map[string]*LockField `json:"fields"`
+	Reserved      []int                 `json:"reserved,omitempty"`
+	ReservedNames []string              `json:"reservedNames,omitempty"`
+}
+
+type LockField struct {
+	Tag  int    `json:"tag"`
+	Type string `json:"type"`
+}
+
+type LockEnum struct {
+	Values        map[string]int `json:"values"`
+	Reserved      []int          `json:"reserved,omitempty"`
+	ReservedNames []string       `json:"reservedNames,omitempty"`
+}
+
+func NewLock() *Lock {
+	return &Lock{
+		Messages: make(map[string]*LockMessage),
+		Enums:    make(map[string]*LockEnum),
+	}
+}
+
+// LoadLock reads lock file. returns empty lock if the file does not exist.
+func LoadLock(filename string) (*Lock, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewLock(), nil
+		}
+		return nil, err
+	}
+
+	lock := NewLock()
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, err
+	}
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]*LockMessage)
+	}
+	if lock.Enums == nil {
+		lock.Enums = make(map[string]*LockEnum)
+	}
+	return lock, nil
+}
+
+// Save writes lock file.
+func (l *Lock) Save(filename string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return util.WriteBytesToFile(filename, append(data, '\n'))
+}
+
+// lockEntry is a message field to lock.
+type lockEntry struct {
+	name      string
+	fieldType string
+	tag       *int
+}
+
+// Apply updates field tags of the message and value numbers of its enums with locked ones.
+// new fields are assigned with unused tags, and removed fields are reserved.
+// returns wire-breaking changes.
+func (l *Lock) Apply(message *PbMessage) []string {
+	var breakingChanges []string
+	for _, enum := range message.Enums {
+		breakingChanges = append(breakingChanges, l.applyEnum(enum)...)
+	}
+	lm := l.Messages[message.Name]
+	if lm == nil {
+		lm = &LockMessage{Fields: make(map[string]*LockField)}
+		l.Messages[message.Name] = lm
+	}
+	if lm.Fields == nil {
+		lm.Fields = make(map[string]*LockField)
+	}
+
+	var entries []*lockEntry
+	for _, field := range message.Fields {
+		fieldType := field.Type
+		if field.Rule == Repeated {
+			fieldType = "repeated " + fieldType
+		}
+		entries = append(entries, &lockEntry{name: field.Name, fieldType: fieldType, tag: &field.Tag})
+	}
+	for _, relation := range message.Relations {
+		fieldType := relation.Type
+		if relation.Repeated {
+			fieldType = "repeated " + fieldType
+		}
+		entries = append(entries, &lockEntry{name: relation.Name, fieldType: fieldType, tag: &relation.Tag})
+	}
+
+	// reserve removed fields
+	nameSet := util.NewStringSet()
+	for _, entry := range entries {
+		nameSet.Add(entry.name)
+	}
+	var removedNames []string
+	for name := range lm.Fields {
+		if !nameSet.Contains(name) {
+			removedNames = append(removedNames, name)
+		}
+	}
+	sort.Strings(removedNames)
+	for _, name := range removedNames {
+		lm.Reserved = append(lm.Reserved, lm.Fields[name].Tag)
+		lm.ReservedNames = append(lm.ReservedNames, name)
+		delete(lm.Fields, name)
+	}
+
+	usedTags := make(map[int]bool)
+	for _, tag := range lm.Reserved {
+		usedTags[tag] = true
+	}
+
+	// locked fields
+	var newEntries []*lockEntry
+	for _, entry := range entries {
+		lf, ok := lm.Fields[entry.name]
+		if !ok {
+			newEntries = append(newEntries, entry)
+			continue
+		}
+		*entry.tag = lf.Tag
+		usedTags[lf.Tag] = true
+		if !isCompatibleType(lf.Type, entry.fieldType) {
+			breakingChanges = append(breakingChanges, fmt.Sprintf("%s.%s: type changed from '%s' to '%s'",
+				message.Name, entry.name, lf.Type, entry.fieldType))
+		}
+		lf.Type = entry.fieldType
+	}
+
+	// new fields keep generated tag if not used
+	for _, entry := range newEntries {
+		tag := *entry.tag
+		if tag < 1 {
+			tag = 1
+		}
+		for usedTags[tag] {
+			tag++
+		}
+		*entry.tag = tag
+		usedTags[tag] = true
+		lm.Fields[entry.name] = &LockField{Tag: tag, Type: entry.fieldType}
+	}
+
+	// re-added field names are not reserved anymore
+	var reservedNames []string
+	for _, name := range lm.ReservedNames {
+		if !nameSet.Contains(name) {
+			reservedNames = append(reservedNames, name)
+		}
+	}
+	sort.Ints(lm.Reserved)
+	sort.Strings(reservedNames)
+	lm.ReservedNames = reservedNames
+
+	message.Reserved = lm.Reserved
+	message.ReservedNames = lm.ReservedNames
+	return breakingChanges
+}
+
+// applyEnum updates value numbers of the enum with locked numbers.
+// new values are assigned with unused numbers, and removed values are reserved.
+// returns renumbered values, which happens only if the locked number is already used by another value.
+func (l *Lock) applyEnum(enum *PbEnum) []string {
+	le := l.Enums[enum.Name]
+	if le == nil {
+		le = &LockEnum{Values: make(map[string]int)}
+		l.Enums[enum.Name] = le
+	}
+	if le.Values == nil {
+		le.Values = make(map[string]int)
+	}
+
+	// reserve removed values
+	nameSet := util.NewStringSet()
+	for _, value := range enum.Values {
+		nameSet.Add(value.Name)
+	}
+	var removedNames []string
+	for name := range le.Values {
+		if !nameSet.Contains(name) {
+			removedNames = append(removedNames, name)
+		}
+	}
+	sort.Strings(removedNames)
+	for _, name := range removedNames {
+		le.Reserved = append(le.Reserved, le.Values[name])
+		le.ReservedNames = append(le.ReservedNames, name)
+		delete(le.Values, name)
+	}
+
+	usedNumbers := make(map[int]bool)
+	for _, number := range le.Reserved {
+		usedNumbers[number] = true
+	}
+
+	// locked values
+	var newValues []*PbEnumValue
+	for _, value := range enum.Values {
+		number, ok := le.Values[value.Name]
+		if !ok {
+			newValues = append(newValues, value)
+			continue
+		}
+		if usedNumbers[number] {
+			// locked number is used by another value. e.g. lock file edited by hand
+			newValues = append(newValues, value)
+			continue
+		}
+		value.Number = number
+		usedNumbers[number] = true
+	}
+
+	// new values keep generated number if not used
+	var breakingChanges []string
+	for _, value := range newValues {
+		number := value.Number
+		for usedNumbers[number] {
+			number++
+		}
+		if locked, ok := le.Values[value.Name]; ok {
+			breakingChanges = append(breakingChanges, fmt.Sprintf("%s.%s: number changed from %d to %d",
+				enum.Name, value.Name, locked, number))
+		}
+		value.Number = number
+		usedNumbers[number] = true
+		le.Values[value.Name] = number
+	}
+
+	// re-added value names are not reserved anymore
+	var reservedNames []string
+	for _, name := range le.ReservedNames {
+		if !nameSet.Contains(name) {
+			reservedNames = append(reservedNames, name)
+		}
+	}
+	sort.Ints(le.Reserved)
+	sort.Strings(reservedNames)
+	le.ReservedNames = reservedNames
+
+	enum.Reserved = le.Reserved
+	enum.ReservedNames = le.ReservedNames
+	return breakingChanges
+}
+
+// isCompatibleType returns true if field of oldType can be decoded as newType.
+func isCompatibleType(oldType, newType string) bool {
+	if oldType == newType {
+		return true
+	}
+	for _, group := range compatibleTypeGroups {
+		set := util.NewStringSet(group...)
+		if set.Contains(oldType) && set.Contains(newType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package protobuf
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func TestLock_Apply(t *testing.T) {
+	Convey("Lock", t, func() {
+		lock := NewLock()
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true},
+						{Name: "name", Type: octopus.ColTypeVarchar},
+						{Name: "age", Type: octopus.ColTypeInt32},
+						{Name: "email", Type: octopus.ColTypeVarchar},
+					},
+				},
+			},
+		}
+		generate := func(option *Option) (string, error) {
+			buf := new(bytes.Buffer)
+			err := newGenerator(schema, option).Generate(buf)
+			return buf.String(), err
+		}
+
+		_, err := generate(&Option{RelationTagStart: -1, Lock: lock})
+		So(err, ShouldBeNil)
+		So(lock.Messages["User"].Fields["email"].Tag, ShouldEqual, 4)
+
+		// insert 'nickname', remove 'age', change type of 'email'
+		schema.Tables[0].Columns = []*octopus.Column{
+			{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true},
+			{Name: "nickname", Type: octopus.ColTypeVarchar},
+			{Name: "name", Type: octopus.ColTypeVarchar},
+			{Name: "email", Type: octopus.ColTypeInt32},
+		}
+
+		Convey("Check", func() {
+			_, err := generate(&Option{RelationTagStart: -1, Lock: lock, Check: true})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "wire-breaking changes found:\n  User.email: type changed from 'string' to 'int32'")
+		})
+
+		Convey("Generate", func() {
+			schema.Tables[0].Columns[3].Type = octopus.ColTypeVarchar
+			expected := `syntax = "proto3";
+
+
+
+message User {
+  reserved 3;
+  reserved "age";
+  int64 id = 1;
+  string nickname = 5;
+  string name = 2;
+  string email = 4;
+}
+`
+
+			actual, err := generate(&Option{RelationTagStart: -1, Lock: lock, Check: true})
+			So(err, ShouldBeNil)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+	})
+}
+
+func TestLock_ApplyEnum(t *testing.T) {
+	Convey("Lock enum", t, func() {
+		lock := NewLock()
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true},
+						{Name: "status", Type: octopus.ColTypeEnum, Values: []string{"active", "inactive"}},
+					},
+				},
+			},
+		}
+		generate := func(option *Option) (string, error) {
+			buf := new(bytes.Buffer)
+			err := newGenerator(schema, option).Generate(buf)
+			return buf.String(), err
+		}
+
+		_, err := generate(&Option{RelationTagStart: -1, Lock: lock})
+		So(err, ShouldBeNil)
+		So(lock.Enums["UserStatus"].Values["USER_STATUS_INACTIVE"], ShouldEqual, 2)
+
+		// insert 'pending', remove 'inactive'
+		schema.Tables[0].Columns[1].Values = []string{"pending", "active"}
+
+		Convey("Generate", func() {
+			expected := `syntax = "proto3";
+
+
+
+enum UserStatus {
+  reserved 2;
+  reserved "USER_STATUS_INACTIVE";
+  USER_STATUS_UNSPECIFIED = 0;
+  USER_STATUS_PENDING = 3;
+  USER_STATUS_ACTIVE = 1;
+}
+
+message User {
+  int64 id = 1;
+  UserStatus status = 2;
+}
+`
+
+			actual, err := generate(&Option{RelationTagStart: -1, Lock: lock, Check: true})
+			So(err, ShouldBeNil)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("Check", func() {
+			// locked number is taken by another value
+			lock.Enums["UserStatus"].Values["USER_STATUS_ACTIVE"] = 0
+			_, err := generate(&Option{RelationTagStart: -1, Lock: lock, Check: true})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "wire-breaking changes found:\n  UserStatus.USER_STATUS_ACTIVE: number changed from 0 to 3")
+		})
+	})
+}
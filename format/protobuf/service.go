@@ -0,0 +1,87 @@
+package protobuf
+
+import (
+	"github.com/gertd/go-pluralize"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+)
+
+// PbService is a gRPC CRUD service of a message.
+type PbService struct {
+	Name     string
+	Methods  []*PbMethod
+	Messages []*PbMessage
+	Imports  []string
+}
+
+// PbMethod is a rpc method of a service.
+type PbMethod struct {
+	Name     string
+	Request  string
+	Response string
+}
+
+// newPbService returns '{message}Service' with Get/List/Create/Update/Delete rpc methods.
+// Get/Update/Delete methods are generated only if the table has primary keys.
+func (t *Generator) newPbService(message *PbMessage, table *octopus.Table) *PbService {
+	name := message.Name
+	plural := pluralize.NewClient().Plural(name)
+
+	var keyFields []*PbField
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			field, _ := t.newPbField(len(keyFields)+1, column, name)
+			field.Rule = ""
+			keyFields = append(keyFields, field)
+		}
+	}
+	hasKey := len(keyFields) > 0
+
+	service := &PbService{Name: name + "Service"}
+	addMethod := func(method string, response string, fields ...*PbField) {
+		request := method + "Request"
+		service.Methods = append(service.Methods, &PbMethod{
+			Name:     method,
+			Request:  request,
+			Response: response,
+		})
+		service.Messages = append(service.Messages, &PbMessage{
+			Name:   request,
+			Fields: fields,
+		})
+	}
+	messageField := &PbField{Type: name, Name: strcase.ToLowerCamel(name), Tag: 1}
+
+	if hasKey {
+		addMethod("Get"+name, name, keyFields...)
+	}
+
+	listResponse := "List" + plural + "Response"
+	addMethod("List"+plural, listResponse,
+		&PbField{Type: "int32", Name: "pageSize", Tag: 1},
+		&PbField{Type: "string", Name: "pageToken", Tag: 2},
+	)
+	service.Messages = append(service.Messages, &PbMessage{
+		Name: listResponse,
+		Fields: []*PbField{
+			{Type: name, Name: strcase.ToLowerCamel(plural), Tag: 1, Rule: Repeated},
+			{Type: "string", Name: "nextPageToken", Tag: 2},
+		},
+	})
+
+	addMethod("Create"+name, name, messageField)
+
+	if hasKey {
+		addMethod("Update"+name, name,
+			messageField,
+			&PbField{Type: "google.protobuf.FieldMask", Name: "updateMask", Tag: 2},
+		)
+		addMethod("Delete"+name, "google.protobuf.Empty", keyFields...)
+		service.Imports = []string{
+			"google/protobuf/empty.proto",
+			"google/protobuf/field_mask.proto",
+		}
+	}
+
+	return service
+}
@@ -0,0 +1,95 @@
+package pydantic
+
+import (
+	"bytes"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FlagDataclass    = "dataclass"
+	FlagGroups       = "groups"
+	FlagInput        = "input"
+	FlagOutput       = "output"
+	FlagPrefix       = "prefix"
+	FlagRemovePrefix = "removePrefix"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	gen := NewGenerator(schema, &Option{
+		PrefixMapper:   common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:    octopus.GetTableFilterFn(c.String(FlagGroups)),
+		RemovePrefixes: strings.Split(c.String(FlagRemovePrefix), ","),
+		Dataclass:      c.Bool(FlagDataclass),
+	})
+
+	outputPath := c.String(FlagOutput)
+	var filename string
+	if ext := strings.ToLower(filepath.Ext(outputPath)); ext == ".py" {
+		filename = outputPath
+	} else {
+		// ensure directory is created
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		filename = filepath.Join(outputPath, "models.py")
+	}
+
+	buf := new(bytes.Buffer)
+	if err = gen.Generate(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteStringToFile(filename, buf.String())
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate python file to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.BoolFlag{
+		Name:    FlagDataclass,
+		Aliases: []string{"d"},
+		Usage:   "generate @dataclass instead of pydantic BaseModel",
+		EnvVars: []string{"OCTOPUS_DATACLASS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"p"},
+		Usage:   "set class name prefix",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"r"},
+		Usage:   "set prefixes to remove from class name. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
+}
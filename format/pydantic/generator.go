@@ -0,0 +1,168 @@
+package pydantic
+
+import (
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	// PyTemplate is the default template to generate python file.
+	// Template is executed with TplData.
+	PyTemplate = `{{"" -}}
+{{range .StdImports}}{{.}}
+{{end}}
+{{- if .ThirdPartyImports}}{{if .StdImports}}
+{{end}}{{range .ThirdPartyImports}}{{.}}
+{{end}}{{end}}
+{{- range .Classes}}
+
+{{if $.Dataclass}}@dataclass
+class {{.Name}}:{{else}}class {{.Name}}(BaseModel):{{end}}
+{{- if .Comment}}
+    """{{.DocString}}"""
+{{- end}}
+{{- range .Fields}}
+{{- if $.Dataclass}}
+{{- if .Comment}}{{range .CommentLines}}
+    # {{.}}{{end}}
+{{- end}}
+    {{.Name}}: {{.Type}}
+{{- else}}
+    {{.Name}}: {{.Type}}{{with .PydanticValue}} = {{.}}{{end}}
+{{- end}}
+{{- end}}
+{{- if not .Fields}}
+    pass
+{{- end}}
+{{end}}`
+)
+
+type Option struct {
+	PrefixMapper   *common.PrefixMapper
+	TableFilter    octopus.TableFilterFn
+	RemovePrefixes []string
+	Dataclass      bool
+}
+
+// TplData is the data passed to the python template.
+type TplData struct {
+	Dataclass         bool
+	StdImports        []string
+	ThirdPartyImports []string
+	Classes           []*PyClass
+}
+
+type Generator struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	return &Generator{
+		schema: schema,
+		option: option,
+	}
+}
+
+// ClassName returns class name from table name.
+func (g *Generator) ClassName(table *octopus.Table) string {
+	name := table.ClassName
+	if name == "" {
+		tableName := table.Name
+		for _, prefix := range g.option.RemovePrefixes {
+			tableName = strings.TrimPrefix(tableName, prefix)
+		}
+		name = strcase.ToCamel(tableName)
+
+		if prefixMapper := g.option.PrefixMapper; prefixMapper != nil {
+			if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+				name = prefix + name
+			}
+		}
+	}
+	return name
+}
+
+func (g *Generator) Generate(wr io.Writer) error {
+	tableFilter := g.option.TableFilter
+
+	var classes []*PyClass
+	importSet := util.NewStringSet()
+	if g.option.Dataclass {
+		importSet.Add("dataclasses.dataclass")
+	} else {
+		importSet.Add("pydantic.BaseModel")
+	}
+
+	for _, table := range g.schema.Tables {
+		if tableFilter != nil && !tableFilter(table) {
+			continue
+		}
+
+		var fields []*PyField
+		for _, column := range table.Columns {
+			field := NewPyField(column)
+			fields = append(fields, field)
+			importSet.AddAll(field.Imports)
+			if !g.option.Dataclass && len(field.FieldArgs()) > 0 {
+				importSet.Add("pydantic.Field")
+			}
+		}
+
+		classes = append(classes, &PyClass{
+			table:   table,
+			Name:    g.ClassName(table),
+			Comment: table.Description,
+			Fields:  fields,
+		})
+	}
+
+	tmpl, err := util.NewTemplate("python", PyTemplate, template.FuncMap{})
+	if err != nil {
+		return err
+	}
+
+	stdImports, thirdPartyImports := importLines(importSet.Slice())
+
+	return tmpl.Execute(wr, &TplData{
+		Dataclass:         g.option.Dataclass,
+		StdImports:        stdImports,
+		ThirdPartyImports: thirdPartyImports,
+		Classes:           classes,
+	})
+}
+
+// importLines converts '{module}.{name}' imports to 'from {module} import {names}' lines.
+// standard library imports and third party imports are returned separately.
+func importLines(imports []string) ([]string, []string) {
+	namesByModule := make(map[string][]string)
+	var modules []string
+	for _, imp := range imports {
+		idx := strings.LastIndex(imp, ".")
+		module, name := imp[:idx], imp[idx+1:]
+		if _, ok := namesByModule[module]; !ok {
+			modules = append(modules, module)
+		}
+		namesByModule[module] = append(namesByModule[module], name)
+	}
+	sort.Strings(modules)
+
+	var stdImports []string
+	var thirdPartyImports []string
+	for _, module := range modules {
+		line := fmt.Sprintf("from %s import %s", module, strings.Join(namesByModule[module], ", "))
+		if module == "pydantic" {
+			thirdPartyImports = append(thirdPartyImports, line)
+		} else {
+			stdImports = append(stdImports, line)
+		}
+	}
+	return stdImports, thirdPartyImports
+}
@@ -0,0 +1,207 @@
+package pydantic
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:        "user",
+			Description: "User table",
+			Group:       "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:        "userName",
+					Type:        octopus.ColTypeVarchar,
+					Size:        40,
+					NotNull:     true,
+					Description: "user's login name",
+				},
+				{
+					Name:    "class",
+					Type:    octopus.ColTypeEnum,
+					Values:  []string{"A", "B"},
+					NotNull: true,
+				},
+				{
+					Name:   "roles",
+					Type:   octopus.ColTypeSet,
+					Values: []string{"admin", "user"},
+				},
+				{
+					Name:  "point",
+					Type:  octopus.ColTypeDecimal,
+					Size:  10,
+					Scale: 2,
+				},
+				{
+					Name: "props",
+					Type: octopus.ColTypeJSON,
+				},
+				{
+					Name:    "created_at",
+					Type:    octopus.ColTypeDateTime,
+					NotNull: true,
+				},
+			},
+		},
+	},
+}
+
+func TestGenerator_Pydantic(t *testing.T) {
+	Convey("Pydantic", t, func() {
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper("common:C"),
+		}
+		expected := `from datetime import datetime
+from decimal import Decimal
+from typing import Any, List, Literal, Optional
+
+from pydantic import BaseModel, Field
+
+
+class CUser(BaseModel):
+    """User table"""
+    id: int
+    user_name: str = Field(..., alias='userName', max_length=40, description='user\'s login name')
+    class_: Literal['A', 'B'] = Field(..., alias='class')
+    roles: Optional[List[Literal['admin', 'user']]] = None
+    point: Optional[Decimal] = Field(None, max_digits=10, decimal_places=2)
+    props: Optional[Any] = None
+    created_at: datetime
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(testSchema, option)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_Dataclass(t *testing.T) {
+	Convey("Dataclass", t, func() {
+		option := &Option{
+			PrefixMapper: common.NewPrefixMapper("common:C"),
+			Dataclass:    true,
+		}
+		expected := `from dataclasses import dataclass
+from datetime import datetime
+from decimal import Decimal
+from typing import Any, List, Literal, Optional
+
+
+@dataclass
+class CUser:
+    """User table"""
+    id: int
+    # user's login name
+    user_name: str
+    class_: Literal['A', 'B']
+    roles: Optional[List[Literal['admin', 'user']]]
+    point: Optional[Decimal]
+    props: Optional[Any]
+    created_at: datetime
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(testSchema, option)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_MultilineComment(t *testing.T) {
+	schema := &octopus.Schema{
+		Tables: []*octopus.Table{
+			{
+				Name:        "note",
+				Description: "Note \"\"\"table\"\"\"\nsecond line \"",
+				Columns: []*octopus.Column{
+					{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+					{Name: "kind", Type: octopus.ColTypeEnum, Values: []string{"a\nb"}, NotNull: true},
+					{Name: "body", Type: octopus.ColTypeText16, NotNull: true, Description: "first\nsecond"},
+				},
+			},
+		},
+	}
+
+	Convey("Pydantic", t, func() {
+		expected := `from typing import Literal
+
+from pydantic import BaseModel, Field
+
+
+class Note(BaseModel):
+    """Note \"\"\"table\"\"\"
+    second line \""""
+    id: int
+    kind: Literal['a\nb']
+    body: str = Field(..., description='first\nsecond')
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(schema, &Option{})
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+
+	Convey("Dataclass", t, func() {
+		expected := `from dataclasses import dataclass
+from typing import Literal
+
+
+@dataclass
+class Note:
+    """Note \"\"\"table\"\"\"
+    second line \""""
+    id: int
+    kind: Literal['a\nb']
+    # first
+    # second
+    body: str
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(schema, &Option{Dataclass: true})
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,230 @@
+package pydantic
+
+import (
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"strings"
+)
+
+type PyClass struct {
+	table   *octopus.Table
+	Name    string
+	Comment string
+	Fields  []*PyField
+}
+
+type PyField struct {
+	Column   *octopus.Column
+	Name     string
+	Alias    string
+	Type     string
+	Nullable bool
+	Comment  string
+	Imports  []string
+}
+
+// pyType is a python type mapped from column type.
+type pyType struct {
+	Type    string
+	Imports []string
+}
+
+func NewPyField(column *octopus.Column) *PyField {
+	fieldName := strcase.ToSnake(column.Name)
+	ok := fieldName == column.Name
+
+	// check python reserved words
+	reservedWord := util.IsPythonReservedWord(fieldName)
+	if reservedWord {
+		fieldName = fieldName + "_"
+	}
+
+	var alias string
+	if reservedWord || !ok {
+		alias = column.Name
+	}
+
+	pt := toPyType(column)
+	fieldType := pt.Type
+	imports := pt.Imports
+	if !column.NotNull {
+		fieldType = fmt.Sprintf("Optional[%s]", fieldType)
+		imports = append(imports, "typing.Optional")
+	}
+
+	return &PyField{
+		Column:   column,
+		Name:     fieldName,
+		Alias:    alias,
+		Type:     fieldType,
+		Nullable: !column.NotNull,
+		Comment:  column.Description,
+		Imports:  imports,
+	}
+}
+
+// toPyType returns python type of column.
+// imports are formatted as '{module}.{name}'.
+func toPyType(column *octopus.Column) *pyType {
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		fallthrough
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		return &pyType{Type: "str"}
+	case octopus.ColTypeBoolean:
+		return &pyType{Type: "bool"}
+	case octopus.ColTypeBit:
+		if column.Size == 1 {
+			return &pyType{Type: "bool"}
+		}
+		return &pyType{Type: "int"}
+	case octopus.ColTypeInt8:
+		fallthrough
+	case octopus.ColTypeInt16:
+		fallthrough
+	case octopus.ColTypeInt24:
+		fallthrough
+	case octopus.ColTypeInt32:
+		fallthrough
+	case octopus.ColTypeInt64:
+		fallthrough
+	case octopus.ColTypeYear:
+		return &pyType{Type: "int"}
+	case octopus.ColTypeDecimal:
+		return &pyType{Type: "Decimal", Imports: []string{"decimal.Decimal"}}
+	case octopus.ColTypeFloat:
+		fallthrough
+	case octopus.ColTypeDouble:
+		return &pyType{Type: "float"}
+	case octopus.ColTypeDateTime:
+		return &pyType{Type: "datetime", Imports: []string{"datetime.datetime"}}
+	case octopus.ColTypeDate:
+		return &pyType{Type: "date", Imports: []string{"datetime.date"}}
+	case octopus.ColTypeTime:
+		return &pyType{Type: "time", Imports: []string{"datetime.time"}}
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		return &pyType{Type: "bytes"}
+	case octopus.ColTypeEnum:
+		if len(column.Values) == 0 {
+			return &pyType{Type: "str"}
+		}
+		return &pyType{
+			Type:    toLiteral(column.Values),
+			Imports: []string{"typing.Literal"},
+		}
+	case octopus.ColTypeSet:
+		if len(column.Values) == 0 {
+			return &pyType{Type: "List[str]", Imports: []string{"typing.List"}}
+		}
+		return &pyType{
+			Type:    fmt.Sprintf("List[%s]", toLiteral(column.Values)),
+			Imports: []string{"typing.List", "typing.Literal"},
+		}
+	default:
+		return &pyType{Type: "Any", Imports: []string{"typing.Any"}}
+	}
+}
+
+func toLiteral(values []string) string {
+	var quoted []string
+	for _, value := range values {
+		quoted = append(quoted, quote(value))
+	}
+	return fmt.Sprintf("Literal[%s]", strings.Join(quoted, ", "))
+}
+
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+// quote returns single-quoted python string literal.
+func quote(s string) string {
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+// DocString returns the class comment to be placed in triple-quoted docstring.
+// lines after the first line are indented to the class body.
+func (c *PyClass) DocString() string {
+	s := strings.ReplaceAll(c.Comment, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"""`, `\"\"\"`)
+	if strings.HasSuffix(s, `"`) {
+		s = strings.TrimSuffix(s, `"`) + `\"`
+	}
+	return strings.Join(splitLines(s), "\n    ")
+}
+
+// CommentLines returns lines of the field comment. each line is written as '#' comment.
+func (f *PyField) CommentLines() []string {
+	return splitLines(f.Comment)
+}
+
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.ReplaceAll(s, "\r", "\n"), "\n")
+}
+
+// FieldArgs returns pydantic 'Field()' arguments of the field.
+// returns nil if 'Field()' is not required.
+func (f *PyField) FieldArgs() []string {
+	column := f.Column
+
+	var args []string
+	if f.Alias != "" {
+		args = append(args, "alias="+quote(f.Alias))
+	}
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		if column.Size > 0 {
+			args = append(args, fmt.Sprintf("max_length=%d", column.Size))
+		}
+	case octopus.ColTypeDecimal:
+		if column.Size > 0 {
+			args = append(args, fmt.Sprintf("max_digits=%d", column.Size))
+		}
+		if column.Scale > 0 {
+			args = append(args, fmt.Sprintf("decimal_places=%d", column.Scale))
+		}
+	}
+	if f.Comment != "" {
+		args = append(args, "description="+quote(f.Comment))
+	}
+	return args
+}
+
+// PydanticValue returns default value expression of pydantic field.
+// returns empty string if the field is required and has no 'Field()' arguments.
+func (f *PyField) PydanticValue() string {
+	args := f.FieldArgs()
+	if len(args) == 0 {
+		if f.Nullable {
+			return "None"
+		}
+		return ""
+	}
+
+	defaultValue := "..."
+	if f.Nullable {
+		defaultValue = "None"
+	}
+	return fmt.Sprintf("Field(%s)", strings.Join(append([]string{defaultValue}, args...), ", "))
+}
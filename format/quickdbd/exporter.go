@@ -3,11 +3,16 @@ package quickdbd
 import (
 	"fmt"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
 	"io"
 	"strings"
 )
 
+// groupDirective is a comment line which sets group of the following tables.
+const groupDirective = "# group:"
+
 type ExportOption struct {
+	TableFilter octopus.TableFilterFn
 }
 
 type Exporter struct {
@@ -15,14 +20,41 @@ type Exporter struct {
 	option *ExportOption
 }
 
+func NewExporter(schema *octopus.Schema, option *ExportOption) *Exporter {
+	return &Exporter{schema: schema, option: option}
+}
+
 func (c *Exporter) Export(wr io.Writer) error {
+	tables := c.schema.FilteredTables(c.option.TableFilter)
+	tableNames := util.NewStringSet()
+	for _, table := range tables {
+		tableNames.Add(table.Name)
+	}
+
 	var result []string
-	for _, table := range c.schema.Tables {
-		result = append(result, getTableDef(table))
+	group := ""
+	for _, table := range tables {
+		if table.Group != group {
+			result = append(result, strings.TrimSpace(groupDirective+" "+table.Group))
+			group = table.Group
+		}
+
+		tableDef := table.Name
+		if table.ClassName != "" {
+			tableDef += " as " + table.ClassName
+		}
+		result = append(result, withDescription(tableDef, table.Description)...)
 		result = append(result, strings.Repeat("-", len(table.Name)))
 
+		indexedColumns := util.NewStringSet()
+		for _, index := range table.Indices {
+			if len(index.Columns) == 1 {
+				indexedColumns.Add(index.Columns[0])
+			}
+		}
 		for _, column := range table.Columns {
-			result = append(result, getColumnDef(column))
+			columnDef := getColumnDef(column, indexedColumns.Contains(column.Name), tableNames)
+			result = append(result, withDescription(columnDef, column.Description)...)
 		}
 		result = append(result, "")
 	}
@@ -31,17 +63,26 @@ func (c *Exporter) Export(wr io.Writer) error {
 	return err
 }
 
-func getTableDef(table *octopus.Table) string {
-	if table.Description != "" {
-		return fmt.Sprintf("%s # %s", table.Name, table.Description)
-	} else {
-		return table.Name
+// withDescription appends description to the definition as a comment.
+// multi-line description is written as comment lines above the definition.
+func withDescription(def string, description string) []string {
+	if description == "" {
+		return []string{def}
+	}
+	if !strings.Contains(description, "\n") {
+		return []string{fmt.Sprintf("%s # %s", def, description)}
+	}
+
+	var result []string
+	for _, line := range strings.Split(description, "\n") {
+		result = append(result, strings.TrimSpace("# "+line))
 	}
+	return append(result, def)
 }
 
-func getColumnDef(col *octopus.Column) string {
+func getColumnDef(col *octopus.Column, indexed bool, tableNames *util.StringSet) string {
 	var params []string
-	params = append(params, col.Type)
+	params = append(params, getColumnType(col))
 
 	if col.PrimaryKey {
 		params = append(params, "PK")
@@ -49,6 +90,9 @@ func getColumnDef(col *octopus.Column) string {
 	if col.UniqueKey {
 		params = append(params, "UNIQUE")
 	}
+	if indexed {
+		params = append(params, "INDEX")
+	}
 	if col.AutoIncremental {
 		params = append(params, "AUTOINCREMENT")
 	}
@@ -56,19 +100,39 @@ func getColumnDef(col *octopus.Column) string {
 		params = append(params, "NULLABLE")
 	}
 	if col.DefaultValue != "" {
-		params = append(params, fmt.Sprintf("default=%s", col.DefaultValue))
+		params = append(params, fmt.Sprintf("default=%s", quoteValue(col.DefaultValue)))
 	}
-	if ref := col.Ref; ref != nil {
+	if ref := col.Ref; ref != nil && tableNames.Contains(ref.Table) {
 		rel := getRelationshipType(ref)
 		params = append(params, fmt.Sprintf("FK %s %s.%s", rel, ref.Table, ref.Column))
 	}
-	if col.Description != "" {
-		params = append(params, fmt.Sprintf("# %s", col.Description))
-	}
 
 	return fmt.Sprintf("%s %s", col.Name, strings.Join(params, " "))
 }
 
+func getColumnType(col *octopus.Column) string {
+	if (col.Type == octopus.ColTypeEnum || col.Type == octopus.ColTypeSet) && len(col.Values) > 0 {
+		var values []string
+		for _, value := range col.Values {
+			values = append(values, quote(value))
+		}
+		return fmt.Sprintf("%s(%s)", col.Type, strings.Join(values, ","))
+	}
+	return col.Format()
+}
+
+// quoteValue quotes value if it contains spaces, quotes or comment character.
+func quoteValue(value string) string {
+	if strings.ContainsAny(value, " \t'\"#") {
+		return quote(value)
+	}
+	return value
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
 func getRelationshipType(ref *octopus.Reference) string {
 	switch ref.Relationship {
 	case octopus.RefManyToOne:
@@ -0,0 +1,132 @@
+package quickdbd
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func newTestSchema() *octopus.Schema {
+	return &octopus.Schema{
+		Tables: []*octopus.Table{
+			{
+				Name:        "group",
+				Group:       "common",
+				ClassName:   "Grp",
+				Description: "Group table",
+				Columns: []*octopus.Column{
+					{
+						Name:            "id",
+						Type:            octopus.ColTypeInt64,
+						NotNull:         true,
+						PrimaryKey:      true,
+						AutoIncremental: true,
+					},
+					{
+						Name:         "name",
+						Type:         octopus.ColTypeVarchar,
+						Size:         40,
+						NotNull:      true,
+						UniqueKey:    true,
+						DefaultValue: "no name",
+						Description:  "group name\nunique",
+					},
+				},
+			},
+			{
+				Name: "user",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:        "group_id",
+						Type:        octopus.ColTypeInt64,
+						Description: "group ID",
+						Ref: &octopus.Reference{
+							Table:        "group",
+							Column:       "id",
+							Relationship: octopus.RefManyToOne,
+						},
+					},
+					{
+						Name:         "status",
+						Type:         octopus.ColTypeEnum,
+						NotNull:      true,
+						DefaultValue: "active",
+						Values:       []string{"active", "it's dormant"},
+					},
+					{
+						Name:         "score",
+						Type:         octopus.ColTypeDecimal,
+						Size:         10,
+						Scale:        2,
+						NotNull:      true,
+						DefaultValue: "0",
+					},
+				},
+				Indices: []*octopus.Index{
+					{Name: "idx_user_group_id", Columns: []string{"group_id"}},
+					{Name: "idx_status", Columns: []string{"status", "score"}},
+				},
+			},
+		},
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		Convey("all tables", func() {
+			expected := `# group: common
+group as Grp # Group table
+-----
+id int64 PK AUTOINCREMENT
+# group name
+# unique
+name varchar(40) UNIQUE default='no name'
+
+# group:
+user
+----
+id int64 PK
+group_id int64 INDEX NULLABLE FK >- group.id # group ID
+status enum('active','it''s dormant') default=active
+score decimal(10,2) default=0
+`
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{}).Export(buf), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+
+		Convey("filtered tables", func() {
+			expected := `user
+----
+id int64 PK
+group_id int64 INDEX NULLABLE # group ID
+status enum('active','it''s dormant') default=active
+score decimal(10,2) default=0
+`
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{
+				TableFilter: func(table *octopus.Table) bool { return table.Group == "" },
+			}).Export(buf), ShouldBeNil)
+			actual := buf.String()
+			diff := cmp.Diff(expected, actual)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected)
+		})
+	})
+}
@@ -0,0 +1,427 @@
+package quickdbd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	columnTypeRegex   = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\((.*)\))?$`)
+	relationshipRegex = regexp.MustCompile(`^([<>0-]+)(.*)$`)
+)
+
+// typeAliases maps QuickDBD column types which are not octopus column types.
+var typeAliases = map[string]string{
+	"bool":       octopus.ColTypeBoolean,
+	"datetime2":  octopus.ColTypeDateTime,
+	"money":      octopus.ColTypeDecimal,
+	"nchar":      octopus.ColTypeChar,
+	"ntext":      octopus.ColTypeText16,
+	"nvarchar":   octopus.ColTypeVarchar,
+	"smallmoney": octopus.ColTypeDecimal,
+}
+
+type ImportOption struct {
+	Author  string
+	Version string
+}
+
+type Importer struct {
+	option *ImportOption
+}
+
+func NewImporter(option *ImportOption) *Importer {
+	return &Importer{option: option}
+}
+
+func (c *Importer) Import(reader io.Reader) (*octopus.Schema, error) {
+	if bytes, err := ioutil.ReadAll(reader); err != nil {
+		return nil, err
+	} else {
+		return c.ImportQuickDBD(string(bytes))
+	}
+}
+
+func (c *Importer) ImportFile(filename string) (*octopus.Schema, error) {
+	if data, err := ioutil.ReadFile(filename); err != nil {
+		return nil, err
+	} else {
+		return c.ImportQuickDBD(string(data))
+	}
+}
+
+func (c *Importer) ImportQuickDBD(text string) (*octopus.Schema, error) {
+	if c.option == nil {
+		return nil, errors.New("option is nil")
+	}
+
+	p := &parser{tableByAlias: make(map[string]*octopus.Table)}
+	if err := p.parse(text); err != nil {
+		return nil, err
+	}
+	if err := p.resolve(); err != nil {
+		return nil, err
+	}
+
+	return &octopus.Schema{
+		Author:  c.option.Author,
+		Tables:  p.tables,
+		Version: c.option.Version,
+	}, nil
+}
+
+// ref is a foreign key of column.
+type ref struct {
+	column       *octopus.Column
+	targetTable  string
+	targetColumn string
+	relationship string
+	line         int
+}
+
+type parser struct {
+	tables       []*octopus.Table
+	tableByAlias map[string]*octopus.Table
+	refs         []*ref
+
+	// table is the table being parsed.
+	table *octopus.Table
+	// group is the group of tables being parsed.
+	group string
+	// comments are comment lines above the current line.
+	comments []string
+}
+
+func (p *parser) parse(text string) error {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+
+		var err error
+		switch {
+		case line == "":
+			p.comments = nil
+		case strings.HasPrefix(line, groupDirective):
+			p.group = strings.TrimSpace(line[len(groupDirective):])
+			p.comments = nil
+		case strings.HasPrefix(line, "#"):
+			p.comments = append(p.comments, strings.TrimSpace(line[1:]))
+		case isSeparator(line):
+			err = fmt.Errorf("line %d: table name is missing", lineNo)
+		case i+1 < len(lines) && isSeparator(strings.TrimSpace(lines[i+1])):
+			err = p.parseTable(line, lineNo)
+			i++
+		default:
+			err = p.parseColumn(line, lineNo)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSeparator returns true if line is the separator between table name and columns.
+func isSeparator(line string) bool {
+	return line != "" && strings.Trim(line, "-") == ""
+}
+
+// description returns comment lines above and inline comment as description.
+func (p *parser) description(comment string) string {
+	lines := p.comments
+	if comment != "" {
+		lines = append(lines, comment)
+	}
+	p.comments = nil
+	return strings.Join(lines, "\n")
+}
+
+func (p *parser) parseTable(line string, lineNo int) error {
+	tokens, comment, err := splitLine(line)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", lineNo, err)
+	}
+
+	table := &octopus.Table{Group: p.group}
+	switch {
+	case len(tokens) == 1:
+		table.Name = tokens[0]
+	case len(tokens) == 3 && strings.EqualFold(tokens[1], "as"):
+		table.Name = tokens[0]
+		table.ClassName = tokens[2]
+	default:
+		return fmt.Errorf("line %d: invalid table definition: %s", lineNo, line)
+	}
+	if p.tableByAlias[table.Name] != nil {
+		return fmt.Errorf("line %d: duplicate table: %s", lineNo, table.Name)
+	}
+	table.Description = p.description(comment)
+
+	p.tables = append(p.tables, table)
+	p.tableByAlias[table.Name] = table
+	if table.ClassName != "" {
+		p.tableByAlias[table.ClassName] = table
+	}
+	p.table = table
+	return nil
+}
+
+func (p *parser) parseColumn(line string, lineNo int) error {
+	table := p.table
+	if table == nil {
+		return fmt.Errorf("line %d: column is defined outside of table: %s", lineNo, line)
+	}
+
+	tokens, comment, err := splitLine(line)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", lineNo, err)
+	}
+	column := &octopus.Column{Name: tokens[0]}
+	if table.ColumnByName(column.Name) != nil {
+		return fmt.Errorf("line %d: duplicate column: %s.%s", lineNo, table.Name, column.Name)
+	}
+
+	nullable := false
+	indexed := false
+	for i := 1; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch upper := strings.ToUpper(tok); {
+		case upper == "PK":
+			column.PrimaryKey = true
+		case upper == "NULL" || upper == "NULLABLE":
+			nullable = true
+		case upper == "UNIQUE":
+			column.UniqueKey = true
+		case upper == "INDEX":
+			indexed = true
+		case upper == "AUTOINCREMENT" || upper == "IDENTITY":
+			column.AutoIncremental = true
+		case upper == "FK" || (strings.HasPrefix(upper, "FK") && relationshipRegex.MatchString(tok[2:])):
+			target := tok[2:]
+			if target == "" && i+1 < len(tokens) {
+				i++
+				target = tokens[i]
+			}
+			// relationship and target can be separated with space.
+			if strings.Trim(target, "<>0-") == "" && i+1 < len(tokens) {
+				i++
+				target += tokens[i]
+			}
+			if err := p.parseRef(column, target, lineNo); err != nil {
+				return err
+			}
+		case strings.HasPrefix(upper, "DEFAULT="):
+			column.DefaultValue = unquote(tok[len("default="):])
+		case column.Type == "":
+			if err := parseColumnType(column, tok); err != nil {
+				return fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		default:
+			return fmt.Errorf("line %d: unexpected '%s'", lineNo, tok)
+		}
+	}
+
+	if column.Type == "" {
+		return fmt.Errorf("line %d: column type is missing: %s.%s", lineNo, table.Name, column.Name)
+	}
+	if !octopus.IsValidColType(column.Type) {
+		return fmt.Errorf("line %d: unsupported column type: %s.%s: %s", lineNo, table.Name, column.Name, column.Type)
+	}
+	column.NotNull = !nullable
+	column.Description = p.description(comment)
+
+	table.Columns = append(table.Columns, column)
+	if indexed {
+		table.Indices = append(table.Indices, &octopus.Index{
+			Name:    fmt.Sprintf("idx_%s_%s", table.Name, column.Name),
+			Columns: []string{column.Name},
+		})
+	}
+	return nil
+}
+
+// parseRef parses relationship and target column. e.g. '>-Table.column'
+func (p *parser) parseRef(column *octopus.Column, target string, lineNo int) error {
+	match := relationshipRegex.FindStringSubmatch(target)
+	if match == nil {
+		return fmt.Errorf("line %d: invalid foreign key: %s", lineNo, target)
+	}
+	names := strings.Split(match[2], ".")
+	if len(names) != 2 || names[0] == "" || names[1] == "" {
+		return fmt.Errorf("line %d: invalid foreign key: %s", lineNo, target)
+	}
+
+	// zero or one, zero or many are handled as one, many.
+	var relationship string
+	switch strings.ReplaceAll(match[1], "0", "") {
+	case ">-":
+		relationship = octopus.RefManyToOne
+	case "-<":
+		relationship = octopus.RefOneToMany
+	case "-":
+		relationship = octopus.RefOneToOne
+	case ">-<":
+		return fmt.Errorf("line %d: many-to-many relationship is not supported", lineNo)
+	default:
+		return fmt.Errorf("line %d: invalid relationship: %s", lineNo, match[1])
+	}
+
+	p.refs = append(p.refs, &ref{
+		column:       column,
+		targetTable:  names[0],
+		targetColumn: names[1],
+		relationship: relationship,
+		line:         lineNo,
+	})
+	return nil
+}
+
+// resolve sets references after all tables are parsed.
+func (p *parser) resolve() error {
+	for _, r := range p.refs {
+		table := p.tableByAlias[r.targetTable]
+		if table == nil {
+			return fmt.Errorf("line %d: table not found: %s", r.line, r.targetTable)
+		}
+		if table.ColumnByName(r.targetColumn) == nil {
+			return fmt.Errorf("line %d: column not found: %s.%s", r.line, table.Name, r.targetColumn)
+		}
+		r.column.Ref = &octopus.Reference{
+			Table:        table.Name,
+			Column:       r.targetColumn,
+			Relationship: r.relationship,
+		}
+	}
+	return nil
+}
+
+func parseColumnType(column *octopus.Column, s string) error {
+	match := columnTypeRegex.FindStringSubmatch(s)
+	if match == nil {
+		return fmt.Errorf("invalid column type: %s", s)
+	}
+
+	colType := strings.ToLower(match[1])
+	if alias, ok := typeAliases[colType]; ok {
+		colType = alias
+	}
+	column.Type = colType
+	column.NormalizeType()
+
+	var args []string
+	if match[2] != "" {
+		args = splitArgs(match[2])
+	}
+	if column.Type == octopus.ColTypeEnum || column.Type == octopus.ColTypeSet {
+		column.Values = args
+		return nil
+	}
+	if len(args) > 0 && args[0] != "max" {
+		size, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid column size: %s", s)
+		}
+		column.Size = uint16(size)
+	}
+	if len(args) > 1 {
+		scale, err := strconv.ParseUint(args[1], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid column scale: %s", s)
+		}
+		column.Scale = uint16(scale)
+	}
+	return nil
+}
+
+// splitLine splits line into tokens separated by spaces and returns the comment after '#'.
+// spaces in quotes or parentheses are not separators.
+func splitLine(line string) ([]string, string, error) {
+	var tokens []string
+	var sb strings.Builder
+	flush := func() {
+		if sb.Len() > 0 {
+			tokens = append(tokens, sb.String())
+			sb.Reset()
+		}
+	}
+
+	var quote rune
+	depth := 0
+	runes := []rune(line)
+	for i, r := range runes {
+		switch {
+		case quote != 0:
+			sb.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			sb.WriteRune(r)
+		case r == '#' && depth == 0:
+			flush()
+			return tokens, strings.TrimSpace(string(runes[i+1:])), nil
+		case unicode.IsSpace(r) && depth == 0:
+			flush()
+		default:
+			if r == '(' {
+				depth++
+			} else if r == ')' {
+				depth--
+			}
+			sb.WriteRune(r)
+		}
+	}
+	if quote != 0 {
+		return nil, "", fmt.Errorf("unterminated quote: %c", quote)
+	}
+	flush()
+	return tokens, "", nil
+}
+
+// splitArgs splits comma separated arguments. quotes of values are removed.
+func splitArgs(s string) []string {
+	var args []string
+	for _, arg := range splitQuoted(s, ',') {
+		args = append(args, unquote(strings.TrimSpace(arg)))
+	}
+	return args
+}
+
+// splitQuoted splits s by sep which is not in quotes.
+func splitQuoted(s string, sep rune) []string {
+	var result []string
+	var sb strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == sep && !quoted:
+			result = append(result, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return append(result, sb.String())
+}
+
+// unquote removes single or double quotes of value.
+func unquote(s string) string {
+	if len(s) >= 2 {
+		if q := s[0]; (q == '\'' || q == '"') && s[len(s)-1] == q {
+			quote := string(q)
+			return strings.ReplaceAll(s[1:len(s)-1], quote+quote, quote)
+		}
+	}
+	return s
+}
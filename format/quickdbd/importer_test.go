@@ -0,0 +1,134 @@
+package quickdbd
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func TestImporter_Import(t *testing.T) {
+	Convey("Import", t, func() {
+		importer := NewImporter(&ImportOption{})
+
+		Convey("round trip", func() {
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{}).Export(buf), ShouldBeNil)
+
+			schema, err := importer.ImportQuickDBD(buf.String())
+			So(err, ShouldBeNil)
+
+			// composite index is not exported
+			expected := newTestSchema()
+			expected.Tables[1].Indices = expected.Tables[1].Indices[:1]
+			diff := cmp.Diff(expected, schema)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(schema, ShouldResemble, expected)
+		})
+
+		Convey("quickdbd", func() {
+			text := `# Modify this code to update the DB schema diagram.
+
+Customer
+-
+CustomerID PK int
+Name string INDEX
+Address2 NULL nvarchar(max)
+
+# Table documentation
+Order as o
+----
+OrderID int PK IDENTITY
+CustomerID int FK >- Customer.CustomerID
+TotalAmount money default=0
+OrderStatusID int FK>-os.OrderStatusID
+
+OrderStatus as os # Status
+------------
+OrderStatusID PK int
+Name UNIQUE string # Field documentation
+`
+			schema, err := importer.ImportQuickDBD(text)
+			So(err, ShouldBeNil)
+
+			expected := []*octopus.Table{
+				{
+					Name: "Customer",
+					Columns: []*octopus.Column{
+						{Name: "CustomerID", Type: octopus.ColTypeInt32, NotNull: true, PrimaryKey: true},
+						{Name: "Name", Type: octopus.ColTypeVarchar, NotNull: true},
+						{Name: "Address2", Type: octopus.ColTypeVarchar},
+					},
+					Indices: []*octopus.Index{
+						{Name: "idx_Customer_Name", Columns: []string{"Name"}},
+					},
+				},
+				{
+					Name:        "Order",
+					ClassName:   "o",
+					Description: "Table documentation",
+					Columns: []*octopus.Column{
+						{Name: "OrderID", Type: octopus.ColTypeInt32, NotNull: true, PrimaryKey: true, AutoIncremental: true},
+						{
+							Name:    "CustomerID",
+							Type:    octopus.ColTypeInt32,
+							NotNull: true,
+							Ref: &octopus.Reference{
+								Table:        "Customer",
+								Column:       "CustomerID",
+								Relationship: octopus.RefManyToOne,
+							},
+						},
+						{Name: "TotalAmount", Type: octopus.ColTypeDecimal, NotNull: true, DefaultValue: "0"},
+						{
+							Name:    "OrderStatusID",
+							Type:    octopus.ColTypeInt32,
+							NotNull: true,
+							Ref: &octopus.Reference{
+								Table:        "OrderStatus",
+								Column:       "OrderStatusID",
+								Relationship: octopus.RefManyToOne,
+							},
+						},
+					},
+				},
+				{
+					Name:        "OrderStatus",
+					ClassName:   "os",
+					Description: "Status",
+					Columns: []*octopus.Column{
+						{Name: "OrderStatusID", Type: octopus.ColTypeInt32, NotNull: true, PrimaryKey: true},
+						{Name: "Name", Type: octopus.ColTypeVarchar, NotNull: true, UniqueKey: true, Description: "Field documentation"},
+					},
+				},
+			}
+			diff := cmp.Diff(expected, schema.Tables)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(schema.Tables, ShouldResemble, expected)
+		})
+
+		Convey("errors", func() {
+			invalids := map[string]string{
+				"t\n-\nid uuid":                          "line 3: unsupported column type: t.id: uuid",
+				"t\n-\nid int FK >-< u.id\nu\n-\nid int": "line 3: many-to-many relationship is not supported",
+				"t\n-\nid int FK >- u.id":                "line 3: table not found: u",
+				"t\n-\nid int FK >- t.name":              "line 3: column not found: t.name",
+				"t\n-\nid int\nid int":                   "line 4: duplicate column: t.id",
+				"t\n-\nid int default='a":                "line 3: unterminated quote: '",
+				"t\n-\nid PK":                            "line 3: column type is missing: t.id",
+				"id int":                                 "line 1: column is defined outside of table: id int",
+			}
+			for text, expected := range invalids {
+				_, err := importer.ImportQuickDBD(text)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, expected)
+			}
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package rust
+
+import (
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/urfave/cli/v2"
+	"strings"
+)
+
+const (
+	FlagGroups       = "groups"
+	FlagInput        = "input"
+	FlagOrm          = "orm"
+	FlagOutput       = "output"
+	FlagPrefix       = "prefix"
+	FlagRemovePrefix = "removePrefix"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	gen := NewGenerator(schema, &Option{
+		PrefixMapper:   common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:    octopus.GetTableFilterFn(c.String(FlagGroups)),
+		RemovePrefixes: strings.Split(c.String(FlagRemovePrefix), ","),
+		Orm:            c.String(FlagOrm),
+	})
+	return gen.Generate(c.String(FlagOutput))
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate rust files to `DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagOrm,
+		Usage:   "target `ORM`. available values: diesel, seaorm",
+		Value:   OrmDiesel,
+		EnvVars: []string{"OCTOPUS_ORM"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"p"},
+		Usage:   "set struct name prefix",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"r"},
+		Usage:   "set prefixes to remove from struct name. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
+}
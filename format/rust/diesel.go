@@ -0,0 +1,211 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"log"
+	"strings"
+	"text/template"
+)
+
+const (
+	// DieselSchemaTemplate is the template to generate diesel 'schema.rs'.
+	// Template is executed with DieselTplData.
+	DieselSchemaTemplate = `{{"" -}}
+// @generated by octopus-db-tools. do not edit.
+{{range .Structs}}
+diesel::table! {
+{{- if .Comment}}
+    /// {{.Comment}}
+{{- end}}
+{{- if .Renamed}}
+    #[sql_name = "{{.TableName}}"]
+{{- end}}
+    {{.Ident}} ({{pkNames .}}) {
+{{- range .Fields}}
+{{- if .Column.Description}}
+        /// {{.Column.Description}}
+{{- end}}
+{{- if .Renamed}}
+        #[sql_name = "{{.Column.Name}}"]
+{{- end}}
+        {{.Name}} -> {{.SqlType}},
+{{- end}}
+    }
+}
+{{end}}
+{{- if .Joinables}}
+{{range .Joinables}}diesel::joinable!({{.}});
+{{end}}
+{{- end}}
+{{- if gt (len .Structs) 1}}
+diesel::allow_tables_to_appear_in_same_query!(
+{{- range .Structs}}
+    {{.Ident}},
+{{- end}}
+);
+{{- end}}
+`
+
+	// DieselModelsTemplate is the template to generate diesel 'models.rs'.
+	// Template is executed with DieselTplData.
+	DieselModelsTemplate = `{{"" -}}
+// @generated by octopus-db-tools. do not edit.
+
+{{range .Imports}}use {{.}};
+{{end}}
+{{- range .Structs}}
+{{if .Comment}}/// {{.Comment}}
+{{end -}}
+#[derive({{join (derives .) ", "}})]
+#[diesel(table_name = crate::schema::{{.Ident}})]
+{{- if ne (pkNames .) "id"}}
+#[diesel(primary_key({{pkNames .}}))]
+{{- end}}
+{{- range belongsTo .}}
+#[diesel(belongs_to({{.Target.Name}}, foreign_key = {{.Field.Name}}))]
+{{- end}}
+pub struct {{.Name}} {
+{{- range .Fields}}
+    pub {{.Name}}: {{.Type}},
+{{- end}}
+}
+
+#[derive(Debug, Insertable)]
+#[diesel(table_name = crate::schema::{{.Ident}})]
+pub struct New{{.Name}} {
+{{- range insertFields .}}
+    pub {{.Name}}: {{.Type}},
+{{- end}}
+}
+{{end}}`
+)
+
+// DieselTplData is the data passed to diesel templates.
+type DieselTplData struct {
+	Imports   []string
+	Structs   []*RustStruct
+	Joinables []string
+}
+
+// isDieselJoinable returns true if the reference can be declared with 'joinable!'.
+// diesel supports references to the single primary key of other tables only.
+func isDieselJoinable(ref *RustReference) bool {
+	target := ref.Target
+	return ref.Source != target &&
+		len(target.PKFields) == 1 &&
+		target.PKFields[0] == ref.TargetField
+}
+
+// pkStructs returns structs with primary keys.
+// diesel and SeaORM require primary key for every table.
+func (g *Generator) pkStructs() []*RustStruct {
+	var structs []*RustStruct
+	for _, s := range g.structs {
+		if s.HasPK() {
+			structs = append(structs, s)
+		}
+	}
+	return structs
+}
+
+func (g *Generator) dieselFuncMap() template.FuncMap {
+	belongsTo := func(s *RustStruct) []*RustReference {
+		var refs []*RustReference
+		for _, ref := range s.References {
+			if isDieselJoinable(ref) {
+				refs = append(refs, ref)
+			}
+		}
+		return refs
+	}
+
+	return template.FuncMap{
+		"join": strings.Join,
+		"pkNames": func(s *RustStruct) string {
+			var names []string
+			for _, field := range s.PKFields {
+				names = append(names, field.Name)
+			}
+			return strings.Join(names, ", ")
+		},
+		"belongsTo": belongsTo,
+		"derives": func(s *RustStruct) []string {
+			derives := []string{"Debug", "Clone", "Queryable", "Selectable", "Identifiable"}
+			if len(belongsTo(s)) > 0 {
+				derives = append(derives, "Associations")
+			}
+			return derives
+		},
+		"insertFields": func(s *RustStruct) []*RustField {
+			var fields []*RustField
+			for _, field := range s.Fields {
+				if !field.Column.AutoIncremental {
+					fields = append(fields, field)
+				}
+			}
+			return fields
+		},
+	}
+}
+
+// GenerateDieselSchema generates 'table!' macros.
+func (g *Generator) GenerateDieselSchema(wr io.Writer) error {
+	tmpl, err := util.NewTemplate("dieselSchema", DieselSchemaTemplate, g.dieselFuncMap())
+	if err != nil {
+		return err
+	}
+
+	for _, s := range g.structs {
+		if !s.HasPK() {
+			log.Printf("Table skipped. %s has no primary key", s.TableName)
+		}
+	}
+	structs := g.pkStructs()
+
+	// joinable
+	var joinables []string
+	joinableSet := util.NewStringSet()
+	for _, s := range structs {
+		for _, ref := range s.References {
+			if !isDieselJoinable(ref) {
+				continue
+			}
+			key := s.Ident + "->" + ref.Target.Ident
+			if joinableSet.Contains(key) {
+				// diesel allows only one joinable between two tables
+				continue
+			}
+			joinableSet.Add(key)
+			joinables = append(joinables, fmt.Sprintf("%s -> %s (%s)", s.Ident, ref.Target.Ident, ref.Field.Name))
+		}
+	}
+
+	return tmpl.Execute(wr, &DieselTplData{
+		Structs:   structs,
+		Joinables: joinables,
+	})
+}
+
+// GenerateDieselModels generates 'Queryable' and 'Insertable' structs.
+func (g *Generator) GenerateDieselModels(wr io.Writer) error {
+	tmpl, err := util.NewTemplate("dieselModels", DieselModelsTemplate, g.dieselFuncMap())
+	if err != nil {
+		return err
+	}
+
+	structs := g.pkStructs()
+
+	importSet := util.NewStringSet("diesel::prelude::*")
+	for _, s := range structs {
+		for _, field := range s.Fields {
+			importSet.AddAll(field.Imports)
+		}
+	}
+
+	return tmpl.Execute(wr, &DieselTplData{
+		Imports: importSet.Slice(),
+		Structs: structs,
+	})
+}
@@ -0,0 +1,199 @@
+package rust
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	OrmDiesel = "diesel"
+	OrmSeaOrm = "seaorm"
+)
+
+type Option struct {
+	PrefixMapper   *common.PrefixMapper
+	TableFilter    octopus.TableFilterFn
+	RemovePrefixes []string
+	Orm            string
+}
+
+type Generator struct {
+	schema  *octopus.Schema
+	option  *Option
+	structs []*RustStruct
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	gen := &Generator{
+		schema: schema,
+		option: option,
+	}
+
+	for _, table := range schema.FilteredTables(option.TableFilter) {
+		gen.structs = append(gen.structs, gen.newRustStruct(table))
+	}
+	gen.populateReferences()
+
+	return gen
+}
+
+// StructName returns struct name from table name.
+func (g *Generator) StructName(table *octopus.Table) string {
+	name := table.ClassName
+	if name == "" {
+		tableName := table.Name
+		for _, prefix := range g.option.RemovePrefixes {
+			tableName = strings.TrimPrefix(tableName, prefix)
+		}
+		name = strcase.ToCamel(tableName)
+
+		if prefixMapper := g.option.PrefixMapper; prefixMapper != nil {
+			if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+				name = prefix + name
+			}
+		}
+	}
+	return name
+}
+
+func (g *Generator) newRustStruct(table *octopus.Table) *RustStruct {
+	var fields []*RustField
+	var pkFields []*RustField
+	for _, column := range table.Columns {
+		field := NewRustField(column)
+		fields = append(fields, field)
+		if column.PrimaryKey {
+			pkFields = append(pkFields, field)
+		}
+	}
+
+	ident, ok := toIdent(table.Name)
+
+	return &RustStruct{
+		table:     table,
+		Name:      g.StructName(table),
+		TableName: table.Name,
+		Ident:     ident,
+		Renamed:   !ok,
+		Comment:   table.Description,
+		Fields:    fields,
+		PKFields:  pkFields,
+	}
+}
+
+func (g *Generator) structByTableName(tableName string) *RustStruct {
+	for _, s := range g.structs {
+		if s.TableName == tableName {
+			return s
+		}
+	}
+	return nil
+}
+
+// populateReferences populates references between generated structs.
+// '1:n' reference is reversed, so that every reference starts from the foreign key field.
+func (g *Generator) populateReferences() {
+	for _, source := range g.structs {
+		for _, field := range source.Fields {
+			ref := field.Column.Ref
+			if ref == nil {
+				continue
+			}
+			target := g.structByTableName(ref.Table)
+			if target == nil {
+				continue
+			}
+			targetField := target.FieldByColumnName(ref.Column)
+			if targetField == nil {
+				continue
+			}
+
+			var reference *RustReference
+			switch ref.Relationship {
+			case octopus.RefOneToMany:
+				reference = &RustReference{
+					Source:       target,
+					Field:        targetField,
+					Target:       source,
+					TargetField:  field,
+					Relationship: octopus.RefManyToOne,
+				}
+			case octopus.RefOneToOne:
+				reference = &RustReference{
+					Source:       source,
+					Field:        field,
+					Target:       target,
+					TargetField:  targetField,
+					Relationship: octopus.RefOneToOne,
+				}
+			default:
+				reference = &RustReference{
+					Source:       source,
+					Field:        field,
+					Target:       target,
+					TargetField:  targetField,
+					Relationship: octopus.RefManyToOne,
+				}
+			}
+			reference.Source.References = append(reference.Source.References, reference)
+			// self reference has no reverse side
+			if reference.Source != reference.Target {
+				reference.Target.ReverseReferences = append(reference.Target.ReverseReferences, reference)
+			}
+		}
+	}
+}
+
+func (g *Generator) Generate(outputPath string) error {
+	if _, err := util.Mkdir(outputPath); err != nil {
+		return err
+	}
+
+	files := make(map[string]func(wr io.Writer) error)
+	var filenames []string
+	addFile := func(filename string, fn func(wr io.Writer) error) {
+		filenames = append(filenames, filename)
+		files[filename] = fn
+	}
+
+	switch g.option.Orm {
+	case "", OrmDiesel:
+		addFile("schema.rs", g.GenerateDieselSchema)
+		addFile("models.rs", g.GenerateDieselModels)
+	case OrmSeaOrm:
+		addFile("mod.rs", g.GenerateSeaOrmMod)
+		addFile("prelude.rs", g.GenerateSeaOrmPrelude)
+		for _, s := range g.structs {
+			if !s.HasPK() {
+				log.Printf("Table skipped. %s has no primary key", s.TableName)
+			}
+		}
+		for _, s := range g.pkStructs() {
+			s := s
+			addFile(s.Ident+".rs", func(wr io.Writer) error {
+				return g.GenerateSeaOrmEntity(wr, s)
+			})
+		}
+	default:
+		return fmt.Errorf("unsupported orm: %s", g.option.Orm)
+	}
+
+	for _, filename := range filenames {
+		buf := new(bytes.Buffer)
+		if err := files[filename](buf); err != nil {
+			return err
+		}
+		if err := util.WriteStringToFile(filepath.Join(outputPath, filename), buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
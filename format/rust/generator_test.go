@@ -0,0 +1,342 @@
+package rust
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var rustTestSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:        "group",
+			Description: "Group table",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:      "name",
+					Type:      octopus.ColTypeVarchar,
+					Size:      40,
+					NotNull:   true,
+					UniqueKey: true,
+				},
+			},
+		},
+		{
+			Name:        "user",
+			Description: "User table",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					NotNull:         true,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+				},
+				{
+					Name:        "type",
+					Type:        octopus.ColTypeVarchar,
+					Size:        10,
+					NotNull:     true,
+					Description: "user type",
+				},
+				{
+					Name:  "point",
+					Type:  octopus.ColTypeDecimal,
+					Size:  10,
+					Scale: 2,
+				},
+				{
+					Name: "props",
+					Type: octopus.ColTypeJSON,
+				},
+				{
+					Name: "group_id",
+					Type: octopus.ColTypeInt64,
+					Ref: &octopus.Reference{
+						Table:        "group",
+						Column:       "id",
+						Relationship: octopus.RefManyToOne,
+					},
+				},
+				{
+					Name:    "created_at",
+					Type:    octopus.ColTypeDateTime,
+					NotNull: true,
+				},
+			},
+		},
+	},
+}
+
+func TestGenerator_Diesel(t *testing.T) {
+	Convey("Diesel", t, func() {
+		expectedSchema := `// @generated by octopus-db-tools. do not edit.
+
+diesel::table! {
+    /// Group table
+    group (id) {
+        id -> BigInt,
+        name -> Varchar,
+    }
+}
+
+diesel::table! {
+    /// User table
+    user (id) {
+        id -> BigInt,
+        /// user type
+        #[sql_name = "type"]
+        type_ -> Varchar,
+        point -> Nullable<Numeric>,
+        props -> Nullable<Json>,
+        group_id -> Nullable<BigInt>,
+        created_at -> Timestamp,
+    }
+}
+
+diesel::joinable!(user -> group (group_id));
+
+diesel::allow_tables_to_appear_in_same_query!(
+    group,
+    user,
+);
+`
+		expectedModels := `// @generated by octopus-db-tools. do not edit.
+
+use bigdecimal::BigDecimal;
+use chrono::NaiveDateTime;
+use diesel::prelude::*;
+
+/// Group table
+#[derive(Debug, Clone, Queryable, Selectable, Identifiable)]
+#[diesel(table_name = crate::schema::group)]
+pub struct Group {
+    pub id: i64,
+    pub name: String,
+}
+
+#[derive(Debug, Insertable)]
+#[diesel(table_name = crate::schema::group)]
+pub struct NewGroup {
+    pub name: String,
+}
+
+/// User table
+#[derive(Debug, Clone, Queryable, Selectable, Identifiable, Associations)]
+#[diesel(table_name = crate::schema::user)]
+#[diesel(belongs_to(Group, foreign_key = group_id))]
+pub struct User {
+    pub id: i64,
+    pub type_: String,
+    pub point: Option<BigDecimal>,
+    pub props: Option<serde_json::Value>,
+    pub group_id: Option<i64>,
+    pub created_at: NaiveDateTime,
+}
+
+#[derive(Debug, Insertable)]
+#[diesel(table_name = crate::schema::user)]
+pub struct NewUser {
+    pub type_: String,
+    pub point: Option<BigDecimal>,
+    pub props: Option<serde_json::Value>,
+    pub group_id: Option<i64>,
+    pub created_at: NaiveDateTime,
+}
+`
+
+		gen := NewGenerator(rustTestSchema, &Option{
+			PrefixMapper: common.NewPrefixMapper(""),
+			Orm:          OrmDiesel,
+		})
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateDieselSchema(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expectedSchema, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expectedSchema)
+
+		buf = new(bytes.Buffer)
+		if err := gen.GenerateDieselModels(buf); err != nil {
+			t.Error(err)
+		}
+		actual = buf.String()
+		if diff := cmp.Diff(expectedModels, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expectedModels)
+	})
+}
+
+func TestGenerator_SeaOrm(t *testing.T) {
+	Convey("SeaOrm", t, func() {
+		expected := []string{
+			`//! @generated by octopus-db-tools. do not edit.
+
+use sea_orm::entity::prelude::*;
+
+/// Group table
+#[derive(Clone, Debug, PartialEq, DeriveEntityModel)]
+#[sea_orm(table_name = "group")]
+pub struct Model {
+    #[sea_orm(primary_key)]
+    pub id: i64,
+    #[sea_orm(unique)]
+    pub name: String,
+}
+
+#[derive(Copy, Clone, Debug, EnumIter, DeriveRelation)]
+pub enum Relation {
+    #[sea_orm(has_many = "super::user::Entity")]
+    User,
+}
+
+impl Related<super::user::Entity> for Entity {
+    fn to() -> RelationDef {
+        Relation::User.def()
+    }
+}
+
+impl ActiveModelBehavior for ActiveModel {}
+`,
+			`//! @generated by octopus-db-tools. do not edit.
+
+use bigdecimal::BigDecimal;
+use chrono::NaiveDateTime;
+use sea_orm::entity::prelude::*;
+
+/// User table
+#[derive(Clone, Debug, PartialEq, DeriveEntityModel)]
+#[sea_orm(table_name = "user")]
+pub struct Model {
+    #[sea_orm(primary_key)]
+    pub id: i64,
+    /// user type
+    #[sea_orm(column_name = "type")]
+    pub type_: String,
+    pub point: Option<BigDecimal>,
+    pub props: Option<serde_json::Value>,
+    pub group_id: Option<i64>,
+    pub created_at: NaiveDateTime,
+}
+
+#[derive(Copy, Clone, Debug, EnumIter, DeriveRelation)]
+pub enum Relation {
+    #[sea_orm(
+        belongs_to = "super::group::Entity",
+        from = "Column::GroupId",
+        to = "super::group::Column::Id"
+    )]
+    Group,
+}
+
+impl Related<super::group::Entity> for Entity {
+    fn to() -> RelationDef {
+        Relation::Group.def()
+    }
+}
+
+impl ActiveModelBehavior for ActiveModel {}
+`,
+		}
+		expectedPrelude := `//! @generated by octopus-db-tools. do not edit.
+
+pub use super::group::Entity as CGroup;
+pub use super::user::Entity as CUser;
+`
+
+		gen := NewGenerator(rustTestSchema, &Option{
+			PrefixMapper: common.NewPrefixMapper("C"),
+			Orm:          OrmSeaOrm,
+		})
+
+		for i, s := range gen.structs {
+			buf := new(bytes.Buffer)
+			if err := gen.GenerateSeaOrmEntity(buf, s); err != nil {
+				t.Error(err)
+			}
+			actual := buf.String()
+			if diff := cmp.Diff(expected[i], actual); diff != "" {
+				log.Println(diff)
+			}
+			So(actual, ShouldEqual, expected[i])
+		}
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateSeaOrmPrelude(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expectedPrelude, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expectedPrelude)
+	})
+}
+
+func TestGenerator_SeaOrmWithoutPK(t *testing.T) {
+	Convey("SeaOrm skips tables without primary key", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "group",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, NotNull: true, PrimaryKey: true},
+					},
+				},
+				{
+					Name: "log",
+					Columns: []*octopus.Column{
+						{Name: "message", Type: octopus.ColTypeVarchar, Size: 100, NotNull: true},
+						{Name: "group_id", Type: octopus.ColTypeInt64, Ref: &octopus.Reference{Table: "group", Column: "id"}},
+					},
+				},
+			},
+		}
+		expectedMod := `//! @generated by octopus-db-tools. do not edit.
+
+pub mod prelude;
+
+pub mod group;
+`
+
+		outputPath, err := ioutil.TempDir("", "seaorm")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(outputPath)
+
+		gen := NewGenerator(schema, &Option{
+			PrefixMapper: common.NewPrefixMapper(""),
+			Orm:          OrmSeaOrm,
+		})
+		So(gen.Generate(outputPath), ShouldBeNil)
+
+		_, err = os.Stat(filepath.Join(outputPath, "log.rs"))
+		So(os.IsNotExist(err), ShouldBeTrue)
+
+		mod, err := ioutil.ReadFile(filepath.Join(outputPath, "mod.rs"))
+		So(err, ShouldBeNil)
+		So(string(mod), ShouldEqual, expectedMod)
+
+		group, err := ioutil.ReadFile(filepath.Join(outputPath, "group.rs"))
+		So(err, ShouldBeNil)
+		So(string(group), ShouldContainSubstring, "pub enum Relation {}")
+	})
+}
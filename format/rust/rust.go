@@ -0,0 +1,166 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"strings"
+)
+
+// RustStruct is a model struct generated from a table.
+type RustStruct struct {
+	table             *octopus.Table
+	Name              string
+	TableName         string
+	Ident             string
+	Renamed           bool
+	Comment           string
+	Fields            []*RustField
+	PKFields          []*RustField
+	References        []*RustReference
+	ReverseReferences []*RustReference
+}
+
+// RustField is a struct field generated from a column.
+type RustField struct {
+	Column  *octopus.Column
+	Name    string
+	Renamed bool
+	SqlType string
+	Type    string
+	Imports []string
+}
+
+// RustReference is a reference from a foreign key field to a field of target struct.
+type RustReference struct {
+	Source       *RustStruct
+	Field        *RustField
+	Target       *RustStruct
+	TargetField  *RustField
+	Relationship string
+}
+
+// rustType is a rust type mapped from column type.
+type rustType struct {
+	SqlType string
+	Type    string
+	Import  string
+}
+
+// toIdent returns snake-case rust identifier.
+// returns false if the identifier is different from name.
+func toIdent(name string) (string, bool) {
+	ident := strcase.ToSnake(name)
+	if util.IsRustReservedWord(ident) {
+		ident = ident + "_"
+	}
+	return ident, ident == name
+}
+
+func NewRustField(column *octopus.Column) *RustField {
+	ident, ok := toIdent(column.Name)
+	rt := toRustType(column)
+
+	sqlType := rt.SqlType
+	fieldType := rt.Type
+	if !column.NotNull {
+		sqlType = fmt.Sprintf("Nullable<%s>", sqlType)
+		fieldType = fmt.Sprintf("Option<%s>", fieldType)
+	}
+
+	var imports []string
+	if rt.Import != "" {
+		imports = append(imports, rt.Import)
+	}
+
+	return &RustField{
+		Column:  column,
+		Name:    ident,
+		Renamed: !ok,
+		SqlType: sqlType,
+		Type:    fieldType,
+		Imports: imports,
+	}
+}
+
+// toRustType returns diesel sql type and rust type of column.
+func toRustType(column *octopus.Column) *rustType {
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		fallthrough
+	case octopus.ColTypeEnum:
+		fallthrough
+	case octopus.ColTypeSet:
+		return &rustType{SqlType: "Varchar", Type: "String"}
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		return &rustType{SqlType: "Text", Type: "String"}
+	case octopus.ColTypeBoolean:
+		fallthrough
+	case octopus.ColTypeBit:
+		return &rustType{SqlType: "Bool", Type: "bool"}
+	case octopus.ColTypeInt8:
+		fallthrough
+	case octopus.ColTypeInt16:
+		fallthrough
+	case octopus.ColTypeYear:
+		return &rustType{SqlType: "SmallInt", Type: "i16"}
+	case octopus.ColTypeInt24:
+		fallthrough
+	case octopus.ColTypeInt32:
+		return &rustType{SqlType: "Integer", Type: "i32"}
+	case octopus.ColTypeInt64:
+		return &rustType{SqlType: "BigInt", Type: "i64"}
+	case octopus.ColTypeDecimal:
+		return &rustType{SqlType: "Numeric", Type: "BigDecimal", Import: "bigdecimal::BigDecimal"}
+	case octopus.ColTypeFloat:
+		return &rustType{SqlType: "Float", Type: "f32"}
+	case octopus.ColTypeDouble:
+		return &rustType{SqlType: "Double", Type: "f64"}
+	case octopus.ColTypeDateTime:
+		return &rustType{SqlType: "Timestamp", Type: "NaiveDateTime", Import: "chrono::NaiveDateTime"}
+	case octopus.ColTypeDate:
+		return &rustType{SqlType: "Date", Type: "NaiveDate", Import: "chrono::NaiveDate"}
+	case octopus.ColTypeTime:
+		return &rustType{SqlType: "Time", Type: "NaiveTime", Import: "chrono::NaiveTime"}
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		return &rustType{SqlType: "Binary", Type: "Vec<u8>"}
+	case octopus.ColTypeJSON:
+		return &rustType{SqlType: "Json", Type: "serde_json::Value"}
+	default:
+		return &rustType{SqlType: "Text", Type: "String"}
+	}
+}
+
+// FieldByColumnName returns field of column name. returns nil if not found.
+func (s *RustStruct) FieldByColumnName(columnName string) *RustField {
+	for _, field := range s.Fields {
+		if field.Column.Name == columnName {
+			return field
+		}
+	}
+	return nil
+}
+
+// HasPK returns true if the struct has primary key fields.
+func (s *RustStruct) HasPK() bool {
+	return len(s.PKFields) > 0
+}
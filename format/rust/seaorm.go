@@ -0,0 +1,220 @@
+package rust
+
+import (
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"strings"
+	"text/template"
+)
+
+const (
+	// SeaOrmEntityTemplate is the template to generate SeaORM entity file.
+	// Template is executed with SeaOrmTplData.
+	SeaOrmEntityTemplate = `{{"" -}}
+//! @generated by octopus-db-tools. do not edit.
+
+{{range .Imports}}use {{.}};
+{{end}}
+{{if .Struct.Comment}}/// {{.Struct.Comment}}
+{{end -}}
+#[derive(Clone, Debug, PartialEq, DeriveEntityModel)]
+#[sea_orm(table_name = "{{.Struct.TableName}}")]
+pub struct Model {
+{{- range .Struct.Fields}}
+{{- if .Column.Description}}
+    /// {{.Column.Description}}
+{{- end}}
+{{- with fieldAttrs .}}
+    #[sea_orm({{join . ", "}})]
+{{- end}}
+    pub {{.Name}}: {{.Type}},
+{{- end}}
+}
+
+#[derive(Copy, Clone, Debug, EnumIter, DeriveRelation)]
+{{- if .Relations}}
+pub enum Relation {
+{{- range .Relations}}
+{{- if eq (len .Attrs) 1}}
+    #[sea_orm({{index .Attrs 0}})]
+{{- else}}
+    #[sea_orm(
+        {{join .Attrs ",\n        "}}
+    )]
+{{- end}}
+    {{.Variant}},
+{{- end}}
+}
+{{- else}}
+pub enum Relation {}
+{{- end}}
+{{range .Relations}}
+{{- if .Related}}
+impl Related<super::{{.Module}}::Entity> for Entity {
+    fn to() -> RelationDef {
+        Relation::{{.Variant}}.def()
+    }
+}
+{{end}}
+{{- end}}
+impl ActiveModelBehavior for ActiveModel {}
+`
+
+	// SeaOrmModTemplate is the template to generate SeaORM 'mod.rs'.
+	// Template is executed with SeaOrmTplData.
+	SeaOrmModTemplate = `{{"" -}}
+//! @generated by octopus-db-tools. do not edit.
+
+pub mod prelude;
+{{range .Structs}}
+pub mod {{.Ident}};
+{{- end}}
+`
+
+	// SeaOrmPreludeTemplate is the template to generate SeaORM 'prelude.rs'.
+	// Template is executed with SeaOrmTplData.
+	SeaOrmPreludeTemplate = `{{"" -}}
+//! @generated by octopus-db-tools. do not edit.
+{{range .Structs}}
+pub use super::{{.Ident}}::Entity as {{.Name}};
+{{- end}}
+`
+)
+
+// SeaOrmTplData is the data passed to SeaORM templates.
+type SeaOrmTplData struct {
+	Imports   []string
+	Struct    *RustStruct
+	Structs   []*RustStruct
+	Relations []*SeaOrmRelation
+}
+
+// SeaOrmRelation is a variant of SeaORM 'Relation' enum.
+type SeaOrmRelation struct {
+	Variant string
+	Module  string
+	Attrs   []string
+	Related bool
+}
+
+func (g *Generator) seaOrmFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"join": strings.Join,
+		"fieldAttrs": func(field *RustField) []string {
+			var attrs []string
+			column := field.Column
+			if column.PrimaryKey {
+				attrs = append(attrs, "primary_key")
+				if !column.AutoIncremental {
+					attrs = append(attrs, "auto_increment = false")
+				}
+			}
+			if column.UniqueKey {
+				attrs = append(attrs, "unique")
+			}
+			if field.Renamed {
+				attrs = append(attrs, fmt.Sprintf("column_name = \"%s\"", column.Name))
+			}
+			return attrs
+		},
+	}
+}
+
+// seaOrmRelations returns 'Relation' enum variants of the struct.
+func seaOrmRelations(s *RustStruct) []*SeaOrmRelation {
+	var relations []*SeaOrmRelation
+	variantSet := util.NewStringSet()
+	relatedSet := util.NewStringSet()
+
+	add := func(variant string, suffix string, module string, attrs []string) {
+		if variantSet.Contains(variant) {
+			variant = variant + suffix
+		}
+		variantSet.Add(variant)
+
+		relations = append(relations, &SeaOrmRelation{
+			Variant: variant,
+			Module:  module,
+			Attrs:   attrs,
+			Related: !relatedSet.Contains(module),
+		})
+		relatedSet.Add(module)
+	}
+
+	// belongs_to
+	for _, ref := range s.References {
+		target := ref.Target
+		// entities are not generated for tables without primary key
+		if !target.HasPK() {
+			continue
+		}
+		add(strcase.ToCamel(target.Ident), fieldSuffix(ref.Field), target.Ident, []string{
+			fmt.Sprintf("belongs_to = \"super::%s::Entity\"", target.Ident),
+			fmt.Sprintf("from = \"Column::%s\"", strcase.ToCamel(ref.Field.Name)),
+			fmt.Sprintf("to = \"super::%s::Column::%s\"", target.Ident, strcase.ToCamel(ref.TargetField.Name)),
+		})
+	}
+
+	// has_many, has_one
+	for _, ref := range s.ReverseReferences {
+		source := ref.Source
+		if !source.HasPK() {
+			continue
+		}
+		kind := "has_many"
+		if ref.Relationship == octopus.RefOneToOne {
+			kind = "has_one"
+		}
+		add(strcase.ToCamel(source.Ident), fieldSuffix(ref.Field), source.Ident, []string{
+			fmt.Sprintf("%s = \"super::%s::Entity\"", kind, source.Ident),
+		})
+	}
+
+	return relations
+}
+
+// fieldSuffix returns variant suffix to distinguish multiple relations to the same entity.
+// 'parent_id' field is mapped to 'Parent'.
+func fieldSuffix(field *RustField) string {
+	return strcase.ToCamel(strings.TrimSuffix(field.Column.Name, "_id"))
+}
+
+// GenerateSeaOrmEntity generates SeaORM entity of the struct.
+func (g *Generator) GenerateSeaOrmEntity(wr io.Writer, s *RustStruct) error {
+	tmpl, err := util.NewTemplate("seaOrmEntity", SeaOrmEntityTemplate, g.seaOrmFuncMap())
+	if err != nil {
+		return err
+	}
+
+	importSet := util.NewStringSet("sea_orm::entity::prelude::*")
+	for _, field := range s.Fields {
+		importSet.AddAll(field.Imports)
+	}
+
+	return tmpl.Execute(wr, &SeaOrmTplData{
+		Imports:   importSet.Slice(),
+		Struct:    s,
+		Relations: seaOrmRelations(s),
+	})
+}
+
+// GenerateSeaOrmMod generates 'mod.rs'.
+func (g *Generator) GenerateSeaOrmMod(wr io.Writer) error {
+	tmpl, err := util.NewTemplate("seaOrmMod", SeaOrmModTemplate, g.seaOrmFuncMap())
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(wr, &SeaOrmTplData{Structs: g.pkStructs()})
+}
+
+// GenerateSeaOrmPrelude generates 'prelude.rs'.
+func (g *Generator) GenerateSeaOrmPrelude(wr io.Writer) error {
+	tmpl, err := util.NewTemplate("seaOrmPrelude", SeaOrmPreludeTemplate, g.seaOrmFuncMap())
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(wr, &SeaOrmTplData{Structs: g.pkStructs()})
+}
@@ -0,0 +1,133 @@
+package seed
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FlagFormat    = "format"
+	FlagGroups    = "groups"
+	FlagInput     = "input"
+	FlagOutput    = "output"
+	FlagProviders = "providers"
+	FlagRows      = "rows"
+	FlagSeed      = "seed"
+	FlagTableRows = "tableRows"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	outputPath := c.String(FlagOutput)
+	format := c.String(FlagFormat)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(outputPath)), ".")
+		if format != FormatJson {
+			format = FormatSql
+		}
+	}
+
+	tableRows, err := parseTableRows(c.String(FlagTableRows))
+	if err != nil {
+		return err
+	}
+
+	seed := c.Int64(FlagSeed)
+	if !c.IsSet(FlagSeed) {
+		seed = time.Now().UnixNano()
+	}
+
+	gen := NewGenerator(schema, &Option{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+		Format:      format,
+		Providers:   c.String(FlagProviders),
+		Rows:        c.Int(FlagRows),
+		Seed:        seed,
+		TableRows:   tableRows,
+	})
+	return gen.Generate(outputPath)
+}
+
+// parseTableRows parses row counts by table. ex: 'user:100,group:5'
+func parseTableRows(s string) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		kv := strings.SplitN(token, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid table rows: %s", token)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid table rows: %s", token)
+		}
+		result[strings.TrimSpace(kv[0])] = count
+	}
+	return result, nil
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "write seed data to `FILE`/`DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagFormat,
+		Aliases: []string{"f"},
+		Usage:   "output `FORMAT`. available values: sql, csv, json",
+		EnvVars: []string{"OCTOPUS_FORMAT"},
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagProviders,
+		Aliases: []string{"p"},
+		Usage:   "set value providers by column name pattern. ex: 'nick:username,*_code:uuid,group.name:company'",
+		EnvVars: []string{"OCTOPUS_PROVIDERS"},
+	},
+	&cli.IntFlag{
+		Name:    FlagRows,
+		Aliases: []string{"n"},
+		Usage:   "set number of rows per table",
+		Value:   10,
+		EnvVars: []string{"OCTOPUS_ROWS"},
+	},
+	&cli.Int64Flag{
+		Name:    FlagSeed,
+		Aliases: []string{"s"},
+		Usage:   "set random seed to generate deterministic data",
+		EnvVars: []string{"OCTOPUS_SEED"},
+	},
+	&cli.StringFlag{
+		Name:    FlagTableRows,
+		Aliases: []string{"t"},
+		Usage:   "set number of rows by table. ex: 'user:100,group:5'",
+		EnvVars: []string{"OCTOPUS_TABLE_ROWS"},
+	},
+}
@@ -0,0 +1,204 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCsv  = "csv"
+	FormatJson = "json"
+	FormatSql  = "sql"
+)
+
+type Option struct {
+	TableFilter octopus.TableFilterFn
+	Format      string
+	Providers   string
+	Rows        int
+	Seed        int64
+	TableRows   map[string]int
+}
+
+type Generator struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	return &Generator{
+		schema: schema,
+		option: option,
+	}
+}
+
+// Seed returns generated rows of filtered tables in foreign key dependency order.
+func (g *Generator) Seed() ([]*TableData, error) {
+	rules, err := NewProviderRules(g.option.Providers)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := g.schema.FilteredTables(g.option.TableFilter)
+	return newSeeder(g.schema, g.option, rules).seed(tables), nil
+}
+
+// Generate writes seed data to outputPath.
+// 'csv' format writes a file per table to outputPath directory.
+func (g *Generator) Generate(outputPath string) error {
+	dataList, err := g.Seed()
+	if err != nil {
+		return err
+	}
+
+	format := g.option.Format
+	switch format {
+	case FormatCsv:
+		if _, err := util.Mkdir(outputPath); err != nil {
+			return err
+		}
+		for _, data := range dataList {
+			buf := new(bytes.Buffer)
+			if err := WriteCsv(buf, data); err != nil {
+				return err
+			}
+			filename := filepath.Join(outputPath, data.Table.Name+".csv")
+			if err := util.WriteStringToFile(filename, buf.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatJson, FormatSql:
+		filename := outputPath
+		if ext := strings.ToLower(filepath.Ext(outputPath)); ext != "."+format {
+			// ensure directory is created
+			if _, err := util.Mkdir(outputPath); err != nil {
+				return err
+			}
+			filename = filepath.Join(outputPath, "seed."+format)
+		}
+
+		buf := new(bytes.Buffer)
+		if format == FormatJson {
+			err = WriteJson(buf, dataList)
+		} else {
+			err = WriteSql(buf, dataList)
+		}
+		if err != nil {
+			return err
+		}
+		return util.WriteStringToFile(filename, buf.String())
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// WriteSql writes INSERT statements of each table.
+func WriteSql(wr io.Writer, dataList []*TableData) error {
+	for _, data := range dataList {
+		if len(data.Rows) == 0 {
+			continue
+		}
+		var columnNames []string
+		for _, column := range data.Table.Columns {
+			columnNames = append(columnNames, column.Name)
+		}
+
+		var rows []string
+		for _, row := range data.Rows {
+			var values []string
+			for _, value := range row {
+				values = append(values, sqlValue(value))
+			}
+			rows = append(rows, "  ("+strings.Join(values, ", ")+")")
+		}
+
+		if _, err := fmt.Fprintf(wr, "INSERT INTO `%s` (%s) VALUES\n%s;\n\n",
+			data.Table.Name,
+			util.QuoteAndJoin(columnNames, "`", ", "),
+			strings.Join(rows, ",\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sqlValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		return util.BoolToString(v, "TRUE", "FALSE")
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case json.Number:
+		return v.String()
+	case HexValue:
+		return "X'" + string(v) + "'"
+	case GeometryValue:
+		return "ST_GeomFromText('" + string(v) + "')"
+	default:
+		s := fmt.Sprint(v)
+		s = strings.ReplaceAll(s, `\`, `\\`)
+		s = strings.ReplaceAll(s, `'`, `''`)
+		return "'" + s + "'"
+	}
+}
+
+// WriteCsv writes rows of a table with header. NULL is written as empty value.
+func WriteCsv(wr io.Writer, data *TableData) error {
+	w := csv.NewWriter(wr)
+	var header []string
+	for _, column := range data.Table.Columns {
+		header = append(header, column.Name)
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range data.Rows {
+		var record []string
+		for _, value := range row {
+			if value == nil {
+				record = append(record, "")
+			} else {
+				record = append(record, fmt.Sprint(value))
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteJson writes rows as a JSON object keyed by table name.
+func WriteJson(wr io.Writer, dataList []*TableData) error {
+	tables := util.NewOrderedMap()
+	for _, data := range dataList {
+		rows := make([]*util.OrderedMap, 0, len(data.Rows))
+		for _, row := range data.Rows {
+			m := util.NewOrderedMap()
+			for i, column := range data.Table.Columns {
+				m.Set(column.Name, row[i])
+			}
+			rows = append(rows, m)
+		}
+		tables.Set(data.Table.Name, rows)
+	}
+
+	b, err := json.MarshalIndent(tables, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = wr.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,267 @@
+package seed
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func idColumn() *octopus.Column {
+	return &octopus.Column{
+		Name:            "id",
+		Type:            octopus.ColTypeInt64,
+		PrimaryKey:      true,
+		AutoIncremental: true,
+		NotNull:         true,
+	}
+}
+
+func TestGenerator_Sql(t *testing.T) {
+	Convey("WriteSql", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "account",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{Name: "email", Type: octopus.ColTypeVarchar, Size: 100, UniqueKey: true, NotNull: true},
+						{Name: "status", Type: octopus.ColTypeEnum, Values: []string{"active", "closed"}, NotNull: true},
+						{Name: "balance", Type: octopus.ColTypeDecimal, Size: 8, Scale: 2, NotNull: true},
+						{Name: "verified", Type: octopus.ColTypeBoolean, NotNull: true},
+						{Name: "code", Type: octopus.ColTypeChar, Size: 4, NotNull: true},
+						{Name: "created_at", Type: octopus.ColTypeDateTime, NotNull: true},
+					},
+				},
+			},
+		}
+
+		gen := NewGenerator(schema, &Option{Rows: 3, Seed: 1})
+		dataList, err := gen.Seed()
+		So(err, ShouldBeNil)
+
+		buf := new(bytes.Buffer)
+		So(WriteSql(buf, dataList), ShouldBeNil)
+
+		expected := "INSERT INTO `account` (`id`, `email`, `status`, `balance`, `verified`, `code`, `created_at`) VALUES\n" +
+			"  (1, 'mary.wilson847@example.org', 'closed', 686823.07, TRUE, 'AICM', '2023-01-13 07:32:15'),\n" +
+			"  (2, 'john.johnson728@example.org', 'closed', 293101.86, TRUE, 'CTCU', '2023-09-30 07:43:13'),\n" +
+			"  (3, 'yuki.choi287@example.net', 'active', 696719.17, TRUE, 'AFPL', '2023-10-06 04:01:11');\n" +
+			"\n"
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_References(t *testing.T) {
+	Convey("Seed in dependency order with existing parent values", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user_role",
+					Columns: []*octopus.Column{
+						{
+							Name:       "user_id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+							NotNull:    true,
+							Ref:        &octopus.Reference{Table: "user", Column: "id"},
+						},
+						{
+							Name:       "role_id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+							NotNull:    true,
+							Ref:        &octopus.Reference{Table: "role", Column: "id"},
+						},
+					},
+				},
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+							NotNull:         true,
+							// profile.user_id references user.id
+							Ref: &octopus.Reference{
+								Table:        "profile",
+								Column:       "user_id",
+								Relationship: octopus.RefOneToMany,
+							},
+						},
+						{Name: "name", Type: octopus.ColTypeVarchar, Size: 3, UniqueKey: true, NotNull: true},
+						{
+							Name: "manager_id",
+							Type: octopus.ColTypeInt64,
+							Ref:  &octopus.Reference{Table: "user", Column: "id"},
+						},
+					},
+				},
+				{
+					Name: "role",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{Name: "nickname", Type: octopus.ColTypeVarchar, Size: 20, NotNull: true},
+					},
+				},
+				{
+					Name: "profile",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{Name: "user_id", Type: octopus.ColTypeInt64, UniqueKey: true, NotNull: true},
+					},
+				},
+			},
+		}
+
+		gen := NewGenerator(schema, &Option{
+			Rows:      5,
+			Seed:      42,
+			TableRows: map[string]int{"role": 2, "user_role": 20},
+			Providers: "role.nickname:company",
+		})
+		dataList, err := gen.Seed()
+		So(err, ShouldBeNil)
+
+		var tableNames []string
+		dataByName := make(map[string]*TableData)
+		for _, data := range dataList {
+			tableNames = append(tableNames, data.Table.Name)
+			dataByName[data.Table.Name] = data
+		}
+		So(tableNames, ShouldResemble, []string{"user", "role", "user_role", "profile"})
+
+		valueSet := func(tableName string, columnIndex int) *util.StringSet {
+			set := util.NewStringSet()
+			for _, row := range dataByName[tableName].Rows {
+				set.Add(fmt.Sprint(row[columnIndex]))
+			}
+			return set
+		}
+		userIDs := valueSet("user", 0)
+		roleIDs := valueSet("role", 0)
+
+		So(len(dataByName["user"].Rows), ShouldEqual, 5)
+		So(valueSet("user", 1).Slice(), ShouldHaveLength, 5)
+		for _, row := range dataByName["user"].Rows {
+			So(len(row[1].(string)), ShouldBeLessThanOrEqualTo, 3)
+			if row[2] != nil {
+				So(userIDs.Contains(fmt.Sprint(row[2])), ShouldBeTrue)
+			}
+		}
+
+		for _, row := range dataByName["role"].Rows {
+			So([]string{"Acme", "Globex", "Initech", "Umbrella", "Stark Industries", "Wayne Enterprises",
+				"Hooli", "Vandelay Industries"}, ShouldContain, row[1])
+		}
+
+		// composite primary key is unique, so rows are limited to 5 users * 2 roles
+		userRoles := dataByName["user_role"].Rows
+		So(len(userRoles), ShouldBeLessThanOrEqualTo, 10)
+		pkSet := util.NewStringSet()
+		for _, row := range userRoles {
+			So(userIDs.Contains(fmt.Sprint(row[0])), ShouldBeTrue)
+			So(roleIDs.Contains(fmt.Sprint(row[1])), ShouldBeTrue)
+			pkSet.Add(fmt.Sprintf("%v-%v", row[0], row[1]))
+		}
+		So(pkSet.Slice(), ShouldHaveLength, len(userRoles))
+
+		// profile.user_id is a unique foreign key of '1:n' reference
+		for _, row := range dataByName["profile"].Rows {
+			So(userIDs.Contains(fmt.Sprint(row[1])), ShouldBeTrue)
+		}
+		So(valueSet("profile", 1).Slice(), ShouldHaveLength, len(dataByName["profile"].Rows))
+	})
+}
+
+func TestGenerator_UniqueExhausted(t *testing.T) {
+	Convey("Skip rows if unique values are exhausted", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "grade",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{Name: "level", Type: octopus.ColTypeEnum, Values: []string{"low", "high"}, UniqueKey: true, NotNull: true},
+						{Name: "flag", Type: octopus.ColTypeBoolean, UniqueKey: true},
+					},
+				},
+			},
+		}
+
+		gen := NewGenerator(schema, &Option{Rows: 5, Seed: 1})
+		dataList, err := gen.Seed()
+		So(err, ShouldBeNil)
+
+		rows := dataList[0].Rows
+		So(len(rows), ShouldBeLessThanOrEqualTo, 2)
+		levelSet := util.NewStringSet()
+		flagSet := util.NewStringSet()
+		for _, row := range rows {
+			So(levelSet.Contains(fmt.Sprint(row[1])), ShouldBeFalse)
+			levelSet.Add(fmt.Sprint(row[1]))
+			if row[2] != nil {
+				So(flagSet.Contains(fmt.Sprint(row[2])), ShouldBeFalse)
+				flagSet.Add(fmt.Sprint(row[2]))
+			}
+		}
+	})
+}
+
+func TestGenerator_UnresolvedReference(t *testing.T) {
+	Convey("Foreign key to a table not generated", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name:    "user",
+					Group:   "common",
+					Columns: []*octopus.Column{idColumn()},
+				},
+				{
+					Name:  "post",
+					Group: "blog",
+					Columns: []*octopus.Column{
+						idColumn(),
+						{Name: "editor_id", Type: octopus.ColTypeInt64, Ref: &octopus.Reference{Table: "user", Column: "id"}},
+						{Name: "author_id", Type: "INT64", NotNull: true, Ref: &octopus.Reference{Table: "user", Column: "id"}},
+					},
+				},
+			},
+		}
+
+		gen := NewGenerator(schema, &Option{TableFilter: octopus.GetTableFilterFn("blog"), Rows: 3, Seed: 1})
+		dataList, err := gen.Seed()
+		So(err, ShouldBeNil)
+		So(dataList, ShouldHaveLength, 1)
+
+		for _, row := range dataList[0].Rows {
+			// nullable column is set to NULL
+			So(row[1], ShouldBeNil)
+			// upper case type is generated by type
+			So(row[2], ShouldHaveSameTypeAs, int64(0))
+		}
+	})
+}
+
+func TestNewProviderRules(t *testing.T) {
+	Convey("NewProviderRules", t, func() {
+		rules, err := NewProviderRules("nick:username, group.name:company")
+		So(err, ShouldBeNil)
+		So(rules[0].Match("user", "NICK"), ShouldBeTrue)
+		So(rules[1].Match("group", "name"), ShouldBeTrue)
+		So(rules[1].Match("user", "name"), ShouldBeFalse)
+
+		_, err = NewProviderRules("nick:unknown")
+		So(err, ShouldNotBeNil)
+	})
+}
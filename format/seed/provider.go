@@ -0,0 +1,224 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+)
+
+// baseTime is the reference time of generated date/time values, so that output only depends on the seed.
+var baseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Provider generates a value of a column.
+// Fn returns string, or time.Time for date/time providers.
+type Provider struct {
+	Name string
+	Fn   func(r *rand.Rand) interface{}
+}
+
+// ProviderRule maps column names matching Pattern to a provider.
+// Pattern is matched against lowercase column name. ex: 'email', '*_email'
+// If Pattern contains '.', it is matched against '<table>.<column>'. ex: 'group.name'
+type ProviderRule struct {
+	Pattern  string
+	Provider *Provider
+}
+
+func (r *ProviderRule) Match(tableName, columnName string) bool {
+	name := columnName
+	if strings.Contains(r.Pattern, ".") {
+		name = tableName + "." + columnName
+	}
+	matched, _ := path.Match(r.Pattern, strings.ToLower(name))
+	return matched
+}
+
+var (
+	firstNames = []string{
+		"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+		"William", "Elizabeth", "David", "Susan", "Minjun", "Seoyeon", "Hiroshi", "Yuki",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+		"Kim", "Lee", "Park", "Choi", "Tanaka", "Suzuki", "Martin", "Wilson",
+	}
+	cities = []string{
+		"Seoul", "Busan", "Tokyo", "Osaka", "New York", "London", "Paris", "Berlin",
+		"Madrid", "Toronto", "Sydney", "Singapore",
+	}
+	countries = []string{
+		"Korea", "Japan", "United States", "United Kingdom", "France", "Germany",
+		"Spain", "Canada", "Australia", "Singapore",
+	}
+	streets = []string{
+		"Main St", "Oak Ave", "Maple Rd", "Park Ln", "Cedar St", "Elm St", "Lake Dr", "Hill Rd",
+	}
+	companies = []string{
+		"Acme", "Globex", "Initech", "Umbrella", "Stark Industries", "Wayne Enterprises",
+		"Hooli", "Vandelay Industries",
+	}
+	domains = []string{"example.com", "example.net", "example.org"}
+	words   = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+		"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore",
+		"magna", "aliqua",
+	}
+)
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
+
+func randomWords(r *rand.Rand, count int) string {
+	var result []string
+	for i := 0; i < count; i++ {
+		result = append(result, pick(r, words))
+	}
+	return strings.Join(result, " ")
+}
+
+func randomSentence(r *rand.Rand) string {
+	sentence := randomWords(r, 4+r.Intn(8))
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+var providers = []*Provider{
+	{Name: "address", Fn: func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("%d %s, %s", 1+r.Intn(999), pick(r, streets), pick(r, cities))
+	}},
+	{Name: "city", Fn: func(r *rand.Rand) interface{} { return pick(r, cities) }},
+	{Name: "company", Fn: func(r *rand.Rand) interface{} { return pick(r, companies) }},
+	{Name: "country", Fn: func(r *rand.Rand) interface{} { return pick(r, countries) }},
+	{Name: "email", Fn: func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("%s.%s%d@%s",
+			strings.ToLower(pick(r, firstNames)), strings.ToLower(pick(r, lastNames)), r.Intn(1000), pick(r, domains))
+	}},
+	{Name: "firstName", Fn: func(r *rand.Rand) interface{} { return pick(r, firstNames) }},
+	{Name: "futureDateTime", Fn: func(r *rand.Rand) interface{} {
+		return baseTime.Add(time.Duration(r.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+	}},
+	{Name: "ipv4", Fn: func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("%d.%d.%d.%d", 1+r.Intn(254), r.Intn(256), r.Intn(256), 1+r.Intn(254))
+	}},
+	{Name: "lastName", Fn: func(r *rand.Rand) interface{} { return pick(r, lastNames) }},
+	{Name: "name", Fn: func(r *rand.Rand) interface{} {
+		return pick(r, firstNames) + " " + pick(r, lastNames)
+	}},
+	{Name: "pastDateTime", Fn: func(r *rand.Rand) interface{} {
+		return baseTime.Add(-time.Duration(r.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+	}},
+	{Name: "phone", Fn: func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("010-%04d-%04d", r.Intn(10000), r.Intn(10000))
+	}},
+	{Name: "sentence", Fn: func(r *rand.Rand) interface{} { return randomSentence(r) }},
+	{Name: "url", Fn: func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("https://%s/%s", pick(r, domains), pick(r, words))
+	}},
+	{Name: "username", Fn: func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("%s%d", strings.ToLower(pick(r, firstNames)), r.Intn(1000))
+	}},
+	{Name: "uuid", Fn: func(r *rand.Rand) interface{} {
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	}},
+	{Name: "word", Fn: func(r *rand.Rand) interface{} { return pick(r, words) }},
+}
+
+// ProviderByName returns provider of the name, or nil if not found.
+func ProviderByName(name string) *Provider {
+	for _, provider := range providers {
+		if provider.Name == name {
+			return provider
+		}
+	}
+	return nil
+}
+
+// ProviderNames returns names of available providers.
+func ProviderNames() []string {
+	var names []string
+	for _, provider := range providers {
+		names = append(names, provider.Name)
+	}
+	return names
+}
+
+// defaultProviderRules are column name heuristics. First matching rule is used.
+var defaultProviderRules = [][]string{
+	{"email", "email"},
+	{"*_email", "email"},
+	{"first_name", "firstName"},
+	{"firstname", "firstName"},
+	{"last_name", "lastName"},
+	{"lastname", "lastName"},
+	{"username", "username"},
+	{"user_name", "username"},
+	{"login", "username"},
+	{"nickname", "username"},
+	{"name", "name"},
+	{"full_name", "name"},
+	{"display_name", "name"},
+	{"phone", "phone"},
+	{"*_phone", "phone"},
+	{"mobile", "phone"},
+	{"url", "url"},
+	{"*_url", "url"},
+	{"homepage", "url"},
+	{"website", "url"},
+	{"uuid", "uuid"},
+	{"*_uuid", "uuid"},
+	{"guid", "uuid"},
+	{"ip", "ipv4"},
+	{"*_ip", "ipv4"},
+	{"ip_address", "ipv4"},
+	{"city", "city"},
+	{"country", "country"},
+	{"address", "address"},
+	{"*_address", "address"},
+	{"company", "company"},
+	{"company_name", "company"},
+	{"description", "sentence"},
+	{"*_description", "sentence"},
+	{"comment", "sentence"},
+	{"memo", "sentence"},
+	{"note", "sentence"},
+	{"*_at", "pastDateTime"},
+}
+
+// NewProviderRules returns provider rules from 'pattern:provider' definitions.
+// rules from definitions have higher priority than default rules.
+func NewProviderRules(definitions string) ([]*ProviderRule, error) {
+	var rules []*ProviderRule
+	for _, definition := range strings.Split(definitions, ",") {
+		definition = strings.TrimSpace(definition)
+		if definition == "" {
+			continue
+		}
+		tokens := strings.SplitN(definition, ":", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("invalid provider definition: %s", definition)
+		}
+		provider := ProviderByName(strings.TrimSpace(tokens[1]))
+		if provider == nil {
+			return nil, fmt.Errorf("unknown provider: %s. available providers: %s",
+				tokens[1], strings.Join(ProviderNames(), ", "))
+		}
+		rules = append(rules, &ProviderRule{
+			Pattern:  strings.ToLower(strings.TrimSpace(tokens[0])),
+			Provider: provider,
+		})
+	}
+
+	for _, rule := range defaultProviderRules {
+		rules = append(rules, &ProviderRule{
+			Pattern:  rule[0],
+			Provider: ProviderByName(rule[1]),
+		})
+	}
+	return rules, nil
+}
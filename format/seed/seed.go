@@ -0,0 +1,453 @@
+package seed
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"log"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// nullRatio is the ratio of NULL values in nullable columns.
+	nullRatio = 0.1
+	// maxRetries is the number of retries to generate a unique value.
+	maxRetries = 10
+)
+
+// HexValue is a binary value encoded in hex.
+type HexValue string
+
+// GeometryValue is a geometry value in WKT format.
+type GeometryValue string
+
+// TableData is the generated rows of a table.
+// Row values are one of nil, bool, int64, json.Number, string, HexValue, GeometryValue.
+type TableData struct {
+	Table *octopus.Table
+	Rows  [][]interface{}
+}
+
+// columnRef is a column referenced by a foreign key column.
+type columnRef struct {
+	table  string
+	column string
+}
+
+type seeder struct {
+	schema        *octopus.Schema
+	option        *Option
+	rand          *rand.Rand
+	rules         []*ProviderRule
+	parentRefs    map[string]map[string]columnRef
+	dataByTable   map[string]*TableData
+	uniqueByTable map[string]map[string]*util.StringSet
+	unresolved    *util.StringSet
+}
+
+func newSeeder(schema *octopus.Schema, option *Option, rules []*ProviderRule) *seeder {
+	s := &seeder{
+		schema:        schema,
+		option:        option,
+		rand:          rand.New(rand.NewSource(option.Seed)),
+		rules:         rules,
+		parentRefs:    make(map[string]map[string]columnRef),
+		dataByTable:   make(map[string]*TableData),
+		unresolved:    util.NewStringSet(),
+		uniqueByTable: make(map[string]map[string]*util.StringSet),
+	}
+
+	// '1:n' reference is reversed, so that every reference starts from the foreign key column.
+	addRef := func(table, column string, parent columnRef) {
+		refs, ok := s.parentRefs[table]
+		if !ok {
+			refs = make(map[string]columnRef)
+			s.parentRefs[table] = refs
+		}
+		refs[column] = parent
+	}
+	for _, table := range schema.Tables {
+		for _, column := range table.Columns {
+			ref := column.Ref
+			if ref == nil {
+				continue
+			}
+			if ref.Relationship == octopus.RefOneToMany {
+				addRef(ref.Table, ref.Column, columnRef{table: table.Name, column: column.Name})
+			} else {
+				addRef(table.Name, column.Name, columnRef{table: ref.Table, column: ref.Column})
+			}
+		}
+	}
+	return s
+}
+
+// sortTables returns tables in foreign key dependency order. parent tables come first.
+// If there is a circular dependency, the first remaining table in schema order is used.
+func (s *seeder) sortTables(tables []*octopus.Table) []*octopus.Table {
+	tableNames := util.NewStringSet()
+	for _, table := range tables {
+		tableNames.Add(table.Name)
+	}
+
+	var sorted []*octopus.Table
+	sortedNames := util.NewStringSet()
+	isReady := func(table *octopus.Table) bool {
+		for _, parent := range s.parentRefs[table.Name] {
+			if parent.table != table.Name && tableNames.Contains(parent.table) && !sortedNames.Contains(parent.table) {
+				return false
+			}
+		}
+		return true
+	}
+
+	remaining := tables
+	for len(remaining) > 0 {
+		index := 0
+		for i, table := range remaining {
+			if isReady(table) {
+				index = i
+				break
+			}
+		}
+		table := remaining[index]
+		sorted = append(sorted, table)
+		sortedNames.Add(table.Name)
+		remaining = append(remaining[:index:index], remaining[index+1:]...)
+	}
+	return sorted
+}
+
+// seed generates rows of tables in dependency order.
+func (s *seeder) seed(tables []*octopus.Table) []*TableData {
+	var result []*TableData
+	for _, table := range s.sortTables(tables) {
+		data := s.seedTable(table, s.rowCount(table))
+		s.dataByTable[table.Name] = data
+		result = append(result, data)
+	}
+	return result
+}
+
+func (s *seeder) rowCount(table *octopus.Table) int {
+	if count, ok := s.option.TableRows[table.Name]; ok {
+		return count
+	}
+	return s.option.Rows
+}
+
+// seedTable generates rows of the table.
+// Rows with duplicated primary key or unique value are skipped, so less rows can be generated if values are exhausted.
+func (s *seeder) seedTable(table *octopus.Table, count int) *TableData {
+	data := &TableData{Table: table}
+	s.dataByTable[table.Name] = data
+
+	var pkColumnCount int
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			pkColumnCount++
+		}
+	}
+
+	pkSet := util.NewStringSet()
+	for attempt := 0; len(data.Rows) < count && attempt < count*maxRetries; attempt++ {
+		index := len(data.Rows)
+		row := make([]interface{}, len(table.Columns))
+		var pkValues []string
+		duplicated := false
+		for i, column := range table.Columns {
+			var value interface{}
+			if column.PrimaryKey && pkColumnCount == 1 && isIntegerType(column.Type) && s.parentRef(table, column) == nil {
+				value = int64(index + 1)
+			} else {
+				var unique bool
+				if value, unique = s.uniqueValue(table, column, index); !unique {
+					duplicated = true
+					break
+				}
+			}
+			row[i] = value
+			if column.PrimaryKey {
+				pkValues = append(pkValues, fmt.Sprint(value))
+			}
+		}
+		if duplicated {
+			continue
+		}
+		if pkColumnCount > 0 {
+			key := strings.Join(pkValues, "\x00")
+			if pkSet.Contains(key) {
+				continue
+			}
+			pkSet.Add(key)
+		}
+		s.addUniqueValues(table, row)
+		data.Rows = append(data.Rows, row)
+	}
+	if len(data.Rows) < count {
+		log.Printf("%s: %d of %d rows generated. unique values are exhausted", table.Name, len(data.Rows), count)
+	}
+	return data
+}
+
+func (s *seeder) uniqueSet(table *octopus.Table, column *octopus.Column) *util.StringSet {
+	sets, ok := s.uniqueByTable[table.Name]
+	if !ok {
+		sets = make(map[string]*util.StringSet)
+		s.uniqueByTable[table.Name] = sets
+	}
+	set, ok := sets[column.Name]
+	if !ok {
+		set = util.NewStringSet()
+		sets[column.Name] = set
+	}
+	return set
+}
+
+func (s *seeder) addUniqueValues(table *octopus.Table, row []interface{}) {
+	for i, column := range table.Columns {
+		if column.UniqueKey && row[i] != nil {
+			s.uniqueSet(table, column).Add(fmt.Sprint(row[i]))
+		}
+	}
+}
+
+// uniqueValue returns a value of the column. If the column is a unique key, retries until the value is unique.
+// Textual value is prefixed with row number if retries are exhausted.
+// bool is false if unique value is not found.
+func (s *seeder) uniqueValue(table *octopus.Table, column *octopus.Column, index int) (interface{}, bool) {
+	value := s.value(table, column)
+	if !column.UniqueKey || value == nil {
+		return value, true
+	}
+	set := s.uniqueSet(table, column)
+	for i := 0; value != nil && set.Contains(fmt.Sprint(value)) && i < maxRetries; i++ {
+		value = s.value(table, column)
+	}
+	if str, ok := value.(string); ok && set.Contains(str) && isTextType(column.Type) {
+		value = fitSize(strconv.Itoa(index+1)+"_"+str, column)
+	}
+	return value, value == nil || !set.Contains(fmt.Sprint(value))
+}
+
+func (s *seeder) parentRef(table *octopus.Table, column *octopus.Column) *columnRef {
+	if refs, ok := s.parentRefs[table.Name]; ok {
+		if ref, ok := refs[column.Name]; ok {
+			return &ref
+		}
+	}
+	return nil
+}
+
+// parentValue returns a random value of referenced column from generated rows.
+// returns false if referenced table is not generated.
+func (s *seeder) parentValue(ref *columnRef) (interface{}, bool) {
+	data, ok := s.dataByTable[ref.table]
+	if !ok {
+		return nil, false
+	}
+	columnIndex := -1
+	for i, column := range data.Table.Columns {
+		if column.Name == ref.column {
+			columnIndex = i
+		}
+	}
+	if columnIndex < 0 {
+		return nil, false
+	}
+	if len(data.Rows) == 0 {
+		return nil, true
+	}
+	return data.Rows[s.rand.Intn(len(data.Rows))][columnIndex], true
+}
+
+// value returns a random value of the column.
+// Foreign key value is picked from the parent rows. If the parent has no rows(ex: filtered out, circular dependency),
+// nullable column is set to NULL, otherwise a random value is used with a warning.
+func (s *seeder) value(table *octopus.Table, column *octopus.Column) interface{} {
+	nullable := !column.NotNull && !column.PrimaryKey
+	if ref := s.parentRef(table, column); ref != nil {
+		if nullable && s.rand.Float64() < nullRatio {
+			return nil
+		}
+		if value, ok := s.parentValue(ref); ok && value != nil {
+			return value
+		}
+		if nullable {
+			return nil
+		}
+		s.warnUnresolved(table, column, ref)
+		return s.typeValue(column)
+	}
+
+	if nullable && s.rand.Float64() < nullRatio {
+		return nil
+	}
+
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeEnum:
+		if len(column.Values) > 0 {
+			return pick(s.rand, column.Values)
+		}
+	case octopus.ColTypeSet:
+		if len(column.Values) > 0 {
+			var values []string
+			for _, value := range column.Values {
+				if s.rand.Intn(2) == 0 {
+					values = append(values, value)
+				}
+			}
+			if len(values) == 0 {
+				values = append(values, pick(s.rand, column.Values))
+			}
+			return strings.Join(values, ",")
+		}
+	}
+
+	for _, rule := range s.rules {
+		if rule.Match(table.Name, column.Name) {
+			if value, ok := s.providerValue(rule.Provider, column); ok {
+				return value
+			}
+			break
+		}
+	}
+
+	return s.typeValue(column)
+}
+
+// warnUnresolved logs foreign key column which has no parent value, once per column.
+func (s *seeder) warnUnresolved(table *octopus.Table, column *octopus.Column, ref *columnRef) {
+	key := table.Name + "." + column.Name
+	if s.unresolved.Contains(key) {
+		return
+	}
+	s.unresolved.Add(key)
+	log.Printf("[WARN] %s: no rows in %s.%s. random values are generated", key, ref.table, ref.column)
+}
+
+// providerValue returns a value from the provider. returns false if provider is not compatible with column type.
+func (s *seeder) providerValue(provider *Provider, column *octopus.Column) (interface{}, bool) {
+	switch value := provider.Fn(s.rand).(type) {
+	case time.Time:
+		if formatted, ok := formatTime(value, column.Type); ok {
+			return formatted, true
+		}
+	case string:
+		if isTextType(column.Type) {
+			return fitSize(value, column), true
+		}
+	}
+	return nil, false
+}
+
+// typeValue returns a random value by column type.
+func (s *seeder) typeValue(column *octopus.Column) interface{} {
+	r := s.rand
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeBoolean:
+		return r.Intn(2) == 1
+	case octopus.ColTypeBit:
+		return int64(r.Intn(2))
+	case octopus.ColTypeInt8:
+		return int64(1 + r.Intn(100))
+	case octopus.ColTypeInt16:
+		return int64(1 + r.Intn(1000))
+	case octopus.ColTypeInt24, octopus.ColTypeInt32, octopus.ColTypeInt64:
+		return int64(1 + r.Intn(100000))
+	case octopus.ColTypeYear:
+		return int64(1990 + r.Intn(40))
+	case octopus.ColTypeDecimal:
+		size := int(column.Size)
+		if size == 0 {
+			size = 10
+		}
+		scale := int(column.Scale)
+		intDigits := size - scale
+		if intDigits > 6 {
+			intDigits = 6
+		}
+		value := r.Float64() * math.Pow10(intDigits)
+		return json.Number(strconv.FormatFloat(value, 'f', scale, 64))
+	case octopus.ColTypeFloat, octopus.ColTypeDouble:
+		return json.Number(strconv.FormatFloat(r.Float64()*1000, 'f', 2, 64))
+	case octopus.ColTypeDate, octopus.ColTypeDateTime, octopus.ColTypeTime:
+		t := baseTime.Add(-time.Duration(r.Int63n(int64(3 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+		formatted, _ := formatTime(t, column.Type)
+		return formatted
+	case octopus.ColTypeChar:
+		size := int(column.Size)
+		if size == 0 {
+			size = 1
+		}
+		b := make([]byte, size)
+		for i := range b {
+			b[i] = byte('A' + r.Intn(26))
+		}
+		return string(b)
+	case octopus.ColTypeVarchar:
+		return fitSize(randomWords(r, 1+r.Intn(3)), column)
+	case octopus.ColTypeText8, octopus.ColTypeText16, octopus.ColTypeText24, octopus.ColTypeText32:
+		return fitSize(randomSentence(r), column)
+	case octopus.ColTypeJSON:
+		return fmt.Sprintf(`{"%s": %d}`, pick(r, words), r.Intn(100))
+	case octopus.ColTypeBinary, octopus.ColTypeVarbinary,
+		octopus.ColTypeBlob8, octopus.ColTypeBlob16, octopus.ColTypeBlob24, octopus.ColTypeBlob32:
+		size := int(column.Size)
+		if size == 0 || size > 16 {
+			size = 16
+		}
+		b := make([]byte, size)
+		r.Read(b)
+		return HexValue(hex.EncodeToString(b))
+	case octopus.ColTypeGeometry, octopus.ColTypePoint:
+		return GeometryValue(fmt.Sprintf("POINT(%.4f %.4f)", r.Float64()*180-90, r.Float64()*360-180))
+	case octopus.ColTypeEnum, octopus.ColTypeSet:
+		return ""
+	default:
+		return fitSize(pick(r, words), column)
+	}
+}
+
+func formatTime(t time.Time, colType string) (string, bool) {
+	switch strings.ToLower(colType) {
+	case octopus.ColTypeDate:
+		return t.Format("2006-01-02"), true
+	case octopus.ColTypeDateTime:
+		return t.Format("2006-01-02 15:04:05"), true
+	case octopus.ColTypeTime:
+		return t.Format("15:04:05"), true
+	}
+	return "", false
+}
+
+// fitSize truncates string value to column size.
+func fitSize(value string, column *octopus.Column) string {
+	if column.Size > 0 && len(value) > int(column.Size) && strings.ToLower(column.Type) != octopus.ColTypeDecimal {
+		return value[:column.Size]
+	}
+	return value
+}
+
+func isIntegerType(colType string) bool {
+	switch strings.ToLower(colType) {
+	case octopus.ColTypeInt8, octopus.ColTypeInt16, octopus.ColTypeInt24, octopus.ColTypeInt32, octopus.ColTypeInt64:
+		return true
+	}
+	return false
+}
+
+func isTextType(colType string) bool {
+	switch strings.ToLower(colType) {
+	case octopus.ColTypeChar, octopus.ColTypeVarchar,
+		octopus.ColTypeText8, octopus.ColTypeText16, octopus.ColTypeText24, octopus.ColTypeText32:
+		return true
+	}
+	return false
+}
@@ -21,6 +21,9 @@ type Option struct {
 	UseUTC           bool
 }
 
+// baseModuleName is the module name declaring shared 'Base' when each class is generated in its own file.
+const baseModuleName = "base"
+
 type Generator struct {
 	schema *octopus.Schema
 	option *Option
@@ -63,10 +66,21 @@ func (c *Generator) Generate(outputPath string) error {
 		}
 		classes = append(classes, NewSaClass(table, option))
 	}
+	populateRelations(classes, option)
+
+	if !generateSingleFile {
+		for _, class := range classes {
+			if class.table.Name == baseModuleName {
+				return fmt.Errorf("table name conflicts with base module: %s", class.table.Name)
+			}
+		}
+	}
 
 	// imports from sqlalchemy
 	saImportSet := util.NewStringSet()
 	saImportSet.Add("Column")
+	// imports from sqlalchemy.orm
+	ormImportSet := util.NewStringSet()
 	// imports
 	importSet := util.NewStringSet()
 
@@ -76,6 +90,7 @@ func (c *Generator) Generate(outputPath string) error {
 
 	for _, class := range classes {
 		table := class.table
+		classUseTZDateTime := false
 
 		var classLines []string
 		appendLine := func(lines ...string) {
@@ -113,19 +128,7 @@ func (c *Generator) Generate(outputPath string) error {
 				attributes = append(attributes, util.Quote(column.Name, "'"))
 			}
 
-			if (lcColumnType == octopus.ColTypeVarchar || lcColumnType == octopus.ColTypeChar) && column.Size > 0 {
-				attributes = append(attributes, fmt.Sprintf("%s(%d)", field.Type, column.Size))
-			} else if lcColumnType == octopus.ColTypeDouble || lcColumnType == octopus.ColTypeFloat || lcColumnType == octopus.ColTypeDecimal {
-				var colAttrs []string
-				if column.Size > 0 {
-					colAttrs = append(colAttrs, fmt.Sprintf("precision=%d", column.Size))
-				}
-				if column.Scale > 0 {
-					colAttrs = append(colAttrs, fmt.Sprintf("scale=%d", column.Scale))
-				}
-
-				attributes = append(attributes, fmt.Sprintf("%s(%s)", field.Type, strings.Join(colAttrs, ", ")))
-			} else if lcColumnType == octopus.ColTypeDateTime {
+			if lcColumnType == octopus.ColTypeDateTime {
 				if column.Name == "created_at" {
 					if useUTC {
 						attributes = append(attributes, field.Type, "default=datetime.utcnow")
@@ -142,15 +145,19 @@ func (c *Generator) Generate(outputPath string) error {
 					if useUTC {
 						attributes = append(attributes, "TZDateTime")
 						useTZDateTime = true
-						saImportSet.Add("TypeDecorator")
-						importSet.Add("from datetime import timezone")
+						classUseTZDateTime = true
 					} else {
 						attributes = append(attributes, field.Type)
 					}
 				}
 				importSet.Add("from datetime import datetime")
 			} else {
-				attributes = append(attributes, field.Type)
+				attributes = append(attributes, field.TypeExpr())
+			}
+			// FK
+			if field.ForeignKey != "" {
+				attributes = append(attributes, fmt.Sprintf("ForeignKey('%s')", field.ForeignKey))
+				saImportSet.Add("ForeignKey")
 			}
 			// PK
 			if column.PrimaryKey {
@@ -177,10 +184,23 @@ func (c *Generator) Generate(outputPath string) error {
 			}
 		}
 
+		// relations
+		if len(class.Relations) > 0 {
+			appendLine("")
+			for _, relation := range class.Relations {
+				appendLine(indent + fmt.Sprintf("%s = relationship(%s)", relation.Name, strings.Join(relation.RelationshipArgs(), ", ")))
+			}
+			ormImportSet.Add("relationship")
+		}
+
 		if generateSingleFile {
 			contents = append(contents, classLines...)
 		} else {
-			contents = append(contents, c.getHeaderLines(importSet.Slice(), saImportSet.Slice())...)
+			baseImports := []string{"Base"}
+			if classUseTZDateTime {
+				baseImports = append(baseImports, "TZDateTime")
+			}
+			contents = append(contents, c.getHeaderLines(importSet.Slice(), saImportSet.Slice(), ormImportSet.Slice(), baseImports)...)
 			contents = append(contents, classLines...)
 			contents = append(contents, "")
 
@@ -192,13 +212,19 @@ func (c *Generator) Generate(outputPath string) error {
 			// reset slice
 			contents = make([]string, 0)
 			saImportSet.Clear()
+			saImportSet.Add("Column")
+			ormImportSet.Clear()
 			importSet.Clear()
 		}
 	}
 
 	// Write to single file
 	if generateSingleFile {
-		finalOutput := c.getHeaderLines(importSet.Slice(), saImportSet.Slice())
+		if useTZDateTime {
+			saImportSet.Add("TypeDecorator")
+			importSet.Add("from datetime import timezone")
+		}
+		finalOutput := c.getHeaderLines(importSet.Slice(), saImportSet.Slice(), ormImportSet.Slice(), nil)
 		if useTZDateTime {
 			finalOutput = append(finalOutput, c.getTZDateTimeLines()...)
 		}
@@ -208,6 +234,23 @@ func (c *Generator) Generate(outputPath string) error {
 		if err := util.WriteLinesToFile(outputPath, finalOutput); err != nil {
 			return err
 		}
+	} else {
+		// Write shared base module
+		var imports, saImports []string
+		if useTZDateTime {
+			imports = []string{"from datetime import timezone"}
+			saImports = []string{"DateTime", "TypeDecorator"}
+		}
+		baseOutput := c.getHeaderLines(imports, saImports, nil, nil)
+		if useTZDateTime {
+			baseOutput = append(baseOutput, c.getTZDateTimeLines()...)
+		}
+		baseOutput = append(baseOutput, "")
+
+		outputFile := path.Join(outputDir, baseModuleName+".py")
+		if err := util.WriteLinesToFile(outputFile, baseOutput); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -239,7 +282,9 @@ func (c *Generator) getTZDateTimeLines() []string {
 	}
 }
 
-func (c *Generator) getHeaderLines(imports []string, saImports []string) []string {
+// getHeaderLines returns import lines and 'Base' declaration.
+// if baseImports is not empty, 'Base' is imported from the shared base module instead.
+func (c *Generator) getHeaderLines(imports []string, saImports []string, ormImports []string, baseImports []string) []string {
 	var lines []string
 
 	if len(imports) > 0 {
@@ -247,13 +292,27 @@ func (c *Generator) getHeaderLines(imports []string, saImports []string) []strin
 		lines = append(lines, "")
 	}
 
-	lines = append(lines,
-		"from sqlalchemy import "+strings.Join(saImports, ", "),
-		"from sqlalchemy.ext.declarative import declarative_base",
-		"from sqlalchemy_repr import RepresentableBase",
-		"",
-		"Base = declarative_base(cls=RepresentableBase)",
-	)
+	if len(saImports) > 0 {
+		lines = append(lines, "from sqlalchemy import "+strings.Join(saImports, ", "))
+	}
+	if len(baseImports) == 0 {
+		lines = append(lines, "from sqlalchemy.ext.declarative import declarative_base")
+	}
+	if len(ormImports) > 0 {
+		lines = append(lines, "from sqlalchemy.orm import "+strings.Join(ormImports, ", "))
+	}
+	if len(baseImports) > 0 {
+		lines = append(lines,
+			"",
+			fmt.Sprintf("from .%s import %s", baseModuleName, strings.Join(baseImports, ", ")),
+		)
+	} else {
+		lines = append(lines,
+			"from sqlalchemy_repr import RepresentableBase",
+			"",
+			"Base = declarative_base(cls=RepresentableBase)",
+		)
+	}
 
 	return lines
 }
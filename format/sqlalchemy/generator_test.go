@@ -2,9 +2,14 @@ package sqlalchemy
 
 import (
 	"bytes"
+	"github.com/google/go-cmp/cmp"
 	"github.com/lechuckroh/octopus-db-tools/format/common"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -77,3 +82,345 @@ func TestGenerator_GenerateClass(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerator_Relations(t *testing.T) {
+	Convey("Generate relations", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "group",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name:    "name",
+							Type:    octopus.ColTypeVarchar,
+							Size:    40,
+							NotNull: true,
+						},
+					},
+				},
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name: "group_id",
+							Type: octopus.ColTypeInt64,
+							Ref: &octopus.Reference{
+								Table:        "group",
+								Column:       "id",
+								Relationship: octopus.RefManyToOne,
+							},
+						},
+						{
+							Name: "manager_id",
+							Type: octopus.ColTypeInt64,
+							Ref: &octopus.Reference{
+								Table:  "user",
+								Column: "id",
+							},
+						},
+					},
+				},
+				{
+					Name: "user_profile",
+					Columns: []*octopus.Column{
+						{
+							Name:       "user_id",
+							Type:       octopus.ColTypeInt64,
+							PrimaryKey: true,
+							Ref: &octopus.Reference{
+								Table:        "user",
+								Column:       "id",
+								Relationship: octopus.RefOneToOne,
+							},
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			PrefixMapper:   common.NewPrefixMapper(""),
+			RemovePrefixes: []string{},
+		}
+
+		expected := `from sqlalchemy import BigInteger, Column, ForeignKey, String
+from sqlalchemy.ext.declarative import declarative_base
+from sqlalchemy.orm import relationship
+from sqlalchemy_repr import RepresentableBase
+
+Base = declarative_base(cls=RepresentableBase)
+
+
+class Group(Base):
+    __tablename__ = 'group'
+
+    id = Column(BigInteger, primary_key=True, autoincrement=True)
+    name = Column(String(40), nullable=False)
+
+    users = relationship('User', back_populates='group')
+
+
+class User(Base):
+    __tablename__ = 'user'
+
+    id = Column(BigInteger, primary_key=True, autoincrement=True)
+    group_id = Column(BigInteger, ForeignKey('group.id'))
+    manager_id = Column(BigInteger, ForeignKey('user.id'))
+
+    group = relationship('Group', back_populates='users')
+    manager = relationship('User', remote_side=[id])
+    user_profile = relationship('UserProfile', back_populates='user', uselist=False)
+
+
+class UserProfile(Base):
+    __tablename__ = 'user_profile'
+
+    user_id = Column(BigInteger, ForeignKey('user.id'), primary_key=True)
+
+    user = relationship('User', back_populates='user_profile')
+`
+
+		dir, err := ioutil.TempDir("", "sqlalchemy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		outputFile := filepath.Join(dir, "models.py")
+
+		g := Generator{
+			schema: schema,
+			option: option,
+		}
+		if err := g.Generate(outputFile); err != nil {
+			t.Error(err)
+		}
+		data, err := ioutil.ReadFile(outputFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := string(data)
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_MultipleForeignKeys(t *testing.T) {
+	Convey("Generate relations of multiple foreign keys to one table", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true},
+					},
+				},
+				{
+					Name: "post",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true},
+						{
+							Name: "author_id",
+							Type: octopus.ColTypeInt64,
+							Ref:  &octopus.Reference{Table: "user", Column: "id"},
+						},
+						{
+							Name: "editor_id",
+							Type: octopus.ColTypeInt64,
+							Ref:  &octopus.Reference{Table: "user", Column: "id"},
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			PrefixMapper:   common.NewPrefixMapper(""),
+			RemovePrefixes: []string{},
+		}
+
+		expected := `from sqlalchemy import BigInteger, Column, ForeignKey
+from sqlalchemy.ext.declarative import declarative_base
+from sqlalchemy.orm import relationship
+from sqlalchemy_repr import RepresentableBase
+
+Base = declarative_base(cls=RepresentableBase)
+
+
+class User(Base):
+    __tablename__ = 'user'
+
+    id = Column(BigInteger, primary_key=True)
+
+    posts = relationship('Post', back_populates='author', foreign_keys='[Post.author_id]')
+    posts_ref = relationship('Post', back_populates='editor', foreign_keys='[Post.editor_id]')
+
+
+class Post(Base):
+    __tablename__ = 'post'
+
+    id = Column(BigInteger, primary_key=True)
+    author_id = Column(BigInteger, ForeignKey('user.id'))
+    editor_id = Column(BigInteger, ForeignKey('user.id'))
+
+    author = relationship('User', back_populates='posts', foreign_keys='[Post.author_id]')
+    editor = relationship('User', back_populates='posts_ref', foreign_keys='[Post.editor_id]')
+`
+
+		dir, err := ioutil.TempDir("", "sqlalchemy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		outputFile := filepath.Join(dir, "models.py")
+
+		g := Generator{
+			schema: schema,
+			option: option,
+		}
+		if err := g.Generate(outputFile); err != nil {
+			t.Error(err)
+		}
+		data, err := ioutil.ReadFile(outputFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := string(data)
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_SharedBase(t *testing.T) {
+	Convey("Generate files with shared base module", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "group",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+					},
+				},
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{
+							Name:            "id",
+							Type:            octopus.ColTypeInt64,
+							PrimaryKey:      true,
+							AutoIncremental: true,
+						},
+						{
+							Name: "group_id",
+							Type: octopus.ColTypeInt64,
+							Ref: &octopus.Reference{
+								Table:  "group",
+								Column: "id",
+							},
+						},
+						{
+							Name: "login_at",
+							Type: octopus.ColTypeDateTime,
+						},
+					},
+				},
+			},
+		}
+		option := &Option{
+			PrefixMapper:   common.NewPrefixMapper(""),
+			RemovePrefixes: []string{},
+			UseUTC:         true,
+		}
+
+		dir, err := ioutil.TempDir("", "sqlalchemy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		g := Generator{
+			schema: schema,
+			option: option,
+		}
+		if err := g.Generate(dir); err != nil {
+			t.Error(err)
+		}
+
+		readFile := func(filename string) string {
+			data, err := ioutil.ReadFile(filepath.Join(dir, filename))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return string(data)
+		}
+
+		expectedBase := `from datetime import timezone
+
+from sqlalchemy import DateTime, TypeDecorator
+from sqlalchemy.ext.declarative import declarative_base
+from sqlalchemy_repr import RepresentableBase
+
+Base = declarative_base(cls=RepresentableBase)
+
+
+class TZDateTime(TypeDecorator):
+    impl = DateTime
+
+    def process_bind_param(self, value, dialect):
+        if value is not None:
+            if not value.tzinfo:
+                raise TypeError("tzinfo is required")
+            value = value.astimezone(timezone.utc).replace(tzinfo=None)
+        return value
+
+    def process_result_value(self, value, dialect):
+        if value is not None:
+            value = value.replace(tzinfo=timezone.utc)
+        return value
+`
+		expectedGroup := `from sqlalchemy import BigInteger, Column
+from sqlalchemy.orm import relationship
+
+from .base import Base
+
+
+class Group(Base):
+    __tablename__ = 'group'
+
+    id = Column(BigInteger, primary_key=True, autoincrement=True)
+
+    users = relationship('User', back_populates='group')
+`
+		actualBase := readFile("base.py")
+		if diff := cmp.Diff(expectedBase, actualBase); diff != "" {
+			log.Println(diff)
+		}
+		So(actualBase, ShouldEqual, expectedBase)
+
+		actualGroup := readFile("group.py")
+		if diff := cmp.Diff(expectedGroup, actualGroup); diff != "" {
+			log.Println(diff)
+		}
+		So(actualGroup, ShouldEqual, expectedGroup)
+
+		So(readFile("user.py"), ShouldContainSubstring, "from .base import Base, TZDateTime\n")
+	})
+}
@@ -1,9 +1,12 @@
 package sqlalchemy
 
 import (
+	"fmt"
+	"github.com/gertd/go-pluralize"
 	"github.com/iancoleman/strcase"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
+	"sort"
 	"strings"
 )
 
@@ -13,6 +16,7 @@ type SaClass struct {
 	Fields       []*SaField
 	PKFields     []*SaField
 	UniqueFields []*SaField
+	Relations    []*SaRelation
 }
 
 type SaField struct {
@@ -20,20 +24,107 @@ type SaField struct {
 	Name         string
 	OverrideName bool
 	Type         string
+	ForeignKey   string
 	Imports      []string
 }
 
+// SaRelation is a 'relationship()' attribute of the class.
+// ScalarBackRef is set on the referenced side of '1:1' relation.
+// ForeignKeys is set if more than one foreign key links the same pair of tables.
+type SaRelation struct {
+	Name          string
+	Target        string
+	BackPopulates string
+	ScalarBackRef bool
+	RemoteSide    string
+	ForeignKeys   string
+}
+
+// TypeExpr returns column type expression with size attributes. ex: 'String(40)'
+func (f *SaField) TypeExpr() string {
+	column := f.Column
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		if column.Size > 0 {
+			return fmt.Sprintf("%s(%d)", f.Type, column.Size)
+		}
+	case octopus.ColTypeDouble:
+		fallthrough
+	case octopus.ColTypeFloat:
+		fallthrough
+	case octopus.ColTypeDecimal:
+		var attrs []string
+		if column.Size > 0 {
+			attrs = append(attrs, fmt.Sprintf("precision=%d", column.Size))
+		}
+		if column.Scale > 0 {
+			attrs = append(attrs, fmt.Sprintf("scale=%d", column.Scale))
+		}
+		return fmt.Sprintf("%s(%s)", f.Type, strings.Join(attrs, ", "))
+	}
+	return f.Type
+}
+
+// FieldByColumnName returns field of the column name. returns nil if not found.
+func (c *SaClass) FieldByColumnName(columnName string) *SaField {
+	for _, field := range c.Fields {
+		if field.Column.Name == columnName {
+			return field
+		}
+	}
+	return nil
+}
+
+// hasAttribute returns true if the class has a field or relation of the name.
+func (c *SaClass) hasAttribute(name string) bool {
+	for _, field := range c.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	for _, relation := range c.Relations {
+		if relation.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeName returns unused attribute name of the class.
+func (c *SaClass) attributeName(name string) string {
+	if c.hasAttribute(name) {
+		return name + "_ref"
+	}
+	return name
+}
+
+// RelationshipArgs returns 'relationship()' arguments of the relation.
+func (r *SaRelation) RelationshipArgs() []string {
+	args := []string{util.Quote(r.Target, "'")}
+	if r.BackPopulates != "" {
+		args = append(args, "back_populates="+util.Quote(r.BackPopulates, "'"))
+	}
+	if r.ScalarBackRef {
+		args = append(args, "uselist=False")
+	}
+	if r.RemoteSide != "" {
+		args = append(args, fmt.Sprintf("remote_side=[%s]", r.RemoteSide))
+	}
+	if r.ForeignKeys != "" {
+		args = append(args, fmt.Sprintf("foreign_keys='[%s]'", r.ForeignKeys))
+	}
+	return args
+}
+
 func NewSaClass(
 	table *octopus.Table,
 	option *Option,
 ) *SaClass {
 	className := table.ClassName
 	if className == "" {
-		tableName := table.Name
-		for _, prefix := range option.RemovePrefixes {
-			tableName = strings.TrimPrefix(tableName, prefix)
-		}
-		className = strcase.ToCamel(tableName)
+		className = strcase.ToCamel(trimmedTableName(table, option))
 
 		if prefix := option.PrefixMapper.GetPrefix(table.Group); prefix != "" {
 			className = prefix + className
@@ -144,3 +235,114 @@ func NewSaField(column *octopus.Column) *SaField {
 		Imports:      importSet.Slice(),
 	}
 }
+
+// trimmedTableName returns table name without prefixes to remove.
+func trimmedTableName(table *octopus.Table, option *Option) string {
+	tableName := table.Name
+	for _, prefix := range option.RemovePrefixes {
+		tableName = strings.TrimPrefix(tableName, prefix)
+	}
+	return tableName
+}
+
+func classByTableName(classes []*SaClass, tableName string) *SaClass {
+	for _, class := range classes {
+		if class.table.Name == tableName {
+			return class
+		}
+	}
+	return nil
+}
+
+// populateRelations sets foreign keys and relationships between classes.
+// '1:n' reference is reversed, so that every relation starts from the foreign key field.
+func populateRelations(classes []*SaClass, option *Option) {
+	client := pluralize.NewClient()
+
+	// relations of each foreign key, grouped by pair of table names
+	pairRelations := make(map[string][][]*SaRelation)
+	addRelations := func(source, target *SaClass, foreignKey string, relations ...*SaRelation) {
+		names := []string{source.table.Name, target.table.Name}
+		sort.Strings(names)
+		pair := strings.Join(names, ".")
+		for _, relation := range relations {
+			relation.ForeignKeys = foreignKey
+		}
+		pairRelations[pair] = append(pairRelations[pair], relations)
+	}
+
+	for _, class := range classes {
+		for _, field := range class.Fields {
+			ref := field.Column.Ref
+			if ref == nil {
+				continue
+			}
+			target := classByTableName(classes, ref.Table)
+			if target == nil {
+				continue
+			}
+			targetField := target.FieldByColumnName(ref.Column)
+			if targetField == nil {
+				continue
+			}
+
+			// source has foreign key field referencing target
+			source, sourceField := class, field
+			oneToOne := ref.Relationship == octopus.RefOneToOne
+			if ref.Relationship == octopus.RefOneToMany {
+				source, sourceField, target, targetField = target, targetField, class, field
+			}
+			sourceField.ForeignKey = target.table.Name + "." + targetField.Column.Name
+
+			name := strings.TrimSuffix(sourceField.Name, "_id")
+			if name == sourceField.Name {
+				name = strcase.ToSnake(trimmedTableName(target.table, option))
+			}
+			name = source.attributeName(name)
+
+			foreignKey := source.Name + "." + sourceField.Name
+
+			// self reference has no reverse side
+			if source == target {
+				relation := &SaRelation{
+					Name:       name,
+					Target:     target.Name,
+					RemoteSide: targetField.Name,
+				}
+				source.Relations = append(source.Relations, relation)
+				addRelations(source, target, foreignKey, relation)
+				continue
+			}
+
+			backName := strcase.ToSnake(trimmedTableName(source.table, option))
+			if !oneToOne {
+				backName = client.Plural(backName)
+			}
+			backName = target.attributeName(backName)
+
+			relation := &SaRelation{
+				Name:          name,
+				Target:        target.Name,
+				BackPopulates: backName,
+			}
+			backRelation := &SaRelation{
+				Name:          backName,
+				Target:        source.Name,
+				BackPopulates: name,
+				ScalarBackRef: oneToOne,
+			}
+			source.Relations = append(source.Relations, relation)
+			target.Relations = append(target.Relations, backRelation)
+			addRelations(source, target, foreignKey, relation, backRelation)
+		}
+	}
+
+	// foreign_keys is required only if join condition is ambiguous
+	for _, fkRelations := range pairRelations {
+		if len(fkRelations) == 1 {
+			for _, relation := range fkRelations[0] {
+				relation.ForeignKeys = ""
+			}
+		}
+	}
+}
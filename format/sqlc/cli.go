@@ -0,0 +1,82 @@
+package sqlc
+
+import (
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/urfave/cli/v2"
+	"strings"
+)
+
+const (
+	FlagGroups           = "groups"
+	FlagInput            = "input"
+	FlagOutput           = "output"
+	FlagPackage          = "package"
+	FlagPrefix           = "prefix"
+	FlagRemovePrefix     = "removePrefix"
+	FlagUniqueNameSuffix = "uniqueNameSuffix"
+)
+
+func Action(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	gen := NewGenerator(schema, &Option{
+		PrefixMapper:     common.NewPrefixMapper(c.String(FlagPrefix)),
+		TableFilter:      octopus.GetTableFilterFn(c.String(FlagGroups)),
+		Package:          c.String(FlagPackage),
+		RemovePrefixes:   strings.Split(c.String(FlagRemovePrefix), ","),
+		UniqueNameSuffix: c.String(FlagUniqueNameSuffix),
+	})
+	return gen.Generate(c.String(FlagOutput))
+}
+
+var CliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "generate sqlc files to `DIR`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPackage,
+		Aliases: []string{"k"},
+		Usage:   "set go package name of sqlc output",
+		Value:   "db",
+		EnvVars: []string{"OCTOPUS_PACKAGE"},
+	},
+	&cli.StringFlag{
+		Name:    FlagPrefix,
+		Aliases: []string{"p"},
+		Usage:   "set query model name prefix",
+		EnvVars: []string{"OCTOPUS_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagRemovePrefix,
+		Aliases: []string{"r"},
+		Usage:   "set prefixes to remove from query model name. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_REMOVE_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    FlagUniqueNameSuffix,
+		Aliases: []string{"u"},
+		Usage:   "set unique constraint name suffix",
+		EnvVars: []string{"OCTOPUS_UNIQUE_NAME_SUFFIX"},
+	},
+}
@@ -0,0 +1,150 @@
+package sqlc
+
+import (
+	"bytes"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/mysql"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	SchemaFilename  = "schema.sql"
+	QueriesFilename = "queries.sql"
+	ConfigFilename  = "sqlc.yaml"
+
+	// QueriesTemplate is the template to generate annotated queries.
+	// Template is executed with SqlcTable slice.
+	QueriesTemplate = `{{"" -}}
+{{- range $i, $table := .}}
+{{- range $j, $query := $table.Queries}}
+{{- if or $i $j}}{{"\n"}}{{end -}}
+-- name: {{$query.Name}} {{$query.Command}}
+{{$query.SQL}}
+{{end}}
+{{- end}}`
+
+	// ConfigTemplate is the template to generate sqlc configuration.
+	ConfigTemplate = `version: "2"
+sql:
+  - engine: "mysql"
+    schema: "{{.Schema}}"
+    queries: "{{.Queries}}"
+    gen:
+      go:
+        package: "{{.Package}}"
+        out: "{{.Package}}"
+`
+)
+
+type Option struct {
+	PrefixMapper     *common.PrefixMapper
+	TableFilter      octopus.TableFilterFn
+	Package          string
+	RemovePrefixes   []string
+	UniqueNameSuffix string
+}
+
+type Generator struct {
+	schema *octopus.Schema
+	option *Option
+	tables []*SqlcTable
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	gen := &Generator{
+		schema: schema,
+		option: option,
+	}
+
+	for _, table := range schema.FilteredTables(option.TableFilter) {
+		gen.tables = append(gen.tables, NewSqlcTable(table, gen.ModelName(table)))
+	}
+	return gen
+}
+
+// ModelName returns model name used in query names.
+func (g *Generator) ModelName(table *octopus.Table) string {
+	name := table.ClassName
+	if name == "" {
+		tableName := table.Name
+		for _, prefix := range g.option.RemovePrefixes {
+			tableName = strings.TrimPrefix(tableName, prefix)
+		}
+		name = strcase.ToCamel(tableName)
+
+		if prefixMapper := g.option.PrefixMapper; prefixMapper != nil {
+			if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+				name = prefix + name
+			}
+		}
+	}
+	return name
+}
+
+// Generate writes schema, queries and sqlc configuration files to outputPath directory.
+func (g *Generator) Generate(outputPath string) error {
+	if _, err := util.Mkdir(outputPath); err != nil {
+		return err
+	}
+
+	files := []struct {
+		filename string
+		fn       func(wr io.Writer) error
+	}{
+		{SchemaFilename, g.GenerateSchema},
+		{QueriesFilename, g.GenerateQueries},
+		{ConfigFilename, g.GenerateConfig},
+	}
+	for _, file := range files {
+		buf := new(bytes.Buffer)
+		if err := file.fn(buf); err != nil {
+			return err
+		}
+		if err := util.WriteStringToFile(filepath.Join(outputPath, file.filename), buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateSchema writes MySQL DDL of filtered tables.
+func (g *Generator) GenerateSchema(wr io.Writer) error {
+	exporter := mysql.NewExporter(g.schema, &mysql.ExportOption{
+		TableFilter:      g.option.TableFilter,
+		UniqueNameSuffix: g.option.UniqueNameSuffix,
+	})
+	return exporter.Export(wr)
+}
+
+// GenerateQueries writes annotated queries of filtered tables.
+func (g *Generator) GenerateQueries(wr io.Writer) error {
+	tmpl, err := util.NewTemplate("sqlcQueries", QueriesTemplate, template.FuncMap{})
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(wr, g.tables)
+}
+
+// GenerateConfig writes sqlc.yaml configuration.
+func (g *Generator) GenerateConfig(wr io.Writer) error {
+	pkg := g.option.Package
+	if pkg == "" {
+		pkg = "db"
+	}
+
+	tmpl, err := util.NewTemplate("sqlcConfig", ConfigTemplate, template.FuncMap{})
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(wr, map[string]string{
+		"Schema":  SchemaFilename,
+		"Queries": QueriesFilename,
+		"Package": pkg,
+	})
+}
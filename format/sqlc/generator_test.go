@@ -0,0 +1,197 @@
+package sqlc
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:  "user",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+					NotNull:         true,
+				},
+				{
+					Name:      "email",
+					Type:      octopus.ColTypeVarchar,
+					Size:      100,
+					UniqueKey: true,
+					NotNull:   true,
+				},
+				{
+					Name: "group_id",
+					Type: octopus.ColTypeInt64,
+				},
+			},
+			Indices: []*octopus.Index{
+				{Name: "idx_group_id", Columns: []string{"group_id"}},
+				{Name: "idx_email", Columns: []string{"email"}},
+			},
+		},
+		{
+			Name:  "user_role",
+			Group: "common",
+			Columns: []*octopus.Column{
+				{
+					Name:       "user_id",
+					Type:       octopus.ColTypeInt64,
+					PrimaryKey: true,
+					NotNull:    true,
+				},
+				{
+					Name:       "role",
+					Type:       octopus.ColTypeVarchar,
+					Size:       20,
+					PrimaryKey: true,
+					NotNull:    true,
+				},
+			},
+		},
+		{
+			Name:  "log",
+			Group: "audit",
+			Columns: []*octopus.Column{
+				{
+					Name: "message",
+					Type: octopus.ColTypeText16,
+				},
+			},
+		},
+	},
+}
+
+func TestGenerator_GenerateQueries(t *testing.T) {
+	Convey("GenerateQueries", t, func() {
+		expected := "-- name: GetCmUserByID :one\n" +
+			"SELECT * FROM `user`\n" +
+			"WHERE `id` = ?\n" +
+			"LIMIT 1;\n" +
+			"\n" +
+			"-- name: GetCmUserByEmail :one\n" +
+			"SELECT * FROM `user`\n" +
+			"WHERE `email` = ?\n" +
+			"LIMIT 1;\n" +
+			"\n" +
+			"-- name: ListCmUsersByGroupID :many\n" +
+			"SELECT * FROM `user`\n" +
+			"WHERE `group_id` = ?;\n" +
+			"\n" +
+			"-- name: ListCmUsers :many\n" +
+			"SELECT * FROM `user`\n" +
+			"ORDER BY `id`\n" +
+			"LIMIT ? OFFSET ?;\n" +
+			"\n" +
+			"-- name: CountCmUsers :one\n" +
+			"SELECT count(*) FROM `user`;\n" +
+			"\n" +
+			"-- name: CreateCmUser :execresult\n" +
+			"INSERT INTO `user` (`email`, `group_id`)\n" +
+			"VALUES (?, ?);\n" +
+			"\n" +
+			"-- name: UpdateCmUser :exec\n" +
+			"UPDATE `user`\n" +
+			"SET `email` = ?, `group_id` = ?\n" +
+			"WHERE `id` = ?;\n" +
+			"\n" +
+			"-- name: DeleteCmUser :exec\n" +
+			"DELETE FROM `user`\n" +
+			"WHERE `id` = ?;\n" +
+			"\n" +
+			"-- name: GetCmRoleByUserIDAndRole :one\n" +
+			"SELECT * FROM `user_role`\n" +
+			"WHERE `user_id` = ? AND `role` = ?\n" +
+			"LIMIT 1;\n" +
+			"\n" +
+			"-- name: ListCmRoles :many\n" +
+			"SELECT * FROM `user_role`\n" +
+			"ORDER BY `user_id`, `role`\n" +
+			"LIMIT ? OFFSET ?;\n" +
+			"\n" +
+			"-- name: CountCmRoles :one\n" +
+			"SELECT count(*) FROM `user_role`;\n" +
+			"\n" +
+			"-- name: CreateCmRole :execresult\n" +
+			"INSERT INTO `user_role` (`user_id`, `role`)\n" +
+			"VALUES (?, ?);\n" +
+			"\n" +
+			"-- name: DeleteCmRole :exec\n" +
+			"DELETE FROM `user_role`\n" +
+			"WHERE `user_id` = ? AND `role` = ?;\n"
+
+		gen := NewGenerator(testSchema, &Option{
+			PrefixMapper:   common.NewPrefixMapper("common:Cm"),
+			TableFilter:    octopus.GetTableFilterFn("common"),
+			RemovePrefixes: []string{"user_"},
+		})
+
+		buf := new(bytes.Buffer)
+		if err := gen.GenerateQueries(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	Convey("Generate", t, func() {
+		outputDir, err := ioutil.TempDir("", "sqlc")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(outputDir)
+
+		gen := NewGenerator(testSchema, &Option{
+			PrefixMapper: common.NewPrefixMapper(""),
+			TableFilter:  octopus.GetTableFilterFn("audit"),
+			Package:      "store",
+		})
+		So(gen.Generate(outputDir), ShouldBeNil)
+
+		schema, err := ioutil.ReadFile(filepath.Join(outputDir, SchemaFilename))
+		So(err, ShouldBeNil)
+		So(string(schema), ShouldEqual, "CREATE TABLE IF NOT EXISTS log (\n  message text\n);\n")
+
+		queries, err := ioutil.ReadFile(filepath.Join(outputDir, QueriesFilename))
+		So(err, ShouldBeNil)
+		So(string(queries), ShouldEqual, "-- name: ListLogs :many\n"+
+			"SELECT * FROM `log`\n"+
+			"LIMIT ? OFFSET ?;\n"+
+			"\n"+
+			"-- name: CountLogs :one\n"+
+			"SELECT count(*) FROM `log`;\n"+
+			"\n"+
+			"-- name: CreateLog :execresult\n"+
+			"INSERT INTO `log` (`message`)\n"+
+			"VALUES (?);\n")
+
+		config, err := ioutil.ReadFile(filepath.Join(outputDir, ConfigFilename))
+		So(err, ShouldBeNil)
+		So(string(config), ShouldEqual, `version: "2"
+sql:
+  - engine: "mysql"
+    schema: "schema.sql"
+    queries: "queries.sql"
+    gen:
+      go:
+        package: "store"
+        out: "store"
+`)
+	})
+}
@@ -0,0 +1,178 @@
+package sqlc
+
+import (
+	"fmt"
+	"github.com/gertd/go-pluralize"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"regexp"
+	"strings"
+)
+
+// SqlcTable is the table to generate queries.
+type SqlcTable struct {
+	table      *octopus.Table
+	Name       string
+	PluralName string
+	Queries    []*SqlcQuery
+}
+
+// SqlcQuery is an annotated query. Command is one of ':one', ':many', ':exec', ':execresult'.
+type SqlcQuery struct {
+	Name    string
+	Command string
+	SQL     string
+}
+
+var pluralizeClient = pluralize.NewClient()
+
+// NewSqlcTable returns SqlcTable with queries of the table.
+// Queries are generated for CRUD, primary key, unique key and indices. Lookups with duplicated columns are skipped.
+func NewSqlcTable(table *octopus.Table, name string) *SqlcTable {
+	t := &SqlcTable{
+		table:      table,
+		Name:       name,
+		PluralName: pluralizeClient.Plural(name),
+	}
+
+	var pkColumns []*octopus.Column
+	var uniqueColumns []*octopus.Column
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			pkColumns = append(pkColumns, column)
+		}
+		if column.UniqueKey {
+			uniqueColumns = append(uniqueColumns, column)
+		}
+	}
+
+	columnSetKeys := util.NewStringSet()
+	addLookup := func(columns []*octopus.Column, unique bool) {
+		if len(columns) == 0 {
+			return
+		}
+		var columnNames []string
+		for _, column := range columns {
+			columnNames = append(columnNames, column.Name)
+		}
+		key := strings.Join(columnNames, ",")
+		if columnSetKeys.Contains(key) {
+			return
+		}
+		columnSetKeys.Add(key)
+
+		if unique {
+			t.addQuery("Get"+t.Name+"By"+byName(columns), ":one",
+				fmt.Sprintf("SELECT * FROM %s\nWHERE %s\nLIMIT 1;", quote(table.Name), whereClause(columns)))
+		} else {
+			t.addQuery("List"+t.PluralName+"By"+byName(columns), ":many",
+				fmt.Sprintf("SELECT * FROM %s\nWHERE %s;", quote(table.Name), whereClause(columns)))
+		}
+	}
+
+	addLookup(pkColumns, true)
+	// unique columns of a table are a single unique constraint
+	addLookup(uniqueColumns, true)
+	for _, index := range table.Indices {
+		var columns []*octopus.Column
+		for _, columnName := range index.Columns {
+			if column := table.ColumnByName(columnName); column != nil {
+				columns = append(columns, column)
+			}
+		}
+		if len(columns) == len(index.Columns) {
+			addLookup(columns, false)
+		}
+	}
+
+	// list
+	var orderBy string
+	if len(pkColumns) > 0 {
+		orderBy = "\nORDER BY " + joinColumnNames(pkColumns, ", ")
+	}
+	t.addQuery("List"+t.PluralName, ":many",
+		fmt.Sprintf("SELECT * FROM %s%s\nLIMIT ? OFFSET ?;", quote(table.Name), orderBy))
+	t.addQuery("Count"+t.PluralName, ":one",
+		fmt.Sprintf("SELECT count(*) FROM %s;", quote(table.Name)))
+
+	// create
+	var insertColumns []*octopus.Column
+	var valueColumns []*octopus.Column
+	for _, column := range table.Columns {
+		if !column.AutoIncremental {
+			insertColumns = append(insertColumns, column)
+		}
+		if !column.PrimaryKey {
+			valueColumns = append(valueColumns, column)
+		}
+	}
+	if len(insertColumns) > 0 {
+		t.addQuery("Create"+t.Name, ":execresult",
+			fmt.Sprintf("INSERT INTO %s (%s)\nVALUES (%s);",
+				quote(table.Name),
+				joinColumnNames(insertColumns, ", "),
+				strings.TrimSuffix(strings.Repeat("?, ", len(insertColumns)), ", ")))
+	}
+
+	// update, delete
+	if len(pkColumns) > 0 {
+		if len(valueColumns) > 0 {
+			var assignments []string
+			for _, column := range valueColumns {
+				assignments = append(assignments, quote(column.Name)+" = ?")
+			}
+			t.addQuery("Update"+t.Name, ":exec",
+				fmt.Sprintf("UPDATE %s\nSET %s\nWHERE %s;",
+					quote(table.Name), strings.Join(assignments, ", "), whereClause(pkColumns)))
+		}
+		t.addQuery("Delete"+t.Name, ":exec",
+			fmt.Sprintf("DELETE FROM %s\nWHERE %s;", quote(table.Name), whereClause(pkColumns)))
+	}
+
+	return t
+}
+
+func (t *SqlcTable) addQuery(name string, command string, sql string) {
+	t.Queries = append(t.Queries, &SqlcQuery{
+		Name:    name,
+		Command: command,
+		SQL:     sql,
+	})
+}
+
+// byName returns query name suffix of columns. ex: 'UserIDAndName'
+func byName(columns []*octopus.Column) string {
+	var names []string
+	for _, column := range columns {
+		names = append(names, fieldName(column.Name))
+	}
+	return strings.Join(names, "And")
+}
+
+var idSuffixRegexp = regexp.MustCompile(`Id$`)
+
+// fieldName returns upper camel case name of the column. 'Id' suffix is replaced to 'ID'.
+func fieldName(columnName string) string {
+	name, _ := util.ToUpperCamel(columnName)
+	return idSuffixRegexp.ReplaceAllString(name, "ID")
+}
+
+func whereClause(columns []*octopus.Column) string {
+	var conditions []string
+	for _, column := range columns {
+		conditions = append(conditions, quote(column.Name)+" = ?")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func joinColumnNames(columns []*octopus.Column, sep string) string {
+	var names []string
+	for _, column := range columns {
+		names = append(names, quote(column.Name))
+	}
+	return strings.Join(names, sep)
+}
+
+func quote(name string) string {
+	return "`" + name + "`"
+}
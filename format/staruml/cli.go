@@ -1,11 +1,14 @@
 package staruml
 
 import (
+	"bytes"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
 	"github.com/urfave/cli/v2"
 )
 
 const (
+	FlagGroups = "groups"
 	FlagInput  = "input"
 	FlagOutput = "output"
 )
@@ -41,3 +44,44 @@ var ImportCliFlags = []cli.Flag{
 		Required: true,
 	},
 }
+
+func ExportAction(c *cli.Context) error {
+	schema, err := octopus.LoadSchema(c.String(FlagInput))
+	if err != nil {
+		return err
+	}
+
+	exporter := NewExporter(schema, &ExportOption{
+		TableFilter: octopus.GetTableFilterFn(c.String(FlagGroups)),
+	})
+	buf := new(bytes.Buffer)
+	if err = exporter.Export(buf); err != nil {
+		return err
+	}
+
+	// write to file
+	return util.WriteBytesToFile(c.String(FlagOutput), buf.Bytes())
+}
+
+var ExportCliFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     FlagInput,
+		Aliases:  []string{"i"},
+		Usage:    "read octopus schema from `FILE`",
+		EnvVars:  []string{"OCTOPUS_INPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     FlagOutput,
+		Aliases:  []string{"o"},
+		Usage:    "export starUML to `FILE`",
+		EnvVars:  []string{"OCTOPUS_OUTPUT"},
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:    FlagGroups,
+		Aliases: []string{"g"},
+		Usage:   "filter table groups to generate. set multiple values with comma separated.",
+		EnvVars: []string{"OCTOPUS_GROUPS"},
+	},
+}
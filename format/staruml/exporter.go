@@ -0,0 +1,358 @@
+package staruml
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"io"
+	"math"
+	"strings"
+)
+
+// diagram layout
+const (
+	font           = "Arial;13;0"
+	boldFont       = "Arial;13;1"
+	charWidth      = 8
+	columnHeight   = 20
+	entitiesPerRow = 4
+	entityMargin   = 60
+	labelHeight    = 25
+	minEntityWidth = 100
+)
+
+type ExportOption struct {
+	TableFilter octopus.TableFilterFn
+}
+
+type Exporter struct {
+	schema *octopus.Schema
+	option *ExportOption
+}
+
+func NewExporter(schema *octopus.Schema, option *ExportOption) *Exporter {
+	return &Exporter{schema: schema, option: option}
+}
+
+func (c *Exporter) Export(wr io.Writer) error {
+	data, err := json.MarshalIndent(c.toProject(), "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = wr.Write(data)
+	return err
+}
+
+// newID returns ID generated from names, so that IDs are not changed between exports.
+func newID(names ...string) string {
+	hash := sha1.Sum([]byte(strings.Join(names, "\x00")))
+	return base64.StdEncoding.EncodeToString(hash[:15])
+}
+
+func ref(id string) *Ref {
+	return &Ref{Ref: id}
+}
+
+func (c *Exporter) toProject() *Model {
+	tables := c.schema.FilteredTables(c.option.TableFilter)
+	tableByName := make(map[string]*octopus.Table)
+	for _, table := range tables {
+		tableByName[table.Name] = table
+	}
+
+	projectName := c.schema.Name
+	if projectName == "" {
+		projectName = "Untitled"
+	}
+	project := &Model{ElemType: "Project", ID: newID("project"), Name: projectName}
+	dataModel := &Model{
+		ElemType: "ERDDataModel",
+		ID:       newID("dataModel"),
+		Parent:   ref(project.ID),
+		Name:     "Data Model",
+	}
+	diagram := &Diagram{
+		ElemType:       "ERDDiagram",
+		ID:             newID("diagram"),
+		Parent:         ref(dataModel.ID),
+		Name:           "ERDDiagram",
+		DefaultDiagram: true,
+	}
+	project.OwnedElements = []interface{}{dataModel}
+	dataModel.OwnedElements = []interface{}{diagram}
+
+	// entities
+	packageByGroup := make(map[string]*Model)
+	var entities []*Entity
+	for _, table := range tables {
+		parent := dataModel
+		if table.Group != "" {
+			if parent = packageByGroup[table.Group]; parent == nil {
+				parent = &Model{
+					ElemType: "UMLPackage",
+					ID:       newID("package", table.Group),
+					Parent:   ref(dataModel.ID),
+					Name:     table.Group,
+				}
+				packageByGroup[table.Group] = parent
+				dataModel.OwnedElements = append(dataModel.OwnedElements, parent)
+			}
+		}
+		entity := toEntity(table, parent.ID, tableByName)
+		parent.OwnedElements = append(parent.OwnedElements, entity)
+		entities = append(entities, entity)
+	}
+
+	diagram.OwnedViews = newDiagramViews(diagram.ID, tables, entities)
+	return project
+}
+
+func toEntity(table *octopus.Table, parentID string, tableByName map[string]*octopus.Table) *Entity {
+	entity := &Entity{
+		ElemType:      "ERDEntity",
+		ID:            newID("entity", table.Name),
+		Parent:        ref(parentID),
+		Name:          table.Name,
+		Documentation: table.Description,
+	}
+
+	for _, column := range table.Columns {
+		erdColumn := &Column{
+			ElemType:      "ERDColumn",
+			ID:            newID("column", table.Name, column.Name),
+			Parent:        ref(entity.ID),
+			Name:          column.Name,
+			Documentation: column.Description,
+			Type:          strings.ToUpper(column.Type),
+			Length:        getLength(column),
+			PrimaryKey:    column.PrimaryKey,
+			Nullable:      !column.NotNull,
+			Unique:        column.UniqueKey,
+		}
+		if column.AutoIncremental {
+			erdColumn.Tags = append(erdColumn.Tags, newTag(erdColumn, TagAutoIncrement, "boolean", ""))
+		}
+		if column.DefaultValue != "" {
+			erdColumn.Tags = append(erdColumn.Tags, newTag(erdColumn, TagDefault, "string", column.DefaultValue))
+		}
+		if column.OnUpdate != "" {
+			erdColumn.Tags = append(erdColumn.Tags, newTag(erdColumn, TagOnUpdate, "string", column.OnUpdate))
+		}
+
+		// references to filtered tables are removed.
+		if colRef := column.Ref; colRef != nil {
+			if target := tableByName[colRef.Table]; target != nil && target.ColumnByName(colRef.Column) != nil {
+				erdColumn.ForeignKey = true
+				erdColumn.ReferenceTo = ref(newID("column", colRef.Table, colRef.Column))
+				entity.OwnedElements = append(entity.OwnedElements, newRelationship(entity.ID, table, column))
+			}
+		}
+		entity.Columns = append(entity.Columns, erdColumn)
+	}
+	return entity
+}
+
+func newTag(column *Column, name string, kind string, value string) *Tag {
+	return &Tag{
+		ElemType: "Tag",
+		ID:       newID("tag", column.ID, name),
+		Parent:   ref(column.ID),
+		Name:     name,
+		Kind:     kind,
+		Value:    value,
+		Checked:  kind == "boolean",
+	}
+}
+
+// getLength returns length of the column. enum and set values are returned as length.
+func getLength(column *octopus.Column) string {
+	if column.Type == octopus.ColTypeEnum || column.Type == octopus.ColTypeSet {
+		var values []string
+		for _, value := range column.Values {
+			values = append(values, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+		}
+		return strings.Join(values, ",")
+	}
+	if column.Size == 0 {
+		return ""
+	}
+	if column.Scale == 0 {
+		return fmt.Sprintf("%d", column.Size)
+	}
+	return fmt.Sprintf("%d,%d", column.Size, column.Scale)
+}
+
+// newRelationship returns relationship from the entity of column to the referenced entity.
+func newRelationship(entityID string, table *octopus.Table, column *octopus.Column) *Relationship {
+	var cardinality1, cardinality2 string
+	switch column.Ref.Relationship {
+	case octopus.RefOneToOne:
+		cardinality1, cardinality2 = "1", "1"
+	case octopus.RefOneToMany:
+		cardinality1, cardinality2 = "1", "0..*"
+	default:
+		cardinality1, cardinality2 = "0..*", "1"
+	}
+
+	id := newID("relationship", table.Name, column.Name)
+	return &Relationship{
+		ElemType: "ERDRelationship",
+		ID:       id,
+		Parent:   ref(entityID),
+		End1: &RelationshipEnd{
+			ElemType:    "ERDRelationshipEnd",
+			ID:          newID(id, "end1"),
+			Parent:      ref(id),
+			Reference:   ref(entityID),
+			Cardinality: cardinality1,
+		},
+		End2: &RelationshipEnd{
+			ElemType:    "ERDRelationshipEnd",
+			ID:          newID(id, "end2"),
+			Parent:      ref(id),
+			Reference:   ref(newID("entity", column.Ref.Table)),
+			Cardinality: cardinality2,
+		},
+	}
+}
+
+// newDiagramViews returns entity views laid out in a grid and relationship views.
+func newDiagramViews(diagramID string, tables []*octopus.Table, entities []*Entity) []interface{} {
+	var views []interface{}
+	viewByEntityID := make(map[string]*View)
+
+	left, top, rowHeight := entityMargin, entityMargin, 0
+	for i, table := range tables {
+		if i > 0 && i%entitiesPerRow == 0 {
+			left = entityMargin
+			top += rowHeight + entityMargin
+			rowHeight = 0
+		}
+		view := newEntityView(diagramID, table, entities[i], left, top)
+		views = append(views, view)
+		viewByEntityID[entities[i].ID] = view
+
+		left += view.Width + entityMargin
+		if view.Height > rowHeight {
+			rowHeight = view.Height
+		}
+	}
+
+	for _, entity := range entities {
+		for _, relationship := range entity.OwnedElements {
+			tail := viewByEntityID[relationship.End1.Reference.Ref]
+			head := viewByEntityID[relationship.End2.Reference.Ref]
+			views = append(views, newRelationshipView(diagramID, relationship, tail, head))
+		}
+	}
+	return views
+}
+
+func newEntityView(diagramID string, table *octopus.Table, entity *Entity, left, top int) *View {
+	width := len(table.Name)
+	for _, column := range entity.Columns {
+		// column is displayed as 'PK name: TYPE(length)'
+		if w := len(column.Name) + len(column.Type) + len(column.Length) + 8; w > width {
+			width = w
+		}
+	}
+	width = int(math.Max(float64(width*charWidth+20), minEntityWidth))
+	height := labelHeight + len(entity.Columns)*columnHeight + 10
+
+	id := newID("entityView", table.Name)
+	nameLabel := &View{
+		ElemType: "LabelView",
+		ID:       newID(id, "nameLabel"),
+		Parent:   ref(id),
+		Font:     boldFont,
+		Left:     left + 5,
+		Top:      top + 5,
+		Width:    width - 10,
+		Height:   13,
+		Text:     table.Name,
+	}
+	compartment := &View{
+		ElemType: "ERDColumnCompartmentView",
+		ID:       newID(id, "columnCompartment"),
+		Parent:   ref(id),
+		Model:    ref(entity.ID),
+		Font:     font,
+		Left:     left,
+		Top:      top + labelHeight,
+		Width:    width,
+		Height:   height - labelHeight,
+	}
+	for i, column := range entity.Columns {
+		compartment.SubViews = append(compartment.SubViews, &View{
+			ElemType: "ERDColumnView",
+			ID:       newID(id, "column", column.Name),
+			Parent:   ref(compartment.ID),
+			Model:    ref(column.ID),
+			Font:     font,
+			Left:     left + 5,
+			Top:      top + labelHeight + 5 + i*columnHeight,
+			Width:    width - 10,
+			Height:   columnHeight,
+		})
+	}
+
+	return &View{
+		ElemType:          "ERDEntityView",
+		ID:                id,
+		Parent:            ref(diagramID),
+		Model:             ref(entity.ID),
+		SubViews:          []interface{}{nameLabel, compartment},
+		Font:              font,
+		Left:              left,
+		Top:               top,
+		Width:             width,
+		Height:            height,
+		NameLabel:         ref(nameLabel.ID),
+		ColumnCompartment: ref(compartment.ID),
+	}
+}
+
+func newRelationshipView(diagramID string, relationship *Relationship, tail, head *View) *EdgeView {
+	id := newID("relationshipView", relationship.ID)
+	newLabel := func(name string, edgePosition int, distance int) *EdgeLabelView {
+		return &EdgeLabelView{
+			ElemType:     "EdgeLabelView",
+			ID:           newID(id, name),
+			Parent:       ref(id),
+			Model:        ref(relationship.ID),
+			Font:         font,
+			HostEdge:     ref(id),
+			EdgePosition: edgePosition,
+			Alpha:        math.Pi / 2,
+			Distance:     distance,
+		}
+	}
+	nameLabel := newLabel("nameLabel", 1, 15)
+	tailNameLabel := newLabel("tailNameLabel", 2, 15)
+	headNameLabel := newLabel("headNameLabel", 0, 15)
+
+	return &EdgeView{
+		ElemType:      "ERDRelationshipView",
+		ID:            id,
+		Parent:        ref(diagramID),
+		Model:         ref(relationship.ID),
+		SubViews:      []interface{}{nameLabel, tailNameLabel, headNameLabel},
+		Font:          font,
+		Head:          ref(head.ID),
+		Tail:          ref(tail.ID),
+		Points:        fmt.Sprintf("%d:%d;%d:%d", centerX(tail), centerY(tail), centerX(head), centerY(head)),
+		NameLabel:     ref(nameLabel.ID),
+		TailNameLabel: ref(tailNameLabel.ID),
+		HeadNameLabel: ref(headNameLabel.ID),
+	}
+}
+
+func centerX(view *View) int {
+	return view.Left + view.Width/2
+}
+
+func centerY(view *View) int {
+	return view.Top + view.Height/2
+}
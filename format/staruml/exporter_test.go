@@ -0,0 +1,155 @@
+package staruml
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+func newTestSchema() *octopus.Schema {
+	return &octopus.Schema{
+		Name: "sample",
+		Tables: []*octopus.Table{
+			{
+				Name:        "group",
+				Group:       "common",
+				Description: "Group table",
+				Columns: []*octopus.Column{
+					{
+						Name:            "id",
+						Type:            octopus.ColTypeInt64,
+						NotNull:         true,
+						PrimaryKey:      true,
+						AutoIncremental: true,
+					},
+					{
+						Name:         "name",
+						Type:         octopus.ColTypeVarchar,
+						Size:         40,
+						NotNull:      true,
+						UniqueKey:    true,
+						DefaultValue: "noname",
+						Description:  "group name",
+					},
+				},
+			},
+			{
+				Name: "user",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name: "group_id",
+						Type: octopus.ColTypeInt64,
+						Ref: &octopus.Reference{
+							Table:        "group",
+							Column:       "id",
+							Relationship: octopus.RefManyToOne,
+						},
+					},
+					{
+						Name:   "status",
+						Type:   octopus.ColTypeEnum,
+						Values: []string{"active", "it's dormant"},
+					},
+					{
+						Name:     "score",
+						Type:     octopus.ColTypeDecimal,
+						Size:     10,
+						Scale:    2,
+						NotNull:  true,
+						OnUpdate: "fn::now()",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	Convey("Export", t, func() {
+		Convey("round trip", func() {
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{}).Export(buf), ShouldBeNil)
+
+			importer := Importer{}
+			schema, err := importer.ImportJson(buf.Bytes())
+			So(err, ShouldBeNil)
+
+			expected := newTestSchema()
+			diff := cmp.Diff(expected, schema)
+			if diff != "" {
+				log.Println(diff)
+			}
+			So(schema, ShouldResemble, expected)
+		})
+
+		Convey("elements", func() {
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{}).Export(buf), ShouldBeNil)
+
+			// IDs are not changed between exports
+			buf2 := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{}).Export(buf2), ShouldBeNil)
+			So(buf2.String(), ShouldEqual, buf.String())
+
+			project := &Element{}
+			So(json.Unmarshal(buf.Bytes(), project), ShouldBeNil)
+			So(project.ElemType, ShouldEqual, "Project")
+			So(project.OwnedElements, ShouldHaveLength, 1)
+
+			dataModel := project.OwnedElements[0]
+			So(dataModel.ElemType, ShouldEqual, "ERDDataModel")
+			So(dataModel.Parent.Ref, ShouldEqual, project.ID)
+
+			var types []string
+			for _, elem := range dataModel.OwnedElements {
+				types = append(types, elem.ElemType+":"+elem.Name)
+			}
+			So(types, ShouldResemble, []string{"ERDDiagram:ERDDiagram", "UMLPackage:common", "ERDEntity:user"})
+
+			group := dataModel.OwnedElements[1].OwnedElements[0]
+			user := dataModel.OwnedElements[2]
+			So(user.Columns[1].ReferenceTo.Ref, ShouldEqual, group.Columns[0].ID)
+			So(user.Columns[2].Length, ShouldEqual, "'active','it''s dormant'")
+			So(user.Columns[3].Length, ShouldEqual, "10,2")
+
+			relationship := user.OwnedElements[0]
+			So(relationship.ElemType, ShouldEqual, "ERDRelationship")
+			So(relationship.End1.Reference.Ref, ShouldEqual, user.ID)
+			So(relationship.End1.Cardinality, ShouldEqual, "0..*")
+			So(relationship.End2.Reference.Ref, ShouldEqual, group.ID)
+			So(relationship.End2.Cardinality, ShouldEqual, "1")
+
+			// 2 entity views, 1 relationship view
+			var viewTypes []string
+			for _, view := range dataModel.OwnedElements[0].OwnedViews {
+				viewTypes = append(viewTypes, view.ElemType)
+			}
+			So(viewTypes, ShouldResemble, []string{"ERDEntityView", "ERDEntityView", "ERDRelationshipView"})
+		})
+
+		Convey("filtered tables", func() {
+			buf := new(bytes.Buffer)
+			So(NewExporter(newTestSchema(), &ExportOption{
+				TableFilter: func(table *octopus.Table) bool { return table.Group == "" },
+			}).Export(buf), ShouldBeNil)
+
+			importer := Importer{}
+			schema, err := importer.ImportJson(buf.Bytes())
+			So(err, ShouldBeNil)
+			So(schema.Tables, ShouldHaveLength, 1)
+			So(schema.Tables[0].Name, ShouldEqual, "user")
+			// references to filtered tables are removed
+			So(schema.Tables[0].Columns[1].Ref, ShouldBeNil)
+		})
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/util"
 	"io/ioutil"
+	"log"
+	"strconv"
 	"strings"
 )
 
@@ -13,8 +15,10 @@ type ImportOption struct {
 }
 
 type Importer struct {
-	root    *Element
-	mapById map[string]*Element
+	root          *Element
+	mapById       map[string]*Element
+	relationships []*Element
+	columnById    map[string]*octopus.Column
 }
 
 func (c *Importer) ImportFile(filename string) (*octopus.Schema, error) {
@@ -41,6 +45,7 @@ func (c *Importer) ImportJson(data []byte) (*octopus.Schema, error) {
 
 	// fill mapById
 	c.mapById = make(map[string]*Element)
+	c.relationships = nil
 	c.walkElement(c.root)
 
 	return c.toSchema()
@@ -49,60 +54,256 @@ func (c *Importer) ImportJson(data []byte) (*octopus.Schema, error) {
 func (c *Importer) toSchema() (*octopus.Schema, error) {
 	project := c.root
 
-	erdDataModels := c.findByType(project.OwnedElements, "ERDDataModel")
+	erdDataModels := c.findAllByType(project.OwnedElements, "ERDDataModel")
 	if len(erdDataModels) == 0 {
 		return nil, errors.New("ERDDataModel not found")
 	}
 
-	erdEntities := c.findByType(erdDataModels[0].OwnedElements, "ERDEntity")
+	c.columnById = make(map[string]*octopus.Column)
 	var tables []*octopus.Table
-	for _, erdEntity := range erdEntities {
-		// Create Columns
-		var columns []*octopus.Column
-		for _, erdColumn := range c.findByType(erdEntity.Columns, "ERDColumn") {
-			colType, colSize, colScale := util.ParseType(erdColumn.Type)
-			size := uint16(util.ToInt(erdColumn.Length, 0))
-			if size == 0 {
-				size = colSize
-			}
+	for _, erdDataModel := range erdDataModels {
+		// data model name is used as group if there are multiple data models.
+		group := ""
+		if len(erdDataModels) > 1 {
+			group = erdDataModel.Name
+		}
+		tables = append(tables, c.toTables(erdDataModel.OwnedElements, group)...)
+	}
+
+	for _, relationship := range c.relationships {
+		c.setRelationship(relationship)
+	}
+
+	schema := octopus.Schema{
+		Name:   project.Name,
+		Tables: tables,
+	}
+	return &schema, nil
+}
+
+// toTables converts entities to tables. entities in package are grouped by package name.
+func (c *Importer) toTables(elems []*Element, group string) []*octopus.Table {
+	var tables []*octopus.Table
+	for _, elem := range elems {
+		switch elem.ElemType {
+		case "ERDEntity":
+			tables = append(tables, c.toTable(elem, group))
+		case "UMLPackage":
+			tables = append(tables, c.toTables(elem.OwnedElements, elem.Name)...)
+		}
+	}
+	return tables
+}
 
-			column := &octopus.Column{
-				Name:            erdColumn.Name,
-				Type:            colType,
-				Size:            size,
-				Scale:           colScale,
-				NotNull:         !erdColumn.Nullable,
-				PrimaryKey:      erdColumn.PrimaryKey,
-				UniqueKey:       erdColumn.Unique,
-				AutoIncremental: false,
-				Description:     strings.TrimSpace(erdColumn.Documentation),
+func (c *Importer) toTable(erdEntity *Element, group string) *octopus.Table {
+	// Create Columns
+	var columns []*octopus.Column
+	for _, erdColumn := range c.findByType(erdEntity.Columns, "ERDColumn") {
+		colType, colSize, colScale := util.ParseType(erdColumn.Type)
+		column := &octopus.Column{
+			Name:            erdColumn.Name,
+			Type:            colType,
+			Size:            colSize,
+			Scale:           colScale,
+			NotNull:         !erdColumn.Nullable,
+			PrimaryKey:      erdColumn.PrimaryKey,
+			UniqueKey:       erdColumn.Unique,
+			AutoIncremental: false,
+			Description:     strings.TrimSpace(erdColumn.Documentation),
+		}
+		column.NormalizeType()
+		c.setLength(column, erdColumn.Length)
+
+		for _, tag := range erdColumn.Tags {
+			switch tag.Name {
+			case TagAutoIncrement:
+				column.AutoIncremental = tag.Checked || tag.Value == "true"
+			case TagDefault:
+				column.DefaultValue = tag.Value
+			case TagOnUpdate:
+				column.OnUpdate = tag.Value
 			}
+		}
 
-			if erdColumn.ReferenceTo != nil {
-				targetColumn := c.findById(erdColumn.ReferenceTo.Ref)
-				targetTable := c.findById(targetColumn.Parent.Ref)
-				column.Ref = &octopus.Reference{
-					Table:  targetTable.Name,
-					Column: targetColumn.Name,
-				}
+		if erdColumn.ReferenceTo != nil {
+			targetColumn := c.findById(erdColumn.ReferenceTo.Ref)
+			targetTable := c.findById(targetColumn.Parent.Ref)
+			column.Ref = &octopus.Reference{
+				Table:  targetTable.Name,
+				Column: targetColumn.Name,
 			}
+		}
+
+		columns = append(columns, column)
+		c.columnById[erdColumn.ID] = column
+	}
 
-			columns = append(columns, column)
+	// Create Table
+	return &octopus.Table{
+		Name:        erdEntity.Name,
+		Columns:     columns,
+		Description: strings.TrimSpace(erdEntity.Documentation),
+		Group:       group,
+	}
+}
+
+// setLength sets size and scale from length. length can be a number, 'size', 'size,scale' or enum values.
+func (c *Importer) setLength(column *octopus.Column, length interface{}) {
+	var s string
+	switch v := length.(type) {
+	case float64:
+		s = strconv.Itoa(int(v))
+	case string:
+		s = strings.TrimSpace(v)
+	}
+	if s == "" {
+		return
+	}
+
+	if column.Type == octopus.ColTypeEnum || column.Type == octopus.ColTypeSet {
+		column.Values = nil
+		for _, value := range strings.Split(s, ",") {
+			value = strings.Trim(strings.TrimSpace(value), "'")
+			column.Values = append(column.Values, strings.ReplaceAll(value, "''", "'"))
 		}
+		return
+	}
 
-		// Create Table
-		table := &octopus.Table{
-			Name:    erdEntity.Name,
-			Columns: columns,
+	sizes := strings.Split(s, ",")
+	if size := util.ToInt(strings.TrimSpace(sizes[0]), 0); size > 0 {
+		column.Size = uint16(size)
+	}
+	if len(sizes) > 1 {
+		column.Scale = uint16(util.ToInt(strings.TrimSpace(sizes[1]), 0))
+	}
+}
+
+// setRelationship sets relationship of the foreign key column between relationship ends.
+func (c *Importer) setRelationship(relationship *Element) {
+	if relationship.End1 == nil || relationship.End2 == nil ||
+		relationship.End1.Reference == nil || relationship.End2.Reference == nil {
+		return
+	}
+	end1, end2 := relationship.End1, relationship.End2
+
+	// foreign key column without 'referenceTo' is matched only if no column references the other end.
+	var column *octopus.Column
+	for _, find := range []func(entityId, targetEntityId string) *octopus.Column{c.findForeignKey, c.findForeignKeyByPK} {
+		if column = find(end1.Reference.Ref, end2.Reference.Ref); column != nil {
+			break
 		}
-		tables = append(tables, table)
+		// foreign key is defined in the opposite entity
+		if column = find(end2.Reference.Ref, end1.Reference.Ref); column != nil {
+			end1, end2 = end2, end1
+			break
+		}
+	}
+	if column == nil {
+		log.Printf("relationship '%s' is skipped: foreign key column not found.", c.relationshipName(relationship))
+		return
 	}
 
-	schema := octopus.Schema{
-		Version: project.Name,
-		Tables:  tables,
+	many := strings.Contains(end1.Cardinality, "*")
+	targetMany := strings.Contains(end2.Cardinality, "*")
+	switch {
+	case many && targetMany:
+		log.Printf("relationship '%s' is skipped: many-to-many relationship is not supported.", c.relationshipName(relationship))
+	case many:
+		column.Ref.Relationship = octopus.RefManyToOne
+	case targetMany:
+		column.Ref.Relationship = octopus.RefOneToMany
+	default:
+		column.Ref.Relationship = octopus.RefOneToOne
 	}
-	return &schema, nil
+}
+
+// findForeignKey returns column of the entity which references the target entity.
+func (c *Importer) findForeignKey(entityId, targetEntityId string) *octopus.Column {
+	entity := c.findById(entityId)
+	if entity == nil {
+		return nil
+	}
+	for _, erdColumn := range entity.Columns {
+		if erdColumn.ReferenceTo == nil {
+			continue
+		}
+		targetColumn := c.findById(erdColumn.ReferenceTo.Ref)
+		if targetColumn != nil && targetColumn.Parent != nil && targetColumn.Parent.Ref == targetEntityId {
+			return c.columnById[erdColumn.ID]
+		}
+	}
+	return nil
+}
+
+// findForeignKeyByPK returns foreign key column of the entity without 'referenceTo', matched to primary key of the target entity.
+// column named '{pk}' or '{target}_{pk}' is matched first, otherwise the only column of the same type as the primary key.
+// reference to the primary key is set to the returned column.
+func (c *Importer) findForeignKeyByPK(entityId, targetEntityId string) *octopus.Column {
+	entity := c.findById(entityId)
+	target := c.findById(targetEntityId)
+	if entity == nil || target == nil {
+		return nil
+	}
+
+	var pkColumns []*Element
+	for _, erdColumn := range target.Columns {
+		if erdColumn.PrimaryKey {
+			pkColumns = append(pkColumns, erdColumn)
+		}
+	}
+	if len(pkColumns) != 1 {
+		return nil
+	}
+	pk := pkColumns[0]
+	pkColumn := c.columnById[pk.ID]
+	if pkColumn == nil {
+		return nil
+	}
+
+	normalize := func(name string) string {
+		return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	}
+	setRef := func(column *octopus.Column) *octopus.Column {
+		column.Ref = &octopus.Reference{
+			Table:  target.Name,
+			Column: pk.Name,
+		}
+		return column
+	}
+
+	var candidates []*octopus.Column
+	for _, erdColumn := range entity.Columns {
+		column := c.columnById[erdColumn.ID]
+		if !erdColumn.ForeignKey || erdColumn.ReferenceTo != nil || column == nil || column.Ref != nil || column == pkColumn {
+			continue
+		}
+		name := normalize(erdColumn.Name)
+		if name == normalize(pk.Name) || name == normalize(target.Name+pk.Name) {
+			return setRef(column)
+		}
+		if column.Type == pkColumn.Type {
+			candidates = append(candidates, column)
+		}
+	}
+	if len(candidates) == 1 {
+		return setRef(candidates[0])
+	}
+	return nil
+}
+
+func (c *Importer) relationshipName(relationship *Element) string {
+	if relationship.Name != "" {
+		return relationship.Name
+	}
+	return c.elementName(relationship.End1.Reference.Ref) + "-" + c.elementName(relationship.End2.Reference.Ref)
+}
+
+// elementName returns name of the element. id is returned if element is not found.
+func (c *Importer) elementName(id string) string {
+	if elem := c.findById(id); elem != nil {
+		return elem.Name
+	}
+	return id
 }
 
 func (c *Importer) findByType(elems []*Element, typ string) []*Element {
@@ -117,8 +318,26 @@ func (c *Importer) findByType(elems []*Element, typ string) []*Element {
 	return result
 }
 
+// findAllByType finds elements recursively. children of found elements are not searched.
+func (c *Importer) findAllByType(elems []*Element, typ string) []*Element {
+	var result []*Element
+
+	for _, elem := range elems {
+		if elem.ElemType == typ {
+			result = append(result, elem)
+		} else {
+			result = append(result, c.findAllByType(elem.OwnedElements, typ)...)
+		}
+	}
+
+	return result
+}
+
 func (c *Importer) walkElement(elem *Element) {
 	c.mapById[elem.ID] = elem
+	if elem.ElemType == "ERDRelationship" {
+		c.relationships = append(c.relationships, elem)
+	}
 
 	c.walkElements(elem.OwnedElements)
 	c.walkElements(elem.Columns)
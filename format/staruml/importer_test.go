@@ -0,0 +1,374 @@
+package staruml
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+const testMdj = `{
+	"_type": "Project",
+	"_id": "P1",
+	"name": "shop",
+	"ownedElements": [
+		{
+			"_type": "ERDDataModel",
+			"_id": "DM1",
+			"_parent": {"$ref": "P1"},
+			"name": "order",
+			"ownedElements": [
+				{
+					"_type": "ERDDiagram",
+					"_id": "D1",
+					"_parent": {"$ref": "DM1"},
+					"name": "ERDDiagram1",
+					"ownedViews": []
+				},
+				{
+					"_type": "ERDEntity",
+					"_id": "E1",
+					"_parent": {"$ref": "DM1"},
+					"name": "orders",
+					"documentation": "orders table\n",
+					"ownedElements": [
+						{
+							"_type": "ERDRelationship",
+							"_id": "R1",
+							"_parent": {"$ref": "E1"},
+							"end1": {
+								"_type": "ERDRelationshipEnd",
+								"_id": "R1E1",
+								"_parent": {"$ref": "R1"},
+								"reference": {"$ref": "E2"},
+								"cardinality": "1"
+							},
+							"end2": {
+								"_type": "ERDRelationshipEnd",
+								"_id": "R1E2",
+								"_parent": {"$ref": "R1"},
+								"reference": {"$ref": "E1"},
+								"cardinality": "0..*"
+							}
+						}
+					],
+					"columns": [
+						{
+							"_type": "ERDColumn",
+							"_id": "C1",
+							"_parent": {"$ref": "E1"},
+							"name": "id",
+							"type": "INTEGER",
+							"primaryKey": true,
+							"tags": [
+								{
+									"_type": "Tag",
+									"_id": "T1",
+									"_parent": {"$ref": "C1"},
+									"name": "autoIncrement",
+									"kind": "boolean",
+									"checked": true
+								}
+							]
+						},
+						{
+							"_type": "ERDColumn",
+							"_id": "C2",
+							"_parent": {"$ref": "E1"},
+							"name": "user_id",
+							"type": "BIGINT",
+							"foreignKey": true,
+							"referenceTo": {"$ref": "C4"}
+						},
+						{
+							"_type": "ERDColumn",
+							"_id": "C3",
+							"_parent": {"$ref": "E1"},
+							"name": "amount",
+							"type": "DECIMAL",
+							"length": "10,2",
+							"nullable": true,
+							"tags": [
+								{
+									"_type": "Tag",
+									"_id": "T2",
+									"_parent": {"$ref": "C3"},
+									"name": "default",
+									"kind": "string",
+									"value": "0"
+								}
+							]
+						}
+					]
+				}
+			]
+		},
+		{
+			"_type": "ERDDataModel",
+			"_id": "DM2",
+			"_parent": {"$ref": "P1"},
+			"name": "user",
+			"ownedElements": [
+				{
+					"_type": "UMLPackage",
+					"_id": "PK1",
+					"_parent": {"$ref": "DM2"},
+					"name": "account",
+					"ownedElements": [
+						{
+							"_type": "ERDEntity",
+							"_id": "E2",
+							"_parent": {"$ref": "PK1"},
+							"name": "users",
+							"columns": [
+								{
+									"_type": "ERDColumn",
+									"_id": "C4",
+									"_parent": {"$ref": "E2"},
+									"name": "id",
+									"type": "BIGINT",
+									"primaryKey": true
+								},
+								{
+									"_type": "ERDColumn",
+									"_id": "C5",
+									"_parent": {"$ref": "E2"},
+									"name": "email",
+									"type": "VARCHAR",
+									"length": 100,
+									"unique": true,
+									"documentation": "login email"
+								},
+								{
+									"_type": "ERDColumn",
+									"_id": "C6",
+									"_parent": {"$ref": "E2"},
+									"name": "name",
+									"type": "VARCHAR(20)"
+								}
+							]
+						}
+					]
+				},
+				{
+					"_type": "ERDEntity",
+					"_id": "E3",
+					"_parent": {"$ref": "DM2"},
+					"name": "user_log",
+					"ownedElements": [
+						{
+							"_type": "ERDRelationship",
+							"_id": "R2",
+							"_parent": {"$ref": "E3"},
+							"end1": {
+								"_type": "ERDRelationshipEnd",
+								"_id": "R2E1",
+								"_parent": {"$ref": "R2"},
+								"reference": {"$ref": "E3"},
+								"cardinality": "0..*"
+							},
+							"end2": {
+								"_type": "ERDRelationshipEnd",
+								"_id": "R2E2",
+								"_parent": {"$ref": "R2"},
+								"reference": {"$ref": "E2"},
+								"cardinality": "1"
+							}
+						}
+					],
+					"columns": [
+						{
+							"_type": "ERDColumn",
+							"_id": "C7",
+							"_parent": {"$ref": "E3"},
+							"name": "message",
+							"type": "ENUM",
+							"length": "'login','it''s me'"
+						}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func TestImporter_ImportJson(t *testing.T) {
+	Convey("ImportJson", t, func() {
+		importer := Importer{}
+		schema, err := importer.ImportJson([]byte(testMdj))
+		So(err, ShouldBeNil)
+		So(schema.Name, ShouldEqual, "shop")
+
+		expected := []*octopus.Table{
+			{
+				Name:        "orders",
+				Group:       "order",
+				Description: "orders table",
+				Columns: []*octopus.Column{
+					{
+						Name:            "id",
+						Type:            octopus.ColTypeInt32,
+						NotNull:         true,
+						PrimaryKey:      true,
+						AutoIncremental: true,
+					},
+					{
+						Name:    "user_id",
+						Type:    octopus.ColTypeInt64,
+						NotNull: true,
+						Ref: &octopus.Reference{
+							Table:        "users",
+							Column:       "id",
+							Relationship: octopus.RefManyToOne,
+						},
+					},
+					{
+						Name:         "amount",
+						Type:         octopus.ColTypeDecimal,
+						Size:         10,
+						Scale:        2,
+						DefaultValue: "0",
+					},
+				},
+			},
+			{
+				Name:  "users",
+				Group: "account",
+				Columns: []*octopus.Column{
+					{
+						Name:       "id",
+						Type:       octopus.ColTypeInt64,
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:        "email",
+						Type:        octopus.ColTypeVarchar,
+						Size:        100,
+						NotNull:     true,
+						UniqueKey:   true,
+						Description: "login email",
+					},
+					{
+						Name:    "name",
+						Type:    octopus.ColTypeVarchar,
+						Size:    20,
+						NotNull: true,
+					},
+				},
+			},
+			{
+				Name:  "user_log",
+				Group: "user",
+				Columns: []*octopus.Column{
+					{
+						Name:    "message",
+						Type:    octopus.ColTypeEnum,
+						NotNull: true,
+						Values:  []string{"login", "it's me"},
+					},
+				},
+			},
+		}
+		diff := cmp.Diff(expected, schema.Tables)
+		if diff != "" {
+			log.Println(diff)
+		}
+		So(schema.Tables, ShouldResemble, expected)
+	})
+}
+
+const testRelationshipOnlyMdj = `{
+	"_type": "Project",
+	"_id": "P1",
+	"name": "club",
+	"ownedElements": [
+		{
+			"_type": "ERDDataModel",
+			"_id": "DM1",
+			"_parent": {"$ref": "P1"},
+			"name": "Data Model1",
+			"ownedElements": [
+				{
+					"_type": "ERDEntity",
+					"_id": "E1",
+					"_parent": {"$ref": "DM1"},
+					"name": "team",
+					"columns": [
+						{
+							"_type": "ERDColumn",
+							"_id": "C1",
+							"_parent": {"$ref": "E1"},
+							"name": "id",
+							"type": "BIGINT",
+							"primaryKey": true
+						}
+					]
+				},
+				{
+					"_type": "ERDEntity",
+					"_id": "E2",
+					"_parent": {"$ref": "DM1"},
+					"name": "member",
+					"ownedElements": [
+						{
+							"_type": "ERDRelationship",
+							"_id": "R1",
+							"_parent": {"$ref": "E2"},
+							"end1": {
+								"_type": "ERDRelationshipEnd",
+								"_id": "R1E1",
+								"_parent": {"$ref": "R1"},
+								"reference": {"$ref": "E1"},
+								"cardinality": "1"
+							},
+							"end2": {
+								"_type": "ERDRelationshipEnd",
+								"_id": "R1E2",
+								"_parent": {"$ref": "R1"},
+								"reference": {"$ref": "E2"},
+								"cardinality": "0..*"
+							}
+						}
+					],
+					"columns": [
+						{
+							"_type": "ERDColumn",
+							"_id": "C2",
+							"_parent": {"$ref": "E2"},
+							"name": "id",
+							"type": "BIGINT",
+							"primaryKey": true
+						},
+						{
+							"_type": "ERDColumn",
+							"_id": "C3",
+							"_parent": {"$ref": "E2"},
+							"name": "team_id",
+							"type": "BIGINT",
+							"foreignKey": true
+						}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func TestImporter_ImportJsonWithoutReferenceTo(t *testing.T) {
+	Convey("ImportJson foreign key without referenceTo", t, func() {
+		importer := Importer{}
+		schema, err := importer.ImportJson([]byte(testRelationshipOnlyMdj))
+		So(err, ShouldBeNil)
+
+		member := schema.TableByName("member")
+		So(member, ShouldNotBeNil)
+		So(member.ColumnByName("team_id").Ref, ShouldResemble, &octopus.Reference{
+			Table:        "team",
+			Column:       "id",
+			Relationship: octopus.RefManyToOne,
+		})
+		So(member.ColumnByName("id").Ref, ShouldBeNil)
+	})
+}
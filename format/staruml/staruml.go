@@ -1,5 +1,12 @@
 package staruml
 
+const (
+	// tag names to store column attributes which StarUML ERD does not support.
+	TagAutoIncrement = "autoIncrement"
+	TagDefault       = "default"
+	TagOnUpdate      = "onUpdate"
+)
+
 type Ref struct {
 	Ref string `json:"$ref"`
 }
@@ -12,15 +19,146 @@ type Element struct {
 	Length        interface{} `json:"length"`
 	Type          string      `json:"type"`
 	PrimaryKey    bool        `json:"primaryKey"`
+	ForeignKey    bool        `json:"foreignKey"`
 	Unique        bool        `json:"unique"`
 	Nullable      bool        `json:"nullable"`
 	Documentation string      `json:"documentation"`
 	Head          *Ref        `json:"head"`
-	End1          *Ref        `json:"end1"`
-	End2          *Ref        `json:"end2"`
+	End1          *Element    `json:"end1"`
+	End2          *Element    `json:"end2"`
+	Cardinality   string      `json:"cardinality"`
 	Reference     *Ref        `json:"reference"`
 	ReferenceTo   *Ref        `json:"referenceTo"`
+	Kind          string      `json:"kind"`
+	Value         string      `json:"value"`
+	Checked       bool        `json:"checked"`
 
 	OwnedElements []*Element `json:"ownedElements"`
+	OwnedViews    []*Element `json:"ownedViews"`
 	Columns       []*Element `json:"columns"`
+	Tags          []*Element `json:"tags"`
+}
+
+// Model is a model element to export.
+type Model struct {
+	ElemType      string        `json:"_type"`
+	ID            string        `json:"_id"`
+	Parent        *Ref          `json:"_parent,omitempty"`
+	Name          string        `json:"name"`
+	Documentation string        `json:"documentation,omitempty"`
+	OwnedElements []interface{} `json:"ownedElements,omitempty"`
+}
+
+// Diagram is an ERDDiagram to export.
+type Diagram struct {
+	ElemType       string        `json:"_type"`
+	ID             string        `json:"_id"`
+	Parent         *Ref          `json:"_parent"`
+	Name           string        `json:"name"`
+	DefaultDiagram bool          `json:"defaultDiagram,omitempty"`
+	OwnedViews     []interface{} `json:"ownedViews"`
+}
+
+// Entity is an ERDEntity to export.
+type Entity struct {
+	ElemType      string          `json:"_type"`
+	ID            string          `json:"_id"`
+	Parent        *Ref            `json:"_parent"`
+	Name          string          `json:"name"`
+	Documentation string          `json:"documentation,omitempty"`
+	OwnedElements []*Relationship `json:"ownedElements,omitempty"`
+	Columns       []*Column       `json:"columns,omitempty"`
+}
+
+// Column is an ERDColumn to export.
+type Column struct {
+	ElemType      string `json:"_type"`
+	ID            string `json:"_id"`
+	Parent        *Ref   `json:"_parent"`
+	Name          string `json:"name"`
+	Documentation string `json:"documentation,omitempty"`
+	Tags          []*Tag `json:"tags,omitempty"`
+	Type          string `json:"type"`
+	Length        string `json:"length,omitempty"`
+	PrimaryKey    bool   `json:"primaryKey,omitempty"`
+	ForeignKey    bool   `json:"foreignKey,omitempty"`
+	ReferenceTo   *Ref   `json:"referenceTo,omitempty"`
+	Nullable      bool   `json:"nullable,omitempty"`
+	Unique        bool   `json:"unique,omitempty"`
+}
+
+// Tag is a tag of element to export.
+type Tag struct {
+	ElemType string `json:"_type"`
+	ID       string `json:"_id"`
+	Parent   *Ref   `json:"_parent"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Value    string `json:"value,omitempty"`
+	Checked  bool   `json:"checked,omitempty"`
+}
+
+// Relationship is an ERDRelationship to export.
+type Relationship struct {
+	ElemType string           `json:"_type"`
+	ID       string           `json:"_id"`
+	Parent   *Ref             `json:"_parent"`
+	End1     *RelationshipEnd `json:"end1"`
+	End2     *RelationshipEnd `json:"end2"`
+}
+
+// RelationshipEnd is an ERDRelationshipEnd to export.
+type RelationshipEnd struct {
+	ElemType    string `json:"_type"`
+	ID          string `json:"_id"`
+	Parent      *Ref   `json:"_parent"`
+	Reference   *Ref   `json:"reference"`
+	Cardinality string `json:"cardinality"`
+}
+
+// View is a diagram view to export.
+type View struct {
+	ElemType          string        `json:"_type"`
+	ID                string        `json:"_id"`
+	Parent            *Ref          `json:"_parent"`
+	Model             *Ref          `json:"model,omitempty"`
+	SubViews          []interface{} `json:"subViews,omitempty"`
+	Font              string        `json:"font"`
+	Left              int           `json:"left"`
+	Top               int           `json:"top"`
+	Width             int           `json:"width"`
+	Height            int           `json:"height"`
+	Text              string        `json:"text,omitempty"`
+	NameLabel         *Ref          `json:"nameLabel,omitempty"`
+	ColumnCompartment *Ref          `json:"columnCompartment,omitempty"`
+}
+
+// EdgeView is a relationship view to export.
+type EdgeView struct {
+	ElemType      string        `json:"_type"`
+	ID            string        `json:"_id"`
+	Parent        *Ref          `json:"_parent"`
+	Model         *Ref          `json:"model"`
+	SubViews      []interface{} `json:"subViews"`
+	Font          string        `json:"font"`
+	Head          *Ref          `json:"head"`
+	Tail          *Ref          `json:"tail"`
+	Points        string        `json:"points"`
+	NameLabel     *Ref          `json:"nameLabel"`
+	TailNameLabel *Ref          `json:"tailNameLabel"`
+	HeadNameLabel *Ref          `json:"headNameLabel"`
+}
+
+// EdgeLabelView is a label of relationship view to export.
+type EdgeLabelView struct {
+	ElemType     string  `json:"_type"`
+	ID           string  `json:"_id"`
+	Parent       *Ref    `json:"_parent"`
+	Model        *Ref    `json:"model"`
+	Font         string  `json:"font"`
+	Visible      bool    `json:"visible"`
+	HostEdge     *Ref    `json:"hostEdge"`
+	EdgePosition int     `json:"edgePosition"`
+	Alpha        float64 `json:"alpha"`
+	Distance     int     `json:"distance"`
 }
@@ -0,0 +1,163 @@
+package typescript
+
+import (
+	"fmt"
+	"github.com/gertd/go-pluralize"
+	"github.com/iancoleman/strcase"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"io"
+	"strings"
+	"text/template"
+)
+
+const (
+	// TsTemplate is the default template to generate typescript file.
+	// Template is executed with TplData.
+	TsTemplate = `{{"" -}}
+{{if .Zod}}import { z } from 'zod';
+
+{{end}}
+{{- range .Interfaces}}
+{{- if .Comment}}/** {{.Comment}} */
+{{end -}}
+export interface {{.Name}} {
+{{- range .Fields}}
+  {{- if .Comment}}
+  /** {{.Comment}} */
+  {{- end}}
+  {{.Name}}: {{.Type}}{{if .Nullable}} | null{{end}};
+{{- end}}
+{{- range .Relations}}
+  {{.Name}}?: {{.Type}}{{if .Array}}[]{{end}};
+{{- end}}
+}
+{{- if $.Zod}}
+
+export const {{.Name}}Schema: z.ZodType<{{.Name}}> = z.object({
+{{- range .Fields}}
+  {{.Name}}: {{.Schema}},
+{{- end}}
+{{- range .Relations}}
+  {{.Name}}: {{.Schema}},
+{{- end}}
+});
+{{- end}}
+
+{{end}}`
+)
+
+type Option struct {
+	PrefixMapper   *common.PrefixMapper
+	TableFilter    octopus.TableFilterFn
+	RemovePrefixes []string
+	Zod            bool
+}
+
+// TplData is the data passed to the typescript template.
+type TplData struct {
+	Zod        bool
+	Interfaces []*TsInterface
+}
+
+type Generator struct {
+	schema *octopus.Schema
+	option *Option
+}
+
+func NewGenerator(schema *octopus.Schema, option *Option) *Generator {
+	return &Generator{
+		schema: schema,
+		option: option,
+	}
+}
+
+// InterfaceName returns interface name from table name.
+func (g *Generator) InterfaceName(table *octopus.Table) string {
+	name := table.ClassName
+	if name == "" {
+		tableName := table.Name
+		for _, prefix := range g.option.RemovePrefixes {
+			tableName = strings.TrimPrefix(tableName, prefix)
+		}
+		name = strcase.ToCamel(tableName)
+
+		if prefixMapper := g.option.PrefixMapper; prefixMapper != nil {
+			if prefix := prefixMapper.GetPrefix(table.Group); prefix != "" {
+				name = prefix + name
+			}
+		}
+	}
+	return name
+}
+
+func (g *Generator) Generate(wr io.Writer) error {
+	tableFilter := g.option.TableFilter
+
+	// tables to generate
+	tableNameSet := util.NewStringSet()
+	var tables []*octopus.Table
+	for _, table := range g.schema.Tables {
+		if tableFilter == nil || tableFilter(table) {
+			tables = append(tables, table)
+			tableNameSet.Add(table.Name)
+		}
+	}
+
+	var interfaces []*TsInterface
+	for _, table := range tables {
+		interfaces = append(interfaces, g.newTsInterface(table, tableNameSet))
+	}
+
+	tmpl, err := util.NewTemplate("typescript", TsTemplate, template.FuncMap{})
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(wr, &TplData{
+		Zod:        g.option.Zod,
+		Interfaces: interfaces,
+	})
+}
+
+func (g *Generator) newTsInterface(table *octopus.Table, tableNameSet *util.StringSet) *TsInterface {
+	client := pluralize.NewClient()
+
+	tsInterface := &TsInterface{
+		table:   table,
+		Name:    g.InterfaceName(table),
+		Comment: escapeComment(table.Description),
+	}
+	for _, column := range table.Columns {
+		tsInterface.Fields = append(tsInterface.Fields, NewTsField(column))
+	}
+
+	for _, column := range table.Columns {
+		// reference
+		ref := column.Ref
+		if ref == nil || !tableNameSet.Contains(ref.Table) {
+			continue
+		}
+		refTable := g.schema.TableByName(ref.Table)
+		refType := g.InterfaceName(refTable)
+		refName := strcase.ToLowerCamel(refType)
+		array := ref.Relationship == octopus.RefOneToMany
+		lazySchema := fmt.Sprintf("z.lazy(() => %sSchema)", refType)
+		if array {
+			refName = client.Plural(refName)
+			lazySchema = fmt.Sprintf("z.array(%s)", lazySchema)
+		}
+
+		// references to the same table are distinguished by column name
+		suffix := "By" + strcase.ToCamel(strings.TrimSuffix(column.Name, "_id"))
+		tsInterface.Relations = append(tsInterface.Relations, &TsRelation{
+			Name:   tsInterface.relationName(refName, suffix),
+			Type:   refType,
+			Array:  array,
+			Schema: lazySchema + ".optional()",
+		})
+	}
+
+	return tsInterface
+}
@@ -0,0 +1,271 @@
+package typescript
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lechuckroh/octopus-db-tools/format/common"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	. "github.com/smartystreets/goconvey/convey"
+	"log"
+	"testing"
+)
+
+var testSchema = &octopus.Schema{
+	Tables: []*octopus.Table{
+		{
+			Name:        "tbl_user",
+			Description: "user table",
+			Group:       "common",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+					NotNull:         true,
+				},
+				{
+					Name:        "name",
+					Type:        octopus.ColTypeVarchar,
+					Size:        40,
+					NotNull:     true,
+					Description: "login name",
+				},
+				{
+					Name:    "role",
+					Type:    octopus.ColTypeEnum,
+					Values:  []string{"admin", "user"},
+					NotNull: true,
+				},
+				{
+					Name:   "tags",
+					Type:   octopus.ColTypeSet,
+					Values: []string{"a", "b"},
+				},
+				{
+					Name:  "point",
+					Type:  octopus.ColTypeDecimal,
+					Size:  10,
+					Scale: 2,
+				},
+				{
+					Name: "1_level",
+					Type: octopus.ColTypeInt8,
+				},
+				{
+					Name:    "group_id",
+					Type:    octopus.ColTypeInt64,
+					NotNull: true,
+					Ref: &octopus.Reference{
+						Table:        "group",
+						Column:       "id",
+						Relationship: octopus.RefManyToOne,
+					},
+				},
+				{
+					Name:    "created_at",
+					Type:    octopus.ColTypeDateTime,
+					NotNull: true,
+				},
+			},
+		},
+		{
+			Name:  "group",
+			Group: "admin",
+			Columns: []*octopus.Column{
+				{
+					Name:            "id",
+					Type:            octopus.ColTypeInt64,
+					PrimaryKey:      true,
+					AutoIncremental: true,
+					NotNull:         true,
+				},
+				{
+					Name: "data",
+					Type: octopus.ColTypeJSON,
+				},
+			},
+		},
+	},
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	Convey("Generate", t, func() {
+		option := &Option{
+			PrefixMapper:   common.NewPrefixMapper("admin:Admin"),
+			RemovePrefixes: []string{"tbl_"},
+		}
+		expected := `/** user table */
+export interface User {
+  id: number;
+  /** login name */
+  name: string;
+  role: 'admin' | 'user';
+  tags: Array<'a' | 'b'> | null;
+  point: string | null;
+  '1Level': number | null;
+  groupId: number;
+  createdAt: string;
+  adminGroup?: AdminGroup;
+}
+
+export interface AdminGroup {
+  id: number;
+  data: unknown | null;
+}
+
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(testSchema, option)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_GenerateZod(t *testing.T) {
+	Convey("Generate with zod schema", t, func() {
+		option := &Option{
+			PrefixMapper:   common.NewPrefixMapper(""),
+			RemovePrefixes: []string{"tbl_"},
+			TableFilter: func(table *octopus.Table) bool {
+				return table.Group == "common"
+			},
+			Zod: true,
+		}
+		expected := `import { z } from 'zod';
+
+/** user table */
+export interface User {
+  id: number;
+  /** login name */
+  name: string;
+  role: 'admin' | 'user';
+  tags: Array<'a' | 'b'> | null;
+  point: string | null;
+  '1Level': number | null;
+  groupId: number;
+  createdAt: string;
+}
+
+export const UserSchema: z.ZodType<User> = z.object({
+  id: z.number().int(),
+  name: z.string().max(40),
+  role: z.enum(['admin', 'user']),
+  tags: z.array(z.enum(['a', 'b'])).nullable(),
+  point: z.string().regex(/^-?\d{1,8}(\.\d{1,2})?$/).nullable(),
+  '1Level': z.number().int().min(-128).max(127).nullable(),
+  groupId: z.number().int(),
+  createdAt: z.string(),
+});
+
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(testSchema, option)
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_Escape(t *testing.T) {
+	Convey("Escape string literals, comments and decimal without integer digits", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name:        "rate",
+					Description: "rate */ table",
+					Columns: []*octopus.Column{
+						{Name: "ratio", Type: octopus.ColTypeDecimal, Size: 3, Scale: 3, NotNull: true},
+						{Name: "mood", Type: octopus.ColTypeEnum, Values: []string{"it's", `a\b`}, NotNull: true, Description: "see */"},
+					},
+				},
+			},
+		}
+		expected := `import { z } from 'zod';
+
+/** rate *\/ table */
+export interface Rate {
+  ratio: string;
+  /** see *\/ */
+  mood: 'it\'s' | 'a\\b';
+}
+
+export const RateSchema: z.ZodType<Rate> = z.object({
+  ratio: z.string().regex(/^-?0?(\.\d{1,3})?$/),
+  mood: z.enum(['it\'s', 'a\\b']),
+});
+
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(schema, &Option{Zod: true})
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
+
+func TestGenerator_RelationNames(t *testing.T) {
+	Convey("References to the same table", t, func() {
+		schema := &octopus.Schema{
+			Tables: []*octopus.Table{
+				{
+					Name: "user",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+					},
+				},
+				{
+					Name: "post",
+					Columns: []*octopus.Column{
+						{Name: "id", Type: octopus.ColTypeInt64, PrimaryKey: true, NotNull: true},
+						{Name: "author_id", Type: octopus.ColTypeInt64, NotNull: true, Ref: &octopus.Reference{Table: "user", Column: "id"}},
+						{Name: "editor_id", Type: octopus.ColTypeInt64, Ref: &octopus.Reference{Table: "user", Column: "id"}},
+					},
+				},
+			},
+		}
+		expected := `export interface User {
+  id: number;
+}
+
+export interface Post {
+  id: number;
+  authorId: number;
+  editorId: number | null;
+  user?: User;
+  userByEditor?: User;
+}
+
+`
+
+		buf := new(bytes.Buffer)
+		gen := NewGenerator(schema, &Option{})
+		if err := gen.Generate(buf); err != nil {
+			t.Error(err)
+		}
+		actual := buf.String()
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			log.Println(diff)
+		}
+		So(actual, ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,263 @@
+package typescript
+
+import (
+	"fmt"
+	"github.com/lechuckroh/octopus-db-tools/format/octopus"
+	"github.com/lechuckroh/octopus-db-tools/util"
+	"regexp"
+	"strings"
+)
+
+type TsInterface struct {
+	table     *octopus.Table
+	Name      string
+	Comment   string
+	Fields    []*TsField
+	Relations []*TsRelation
+}
+
+func (i *TsInterface) hasMemberName(name string) bool {
+	for _, field := range i.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	for _, relation := range i.Relations {
+		if relation.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// relationName returns unused relation name.
+// suffix is appended to the name if the name is already used.
+func (i *TsInterface) relationName(name string, suffix string) string {
+	if i.hasMemberName(name) {
+		name = name + suffix
+	}
+	for i.hasMemberName(name) {
+		name = name + "Ref"
+	}
+	return name
+}
+
+type TsField struct {
+	Column   *octopus.Column
+	Name     string
+	Type     string
+	Nullable bool
+	Comment  string
+	Schema   string
+}
+
+type TsRelation struct {
+	Name   string
+	Type   string
+	Array  bool
+	Schema string
+}
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func NewTsField(column *octopus.Column) *TsField {
+	fieldName, _ := util.ToLowerCamel(column.Name)
+	if !identifierRegexp.MatchString(fieldName) {
+		fieldName = quoteString(fieldName)
+	}
+
+	return &TsField{
+		Column:   column,
+		Name:     fieldName,
+		Type:     toTsType(column),
+		Nullable: !column.NotNull,
+		Comment:  escapeComment(column.Description),
+		Schema:   toZodSchema(column),
+	}
+}
+
+// toTsType returns typescript type of column.
+func toTsType(column *octopus.Column) string {
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		fallthrough
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		return "string"
+	case octopus.ColTypeBoolean:
+		return "boolean"
+	case octopus.ColTypeBit:
+		fallthrough
+	case octopus.ColTypeYear:
+		fallthrough
+	case octopus.ColTypeInt8:
+		fallthrough
+	case octopus.ColTypeInt16:
+		fallthrough
+	case octopus.ColTypeInt24:
+		fallthrough
+	case octopus.ColTypeInt32:
+		fallthrough
+	case octopus.ColTypeInt64:
+		fallthrough
+	case octopus.ColTypeFloat:
+		fallthrough
+	case octopus.ColTypeDouble:
+		return "number"
+	case octopus.ColTypeDecimal:
+		// decimal is transferred as string to keep precision
+		return "string"
+	case octopus.ColTypeDate:
+		fallthrough
+	case octopus.ColTypeDateTime:
+		fallthrough
+	case octopus.ColTypeTime:
+		return "string"
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		// base64 encoded
+		return "string"
+	case octopus.ColTypeEnum:
+		return toUnionType(column.Values)
+	case octopus.ColTypeSet:
+		return fmt.Sprintf("Array<%s>", toUnionType(column.Values))
+	default:
+		return "unknown"
+	}
+}
+
+// toUnionType returns string-literal union type.
+func toUnionType(values []string) string {
+	if len(values) == 0 {
+		return "string"
+	}
+	return quoteJoin(values, " | ")
+}
+
+// toZodSchema returns zod schema of column.
+func toZodSchema(column *octopus.Column) string {
+	var schema string
+
+	switch strings.ToLower(column.Type) {
+	case octopus.ColTypeChar:
+		fallthrough
+	case octopus.ColTypeVarchar:
+		schema = "z.string()"
+		if column.Size > 0 {
+			schema += fmt.Sprintf(".max(%d)", column.Size)
+		}
+	case octopus.ColTypeText8:
+		fallthrough
+	case octopus.ColTypeText16:
+		fallthrough
+	case octopus.ColTypeText24:
+		fallthrough
+	case octopus.ColTypeText32:
+		schema = "z.string()"
+	case octopus.ColTypeBoolean:
+		schema = "z.boolean()"
+	case octopus.ColTypeInt8:
+		schema = "z.number().int().min(-128).max(127)"
+	case octopus.ColTypeInt16:
+		schema = "z.number().int().min(-32768).max(32767)"
+	case octopus.ColTypeInt24:
+		schema = "z.number().int().min(-8388608).max(8388607)"
+	case octopus.ColTypeInt32:
+		schema = "z.number().int().min(-2147483648).max(2147483647)"
+	case octopus.ColTypeBit:
+		fallthrough
+	case octopus.ColTypeYear:
+		fallthrough
+	case octopus.ColTypeInt64:
+		schema = "z.number().int()"
+	case octopus.ColTypeFloat:
+		fallthrough
+	case octopus.ColTypeDouble:
+		schema = "z.number()"
+	case octopus.ColTypeDecimal:
+		schema = "z.string()"
+		if column.Size > 0 {
+			intDigits := int(column.Size) - int(column.Scale)
+			if column.Scale > 0 && intDigits <= 0 {
+				// no integer digits
+				schema += fmt.Sprintf(".regex(/^-?0?(\\.\\d{1,%d})?$/)", column.Scale)
+			} else if column.Scale > 0 {
+				schema += fmt.Sprintf(".regex(/^-?\\d{1,%d}(\\.\\d{1,%d})?$/)", intDigits, column.Scale)
+			} else {
+				schema += fmt.Sprintf(".regex(/^-?\\d{1,%d}$/)", intDigits)
+			}
+		}
+	case octopus.ColTypeDate:
+		fallthrough
+	case octopus.ColTypeDateTime:
+		fallthrough
+	case octopus.ColTypeTime:
+		fallthrough
+	case octopus.ColTypeBinary:
+		fallthrough
+	case octopus.ColTypeVarbinary:
+		fallthrough
+	case octopus.ColTypeBlob8:
+		fallthrough
+	case octopus.ColTypeBlob16:
+		fallthrough
+	case octopus.ColTypeBlob24:
+		fallthrough
+	case octopus.ColTypeBlob32:
+		schema = "z.string()"
+	case octopus.ColTypeEnum:
+		schema = toZodEnum(column.Values)
+	case octopus.ColTypeSet:
+		schema = fmt.Sprintf("z.array(%s)", toZodEnum(column.Values))
+	default:
+		schema = "z.unknown()"
+	}
+
+	if !column.NotNull {
+		schema += ".nullable()"
+	}
+	return schema
+}
+
+func toZodEnum(values []string) string {
+	if len(values) == 0 {
+		return "z.string()"
+	}
+	return fmt.Sprintf("z.enum([%s])", quoteJoin(values, ", "))
+}
+
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+// quoteString returns single-quoted string literal.
+func quoteString(s string) string {
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+func quoteJoin(values []string, sep string) string {
+	var quoted []string
+	for _, value := range values {
+		quoted = append(quoted, quoteString(value))
+	}
+	return strings.Join(quoted, sep)
+}
+
+// escapeComment escapes the end of comment in s.
+func escapeComment(s string) string {
+	return strings.ReplaceAll(s, "*/", "*\\/")
+}
@@ -1,20 +1,33 @@
 package main
 
 import (
+	"github.com/lechuckroh/octopus-db-tools/format/avro"
 	"github.com/lechuckroh/octopus-db-tools/format/dbml"
 	"github.com/lechuckroh/octopus-db-tools/format/diff"
+	"github.com/lechuckroh/octopus-db-tools/format/dot"
+	"github.com/lechuckroh/octopus-db-tools/format/drawio"
 	"github.com/lechuckroh/octopus-db-tools/format/gorm"
 	"github.com/lechuckroh/octopus-db-tools/format/graphql"
+	"github.com/lechuckroh/octopus-db-tools/format/htmldoc"
 	"github.com/lechuckroh/octopus-db-tools/format/jpa"
+	"github.com/lechuckroh/octopus-db-tools/format/jsonschema"
 	"github.com/lechuckroh/octopus-db-tools/format/liquibase"
+	"github.com/lechuckroh/octopus-db-tools/format/markdown"
+	"github.com/lechuckroh/octopus-db-tools/format/mermaid"
 	"github.com/lechuckroh/octopus-db-tools/format/mysql"
 	"github.com/lechuckroh/octopus-db-tools/format/octopus"
 	"github.com/lechuckroh/octopus-db-tools/format/ojson"
+	"github.com/lechuckroh/octopus-db-tools/format/openapi"
 	"github.com/lechuckroh/octopus-db-tools/format/plantuml"
 	"github.com/lechuckroh/octopus-db-tools/format/protobuf"
+	"github.com/lechuckroh/octopus-db-tools/format/pydantic"
 	"github.com/lechuckroh/octopus-db-tools/format/quickdbd"
+	"github.com/lechuckroh/octopus-db-tools/format/rust"
+	"github.com/lechuckroh/octopus-db-tools/format/seed"
 	"github.com/lechuckroh/octopus-db-tools/format/sqlalchemy"
+	"github.com/lechuckroh/octopus-db-tools/format/sqlc"
 	"github.com/lechuckroh/octopus-db-tools/format/staruml"
+	"github.com/lechuckroh/octopus-db-tools/format/typescript"
 	"github.com/lechuckroh/octopus-db-tools/format/xlsx"
 	"github.com/urfave/cli/v2"
 	"log"
@@ -35,10 +48,23 @@ func initCommand() *cli.Command {
 	}
 }
 
+func seedCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "seed",
+		Action: seed.Action,
+		Flags:  seed.CliFlags,
+	}
+}
+
 func importCommand() *cli.Command {
 	return &cli.Command{
 		Name: "import",
 		Subcommands: []*cli.Command{
+			{
+				Name:   "dbml",
+				Action: dbml.ImportAction,
+				Flags:  dbml.ImportCliFlags,
+			},
 			{
 				Name:   "mysql",
 				Action: mysql.ImportAction,
@@ -49,6 +75,11 @@ func importCommand() *cli.Command {
 				Action: ojson.ImportAction,
 				Flags:  ojson.ImportCliFlags,
 			},
+			{
+				Name:   "quickdbd",
+				Action: quickdbd.ImportAction,
+				Flags:  quickdbd.ImportCliFlags,
+			},
 			{
 				Name:   "staruml",
 				Action: staruml.ImportAction,
@@ -72,11 +103,41 @@ func exportCommand() *cli.Command {
 				Action: dbml.ExportAction,
 				Flags:  dbml.ExportCliFlags,
 			},
+			{
+				Name:   "dot",
+				Action: dot.ExportAction,
+				Flags:  dot.ExportCliFlags,
+			},
+			{
+				Name:   "drawio",
+				Action: drawio.ExportAction,
+				Flags:  drawio.ExportCliFlags,
+			},
+			{
+				Name:   "jsonschema",
+				Action: jsonschema.ExportAction,
+				Flags:  jsonschema.ExportCliFlags,
+			},
+			{
+				Name:   "md",
+				Action: markdown.ExportAction,
+				Flags:  markdown.ExportCliFlags,
+			},
 			{
 				Name:   "quickdbd",
 				Action: quickdbd.ExportAction,
 				Flags:  quickdbd.ExportCliFlags,
 			},
+			{
+				Name:   "staruml",
+				Action: staruml.ExportAction,
+				Flags:  staruml.ExportCliFlags,
+			},
+			{
+				Name:   "mermaid",
+				Action: mermaid.ExportAction,
+				Flags:  mermaid.ExportCliFlags,
+			},
 			{
 				Name:   "mysql",
 				Action: mysql.ExportAction,
@@ -95,6 +156,11 @@ func generateCommand() *cli.Command {
 	return &cli.Command{
 		Name: "generate",
 		Subcommands: []*cli.Command{
+			{
+				Name:   "avro",
+				Action: avro.Action,
+				Flags:  avro.CliFlags,
+			},
 			{
 				Name:   "gorm",
 				Action: gorm.Action,
@@ -105,6 +171,11 @@ func generateCommand() *cli.Command {
 				Action: graphql.Action,
 				Flags:  graphql.CliFlags,
 			},
+			{
+				Name:   "java",
+				Action: jpa.JavaAction,
+				Flags:  jpa.JavaCliFlags,
+			},
 			{
 				Name:   "kt",
 				Action: jpa.KotlinAction,
@@ -115,6 +186,11 @@ func generateCommand() *cli.Command {
 				Action: liquibase.Action,
 				Flags:  liquibase.CliFlags,
 			},
+			{
+				Name:   "openapi",
+				Action: openapi.Action,
+				Flags:  openapi.CliFlags,
+			},
 			{
 				Name:   "plantuml",
 				Action: plantuml.Action,
@@ -125,11 +201,44 @@ func generateCommand() *cli.Command {
 				Action: protobuf.Action,
 				Flags:  protobuf.CliFlags,
 			},
+			{
+				Name:   "pydantic",
+				Action: pydantic.Action,
+				Flags:  pydantic.CliFlags,
+			},
+			{
+				Name:   "rust",
+				Action: rust.Action,
+				Flags:  rust.CliFlags,
+			},
 			{
 				Name:   "sqlalchemy",
 				Action: sqlalchemy.Action,
 				Flags:  sqlalchemy.CliFlags,
 			},
+			{
+				Name:   "sqlc",
+				Action: sqlc.Action,
+				Flags:  sqlc.CliFlags,
+			},
+			{
+				Name:   "ts",
+				Action: typescript.Action,
+				Flags:  typescript.CliFlags,
+			},
+		},
+	}
+}
+
+func docCommand() *cli.Command {
+	return &cli.Command{
+		Name: "doc",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "html",
+				Action: htmldoc.Action,
+				Flags:  htmldoc.CliFlags,
+			},
 		},
 	}
 }
@@ -138,6 +247,11 @@ func diffCommand() *cli.Command {
 	return &cli.Command{
 		Name: "diff",
 		Subcommands: []*cli.Command{
+			{
+				Name:   "alembic",
+				Action: diff.AlembicAction,
+				Flags:  diff.AlembicCliFlags,
+			},
 			{
 				Name:   "flyway",
 				Action: diff.FlywayAction,
@@ -173,10 +287,12 @@ func main() {
 	cliApp.Usage = "octopus-db-tools"
 	cliApp.Commands = []*cli.Command{
 		diffCommand(),
+		docCommand(),
 		initCommand(),
 		importCommand(),
 		exportCommand(),
 		generateCommand(),
+		seedCommand(),
 	}
 
 	sort.Sort(cli.FlagsByName(cliApp.Flags))
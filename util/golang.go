@@ -0,0 +1,42 @@
+package util
+
+var goReservedWords = [...]string{
+	"break",
+	"case",
+	"chan",
+	"const",
+	"continue",
+	"default",
+	"defer",
+	"else",
+	"fallthrough",
+	"for",
+	"func",
+	"go",
+	"goto",
+	"if",
+	"import",
+	"interface",
+	"map",
+	"package",
+	"range",
+	"return",
+	"select",
+	"struct",
+	"switch",
+	"type",
+	"var",
+}
+
+var goReservedWordSet *StringSet
+
+// IsGoReservedWord returns true if 's' is reserved word in golang.
+func IsGoReservedWord(s string) bool {
+	if goReservedWordSet == nil {
+		goReservedWordSet = NewStringSet()
+		for _, word := range goReservedWords {
+			goReservedWordSet.Add(word)
+		}
+	}
+	return goReservedWordSet.Contains(s)
+}
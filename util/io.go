@@ -5,9 +5,13 @@ import (
 	"log"
 	"os"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+var unsafeFilenameRegex = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
 // MkdirPackage creates nested package directories.
 func MkdirPackage(basedir, pkgName string) (string, error) {
 	if pkgName == "" {
@@ -50,3 +54,52 @@ func Mkdir(dir string) (string, error) {
 	}
 	return dir, nil
 }
+
+// ReadTemplateText reads template text from filename.
+// returns defaultText if filename is empty.
+func ReadTemplateText(filename string, defaultText string) (string, error) {
+	if filename == "" {
+		return defaultText, nil
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SafeFilename replaces characters not allowed in filename with '_'.
+func SafeFilename(name string) string {
+	return unsafeFilenameRegex.ReplaceAllString(name, "_")
+}
+
+// GroupFilenames returns safe filename without extension of each group.
+// Empty group is named defaultName, and filenames duplicated or in reserved get numeric suffix.
+// Filenames are compared case-insensitively.
+func GroupFilenames(groups []string, defaultName string, reserved ...string) map[string]string {
+	filenames := make(map[string]string)
+	filenameSet := NewStringSet()
+	for _, name := range reserved {
+		filenameSet.Add(strings.ToLower(name))
+	}
+	add := func(group, filename string) {
+		name := filename
+		for suffix := 2; filenameSet.Contains(strings.ToLower(name)); suffix++ {
+			name = filename + "_" + strconv.Itoa(suffix)
+		}
+		filenameSet.Add(strings.ToLower(name))
+		filenames[group] = name
+	}
+	// default name is reserved for empty group
+	for _, group := range groups {
+		if group == "" {
+			add(group, defaultName)
+		}
+	}
+	for _, group := range groups {
+		if group != "" {
+			add(group, SafeFilename(group))
+		}
+	}
+	return filenames
+}
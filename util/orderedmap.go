@@ -0,0 +1,71 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"gopkg.in/yaml.v2"
+)
+
+// OrderedMap is a map which keeps insertion order of keys when marshalled.
+type OrderedMap struct {
+	items yaml.MapSlice
+}
+
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{}
+}
+
+func (m *OrderedMap) Set(key string, value interface{}) {
+	m.items = append(m.items, yaml.MapItem{Key: key, Value: value})
+}
+
+func (m *OrderedMap) Contains(key string) bool {
+	for _, item := range m.items {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *OrderedMap) Len() int {
+	return len(m.items)
+}
+
+func (m *OrderedMap) MarshalYAML() (interface{}, error) {
+	return m.items, nil
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString("{")
+	for i, item := range m.items {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := marshalJSON(item.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalJSON(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// marshalJSON marshals v without escaping HTML characters.
+func marshalJSON(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,68 @@
+package util
+
+var rustReservedWords = [...]string{
+	"abstract",
+	"as",
+	"async",
+	"await",
+	"become",
+	"box",
+	"break",
+	"const",
+	"continue",
+	"crate",
+	"do",
+	"dyn",
+	"else",
+	"enum",
+	"extern",
+	"false",
+	"final",
+	"fn",
+	"for",
+	"if",
+	"impl",
+	"in",
+	"let",
+	"loop",
+	"macro",
+	"match",
+	"mod",
+	"move",
+	"mut",
+	"override",
+	"priv",
+	"pub",
+	"ref",
+	"return",
+	"self",
+	"Self",
+	"static",
+	"struct",
+	"super",
+	"trait",
+	"true",
+	"try",
+	"type",
+	"typeof",
+	"unsafe",
+	"unsized",
+	"use",
+	"virtual",
+	"where",
+	"while",
+	"yield",
+}
+
+var rustReservedWordSet *StringSet
+
+// IsRustReservedWord returns true if 's' is reserved word in rust.
+func IsRustReservedWord(s string) bool {
+	if rustReservedWordSet == nil {
+		rustReservedWordSet = NewStringSet()
+		for _, word := range rustReservedWords {
+			rustReservedWordSet.Add(word)
+		}
+	}
+	return rustReservedWordSet.Contains(s)
+}
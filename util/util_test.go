@@ -57,3 +57,20 @@ func TestToLowerSnake(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupFilenames(t *testing.T) {
+	filenames := GroupFilenames([]string{"default", "../evil", "", "Admin", "admin", "a b"}, "default")
+	expected := map[string]string{
+		"":        "default",
+		"default": "default_2",
+		"../evil": ".._evil",
+		"Admin":   "Admin",
+		"admin":   "admin_2",
+		"a b":     "a_b",
+	}
+	for group, filename := range expected {
+		if filenames[group] != filename {
+			t.Errorf("GroupFilenames failed: %s -> %s, expected: %s", group, filenames[group], filename)
+		}
+	}
+}